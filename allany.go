@@ -0,0 +1,503 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// elementLoop is a range loop over a container whose every element an
+// assertion is about.
+type elementLoop struct {
+	loop *ast.RangeStmt
+	// v is the loop's value variable, and itemsText the container as written.
+	v         *types.Var
+	itemsText string
+}
+
+// matchElementLoop matches loop as a range over a slice, array or map that
+// declares its value variable and nothing else. A key in use could reach the
+// assertion, and a value assigned to a variable declared outside the loop
+// outlives it; qt.All and qt.Any leave neither behind.
+func matchElementLoop(pass *analysis.Pass, loop *ast.RangeStmt) (elementLoop, bool) {
+	if loop.Tok != token.DEFINE || loop.Value == nil || len(loop.Body.List) != 1 {
+		return elementLoop{}, false
+	}
+	if loop.Key != nil {
+		if key, ok := loop.Key.(*ast.Ident); !ok || key.Name != "_" {
+			return elementLoop{}, false
+		}
+	}
+	ident, ok := loop.Value.(*ast.Ident)
+	if !ok {
+		return elementLoop{}, false
+	}
+	v, ok := pass.TypesInfo.Defs[ident].(*types.Var)
+	if !ok {
+		return elementLoop{}, false
+	}
+	typ := pass.TypesInfo.TypeOf(loop.X)
+	if typ == nil {
+		return elementLoop{}, false
+	}
+	switch typ.Underlying().(type) {
+	case *types.Slice, *types.Array, *types.Map:
+	default:
+		return elementLoop{}, false
+	}
+	itemsText, ok := formatExpr(pass, loop.X)
+	if !ok {
+		return elementLoop{}, false
+	}
+	return elementLoop{loop: loop, v: v, itemsText: itemsText}, true
+}
+
+// v2Quantifier returns the go-quicktest/qt function applying a checker to
+// every element of the loop's container, or to any one of them: SliceAll or
+// MapAll, SliceAny or MapAny. There is none for an array.
+func (l elementLoop) v2Quantifier(pass *analysis.Pass, all bool) (string, bool) {
+	word := "Any"
+	if all {
+		word = "All"
+	}
+	switch pass.TypesInfo.TypeOf(l.loop.X).Underlying().(type) {
+	case *types.Slice:
+		return "Slice" + word, true
+	case *types.Map:
+		return "Map" + word, true
+	}
+	return "", false
+}
+
+// v2Func renders the head of the func literal a go-quicktest/qt quantifier
+// takes, up to the checker it returns, with the loop's value variable as its
+// parameter. The element type has to be nameable at the loop.
+func (l elementLoop) v2Func(pass *analysis.Pass, qtAlias string) (string, bool) {
+	typeText, ok := typeTextAt(pass, l.v.Type(), l.loop.Pos())
+	if !ok {
+		return "", false
+	}
+	return "func(" + l.v.Name() + " " + typeText + ") " + qtAlias + ".Checker { return ", true
+}
+
+// typeTextAt renders typ as source written at pos, qualifying a type from
+// another package by the name the file imports that package under. It fails
+// when the file does not import one of the packages typ refers to, or when
+// the name is shadowed at pos.
+func typeTextAt(pass *analysis.Pass, typ types.Type, pos token.Pos) (string, bool) {
+	file := fileOf(pass, pos)
+	if file == nil {
+		return "", false
+	}
+	ok := true
+	text := types.TypeString(typ, func(pkg *types.Package) string {
+		if pkg == pass.Pkg {
+			return ""
+		}
+		name := importedPkgName(pass, file, pkg.Path())
+		if name == "" || !packageQualifies(pass, name, pkg.Path(), pos) {
+			ok = false
+		}
+		return name
+	})
+	return text, ok
+}
+
+// callsOutsideQuicktest reports whether any of exprs calls a function other
+// than one of either quicktest package, such as qt.Commentf. Conversions are
+// not calls.
+func callsOutsideQuicktest(pass *analysis.Pass, exprs []ast.Expr) bool {
+	found := false
+	for _, expr := range exprs {
+		ast.Inspect(expr, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return !found
+			}
+			if tv, ok := pass.TypesInfo.Types[call.Fun]; ok && tv.IsType() {
+				return true
+			}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok &&
+				(isPackageQualified(pass, sel) || isQualifiedBy(pass, sel, quicktestV2PkgPath)) {
+				return true
+			}
+			found = true
+			return false
+		})
+	}
+	return found
+}
+
+// usesAny reports whether any of exprs uses obj.
+func usesAny(pass *analysis.Pass, exprs []ast.Expr, obj types.Object) bool {
+	for _, expr := range exprs {
+		if usesObject(pass, expr, obj) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAllAnyPattern checks for a range loop asserting the same thing of each
+// element of a container, and suggests qt.All; and for a loop setting a flag
+// when some element satisfies a condition that is then asserted with
+// qt.IsTrue, and suggests qt.Any. In go-quicktest/qt the two are SliceAll and
+// MapAll, SliceAny and MapAny, which take the checker as a func literal of
+// the element.
+//
+// An assertion failing inside the loop says nothing of which element it was
+// checking, and one made with Check fails once for every element; qt.All
+// reports the index or key of the element that failed. The flag's assertion
+// says only that it was false, where qt.Any prints the container.
+func (a *analyzer) checkAllAnyPattern(pass *analysis.Pass, insp *inspector.Inspector) {
+	insp.Preorder([]ast.Node{(*ast.BlockStmt)(nil)}, func(n ast.Node) {
+		block := n.(*ast.BlockStmt)
+		for i, stmt := range block.List {
+			loop, ok := stmt.(*ast.RangeStmt)
+			if !ok {
+				continue
+			}
+			if i > 0 && i+1 < len(block.List) && a.checkAnyLoop(pass, block.List[i-1], loop, block.List[i+1]) {
+				continue
+			}
+			a.checkAllLoop(pass, loop)
+		}
+	})
+}
+
+// checkAllLoop reports loop when its body is a single assertion about its
+// value variable whose want and comments are the same for every element.
+//
+// frankban/quicktest's All applies a checker to the elements themselves, so
+// the assertion's got must be the value variable, not a field of it; the
+// go-quicktest/qt checker is built per element and may check anything of it.
+// The fix is best-effort when the assertion is made with Check, since qt.All
+// stops at the first element that fails, or when a frankban/quicktest want or
+// comment calls a function, which now runs once rather than once per
+// element.
+func (a *analyzer) checkAllLoop(pass *analysis.Pass, loop *ast.RangeStmt) {
+	l, ok := matchElementLoop(pass, loop)
+	if !ok {
+		return
+	}
+	exprStmt, ok := loop.Body.List[0].(*ast.ExprStmt)
+	if !ok {
+		return
+	}
+	call, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok || call.Ellipsis.IsValid() {
+		return
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	stable := sel.Sel.Name == "Assert"
+
+	if checker, ok := getV2Checker(pass, call); ok {
+		inner := checker
+		for inner.name == "Not" {
+			if inner, ok = unwrapV2Not(pass, inner); !ok {
+				return
+			}
+		}
+		if len(inner.call.Args) == 0 || !usesObject(pass, inner.call.Args[0], l.v) ||
+			usesAny(pass, inner.call.Args[1:], l.v) || usesAny(pass, call.Args[2:], l.v) {
+			return
+		}
+		stable = stable && !callsOutsideQuicktest(pass, call.Args[2:])
+		a.reportV2AllLoop(pass, l, exprStmt, checker, stable)
+		return
+	}
+
+	if !isQuicktestAssertion(pass, call) {
+		return
+	}
+	args := call.Args
+	if isPackageQualified(pass, sel) && len(args) > 0 {
+		args = args[1:]
+	}
+	if len(args) < 2 {
+		return
+	}
+	c, ok := parseV1Checker(pass, args[1])
+	if !ok || !usesObject(pass, args[0], l.v) || usesAny(pass, args[1:], l.v) {
+		return
+	}
+	qtPkgIdent, ok := c.sels[0].X.(*ast.Ident)
+	if !ok {
+		return
+	}
+	var extra []ast.Expr
+	for c := c; c != nil; c = c.inner {
+		extra = append(extra, c.extra...)
+	}
+	stable = stable && !callsOutsideQuicktest(pass, append(extra, args[2:]...))
+
+	diag := analysis.Diagnostic{
+		Pos: loop.Pos(),
+		End: loop.End(),
+		Message: fmt.Sprintf("qtlint: use qt.All(%s) instead of a loop asserting on each element; qt.All reports which element failed",
+			c.schematic()),
+	}
+	got, ok := stripParens(args[0]).(*ast.Ident)
+	if !ok || pass.TypesInfo.Uses[got] != l.v {
+		gotText, _ := formatExpr(pass, args[0])
+		diag.Message += fmt.Sprintf("; no fix: the loop checks %s rather than the element itself", gotText)
+		pass.Report(diag)
+		return
+	}
+	if stable || !a.onlyStableFixes {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Replace with qt.All",
+			TextEdits: []analysis.TextEdit{
+				{Pos: loop.Pos(), End: exprStmt.Pos()},
+				{Pos: args[0].Pos(), End: args[0].End(), NewText: []byte(l.itemsText)},
+				{Pos: args[1].Pos(), End: args[1].Pos(), NewText: []byte(qtPkgIdent.Name + ".All(")},
+				{Pos: args[1].End(), End: args[1].End(), NewText: []byte(")")},
+				{Pos: exprStmt.End(), End: loop.End()},
+			},
+		}}
+	}
+	pass.Report(diag)
+}
+
+func (a *analyzer) reportV2AllLoop(pass *analysis.Pass, l elementLoop, exprStmt *ast.ExprStmt, checker v2Checker, stable bool) {
+	quantifier, ok := l.v2Quantifier(pass, true)
+	diag := analysis.Diagnostic{Pos: l.loop.Pos(), End: l.loop.End()}
+	if !ok {
+		diag.Message = "qtlint: use qt.SliceAll instead of a loop asserting on each element; qt.SliceAll reports which element failed" +
+			"; no fix: qt.SliceAll takes a slice, not an array"
+		pass.Report(diag)
+		return
+	}
+	diag.Message = fmt.Sprintf("qtlint: use qt.%s instead of a loop asserting on each element; qt.%s reports which element failed",
+		quantifier, quantifier)
+	head, ok := l.v2Func(pass, checker.qtAlias)
+	if ok && (stable || !a.onlyStableFixes) {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Replace with qt." + quantifier,
+			TextEdits: []analysis.TextEdit{
+				{Pos: l.loop.Pos(), End: exprStmt.Pos()},
+				{
+					Pos:     checker.call.Pos(),
+					End:     checker.call.Pos(),
+					NewText: []byte(checker.qtAlias + "." + quantifier + "(" + l.itemsText + ", " + head),
+				},
+				{Pos: checker.call.End(), End: checker.call.End(), NewText: []byte(" })")},
+				{Pos: exprStmt.End(), End: l.loop.End()},
+			},
+		}}
+	}
+	pass.Report(diag)
+}
+
+// foundFlag is a loop searching a container for an element satisfying a
+// condition, which records finding one in a flag.
+type foundFlag struct {
+	l    elementLoop
+	flag types.Object
+	// check is what the condition asserts of an element, and decl the
+	// statement declaring the flag.
+	check failureCheck
+	decl  ast.Stmt
+}
+
+// matchFoundFlag matches decl and loop as
+//
+//	found := false
+//	for _, v := range items {
+//		if cond {
+//			found = true
+//			break
+//		}
+//	}
+//
+// with or without the break, and with the flag declared with var instead.
+// The condition is matched the way a failure block's is.
+func matchFoundFlag(pass *analysis.Pass, decl ast.Stmt, loop *ast.RangeStmt) (foundFlag, bool) {
+	l, ok := matchElementLoop(pass, loop)
+	if !ok {
+		return foundFlag{}, false
+	}
+	flag, ok := falseFlag(pass, decl)
+	if !ok {
+		return foundFlag{}, false
+	}
+	ifStmt, ok := loop.Body.List[0].(*ast.IfStmt)
+	if !ok || ifStmt.Init != nil || ifStmt.Else != nil {
+		return foundFlag{}, false
+	}
+	body := ifStmt.Body.List
+	if len(body) == 2 {
+		if branch, ok := body[1].(*ast.BranchStmt); !ok || branch.Tok != token.BREAK || branch.Label != nil {
+			return foundFlag{}, false
+		}
+	} else if len(body) != 1 {
+		return foundFlag{}, false
+	}
+	assign, ok := body[0].(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 ||
+		!isBoolConst(pass, assign.Rhs[0], true) {
+		return foundFlag{}, false
+	}
+	if ident, ok := assign.Lhs[0].(*ast.Ident); !ok || pass.TypesInfo.Uses[ident] != flag {
+		return foundFlag{}, false
+	}
+
+	fc, ok := failureCondition(pass, ifStmt.Cond)
+	if !ok || !usesObject(pass, fc.got, l.v) || (fc.want != nil && usesObject(pass, fc.want, l.v)) {
+		return foundFlag{}, false
+	}
+	// The block sets the flag when the condition holds, which is when the
+	// assertion a failure block is read as would fail.
+	switch fc.checker {
+	case "IsNil", "IsNotNil", "IsTrue", "IsFalse":
+		fc.checker = replacements[fc.checker]
+	default:
+		fc.not = !fc.not
+	}
+	return foundFlag{l: l, flag: flag, check: fc, decl: decl}, true
+}
+
+// falseFlag returns the bool variable stmt declares false, in one of
+// found := false, var found bool or var found = false.
+func falseFlag(pass *analysis.Pass, stmt ast.Stmt) (types.Object, bool) {
+	var ident *ast.Ident
+	switch stmt := stmt.(type) {
+	case *ast.AssignStmt:
+		if stmt.Tok != token.DEFINE || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 || !isBoolConst(pass, stmt.Rhs[0], false) {
+			return nil, false
+		}
+		ident, _ = stmt.Lhs[0].(*ast.Ident)
+	case *ast.DeclStmt:
+		gen, ok := stmt.Decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR || len(gen.Specs) != 1 {
+			return nil, false
+		}
+		spec := gen.Specs[0].(*ast.ValueSpec)
+		if len(spec.Names) != 1 || len(spec.Values) > 1 || len(spec.Values) == 1 && !isBoolConst(pass, spec.Values[0], false) {
+			return nil, false
+		}
+		ident = spec.Names[0]
+	}
+	if ident == nil {
+		return nil, false
+	}
+	obj, ok := pass.TypesInfo.Defs[ident].(*types.Var)
+	if !ok {
+		return nil, false
+	}
+	if basic, ok := obj.Type().(*types.Basic); !ok || basic.Kind() != types.Bool {
+		return nil, false
+	}
+	return obj, true
+}
+
+// isBoolConst reports whether expr is the boolean constant value.
+func isBoolConst(pass *analysis.Pass, expr ast.Expr, value bool) bool {
+	tv, ok := pass.TypesInfo.Types[expr]
+	return ok && tv.Value != nil && tv.Value.Kind() == constant.Bool && constant.BoolVal(tv.Value) == value
+}
+
+// checkAnyLoop reports the loop setting a foundFlag, declared by decl, when
+// assertion asserts the flag with qt.IsTrue and nothing else uses it, and
+// reports whether it did. The declaration and the loop go with the fix.
+//
+// As with qt.All, a frankban/quicktest checker takes the element itself, and
+// the fix is best-effort when its want calls a function, which now runs once.
+func (a *analyzer) checkAnyLoop(pass *analysis.Pass, decl ast.Stmt, loop *ast.RangeStmt, assertion ast.Stmt) bool {
+	f, ok := matchFoundFlag(pass, decl, loop)
+	if !ok {
+		return false
+	}
+	m, ok := matchAssertionStmt(pass, assertion)
+	if !ok || m.checker.Sel.Name != "IsTrue" || countUses(pass, f.flag) != 2 {
+		return false
+	}
+	if got, ok := stripParens(m.got).(*ast.Ident); !ok || pass.TypesInfo.Uses[got] != f.flag {
+		return false
+	}
+	qtPkgIdent, ok := m.checker.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	flagName := f.flag.Name()
+	fc := f.check
+
+	gotText, ok := formatExpr(pass, fc.got)
+	if !ok {
+		return false
+	}
+	var wantText string
+	if fc.want != nil {
+		if wantText, ok = formatExpr(pass, fc.want); !ok {
+			return false
+		}
+	}
+
+	diag := analysis.Diagnostic{Pos: loop.Pos(), End: loop.End()}
+	deleted := analysis.TextEdit{Pos: decl.Pos(), End: m.stmt.Pos()}
+	if isQualifiedBy(pass, m.checker, quicktestV2PkgPath) {
+		spelling := assertionSpelling{qtAlias: qtPkgIdent.Name}
+		quantifier, ok := f.l.v2Quantifier(pass, false)
+		if !ok {
+			diag.Message = fmt.Sprintf("qtlint: use qt.SliceAny instead of a loop setting %s; no fix: qt.SliceAny takes a slice, not an array", flagName)
+			pass.Report(diag)
+			return true
+		}
+		diag.Message = fmt.Sprintf("qtlint: use qt.%s instead of a loop setting %s", quantifier, flagName)
+		head, ok := f.l.v2Func(pass, spelling.qtAlias)
+		if ok && fc.fixable(pass, spelling) && (fc.stable || !a.onlyStableFixes) {
+			checkerCall := m.stmt.X.(*ast.CallExpr).Args[1]
+			text := spelling.qtAlias + "." + quantifier + "(" + f.l.itemsText + ", " + head +
+				fc.render(spelling, gotText, wantText) + " })"
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message: "Replace with qt." + quantifier,
+				TextEdits: []analysis.TextEdit{
+					deleted,
+					{Pos: checkerCall.Pos(), End: checkerCall.End(), NewText: []byte(text)},
+				},
+			}}
+		}
+		pass.Report(diag)
+		return true
+	}
+
+	// The spelling's *qt.C only marks the frankban/quicktest form: the
+	// assertion keeps its own receiver.
+	spelling := assertionSpelling{qtAlias: qtPkgIdent.Name, cVar: "c"}
+	checker := spelling.qtAlias + "." + fc.checker
+	use := "qt." + fc.checker
+	if fc.not {
+		checker = spelling.qtAlias + ".Not(" + checker + ")"
+		use = "qt.Not(" + use + ")"
+	}
+	diag.Message = fmt.Sprintf("qtlint: use qt.Any(%s) instead of a loop setting %s", use, flagName)
+	if got, ok := stripParens(fc.got).(*ast.Ident); !ok || pass.TypesInfo.Uses[got] != f.l.v {
+		diag.Message += fmt.Sprintf("; no fix: the loop checks %s rather than the element itself", gotText)
+		pass.Report(diag)
+		return true
+	}
+	stable := fc.stable && (fc.want == nil || !callsOutsideQuicktest(pass, []ast.Expr{fc.want}))
+	if fc.fixable(pass, spelling) && (stable || !a.onlyStableFixes) {
+		text := f.l.itemsText + ", " + spelling.qtAlias + ".Any(" + checker + ")"
+		if wantText != "" {
+			text += ", " + wantText
+		}
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Replace with qt.Any",
+			TextEdits: []analysis.TextEdit{
+				deleted,
+				{Pos: m.got.Pos(), End: m.checker.End(), NewText: []byte(text)},
+			},
+		}}
+	}
+	pass.Report(diag)
+	return true
+}
@@ -204,6 +204,10 @@ func (r *calleeReach) bodyReach(fn *ast.FuncDecl, paramObj types.Object) (cReach
 			return
 		}
 		if sel, ok := parent.(*ast.SelectorExpr); ok && sel.X == ident {
+			switch sel.Sel.Name {
+			case "Assert", "Check", "Run":
+				reach.checks = true
+			}
 			switch {
 			case deferredCMethods[sel.Sel.Name]:
 				reach.deferred = true
@@ -215,6 +219,8 @@ func (r *calleeReach) bodyReach(fn *ast.FuncDecl, paramObj types.Object) (cReach
 				if reach.method == "" {
 					reach.method = sel.Sel.Name
 				}
+			case sel.Sel.Name == "Assert":
+				reach.asserts = true
 			}
 			return
 		}
@@ -223,6 +229,12 @@ func (r *calleeReach) bodyReach(fn *ast.FuncDecl, paramObj types.Object) (cReach
 		}
 		if call, ok := parent.(*ast.CallExpr); ok {
 			if index, ok := argumentIndex(call, ident); ok {
+				if index == 0 && isPackageAssert(r.pass, call) {
+					reach.asserts = true
+				}
+				if index == 0 && (isQuicktestAssertion(r.pass, call) || isV2Assertion(r.pass, call)) {
+					reach.checks = true
+				}
 				if inner, ok := r.follow(call, index); ok {
 					reach.merge(inner)
 					return
@@ -245,6 +257,8 @@ func (r *cReach) merge(other cReach) {
 	r.deferred = r.deferred || other.deferred
 	r.testScoped = r.testScoped || other.testScoped
 	r.handedOn = r.handedOn || other.handedOn
+	r.asserts = r.asserts || other.asserts
+	r.checks = r.checks || other.checks
 	if r.method == "" {
 		r.method = other.method
 	}
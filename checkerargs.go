@@ -0,0 +1,114 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkCheckerArgCount checks that a frankban/quicktest assertion gives its
+// checker as many arguments as the checker's ArgNames ask for after got.
+// Assert and Check take them as ...interface{}, so the compiler accepts any
+// number, and quicktest only fails the assertion at run time with "not enough
+// arguments provided to checker" or "too many arguments provided to checker".
+//
+// quicktest takes qt.Comment values off the end of the arguments before it
+// counts them, so trailing qt.Commentf calls are not counted here either; a
+// comment anywhere else is an argument like any other, though one that only
+// needs moving to the end is left to checkMisplacedComment. Arguments spread
+// from a slice with args... cannot be counted, and are only reported under
+// -strict-checker-args.
+func (a *analyzer) checkCheckerArgCount(pass *analysis.Pass, call *ast.CallExpr) {
+	if !isQuicktestAssertion(pass, call) {
+		return
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	args := call.Args
+	if isPackageQualified(pass, sel) {
+		if len(args) == 0 {
+			return
+		}
+		args = args[1:]
+	}
+	if len(args) < 2 {
+		return
+	}
+	checker, ok := parseV1Checker(pass, args[1])
+	if !ok {
+		return
+	}
+	wants, ok := checkerWants(pass, checker)
+	if !ok {
+		return
+	}
+
+	if call.Ellipsis != token.NoPos {
+		if a.strictCheckerArgs {
+			spread := args[len(args)-1]
+			pass.Report(analysis.Diagnostic{
+				Pos:     spread.Pos(),
+				End:     spread.End(),
+				Message: fmt.Sprintf("qtlint: cannot count the arguments spread to %s, which takes %s", checker.schematic(), wantsText(wants)),
+			})
+		}
+		return
+	}
+
+	given := args[2:]
+	if own, _, misplaced := splitComments(pass, given); misplaced != nil && len(own) == wants {
+		// Only a comment out of place, which checkMisplacedComment reports.
+		return
+	}
+	for len(given) > 0 && isV1CommentType(pass.TypesInfo.TypeOf(given[len(given)-1])) {
+		given = given[:len(given)-1]
+	}
+	switch {
+	case len(given) < wants:
+		pass.Report(analysis.Diagnostic{
+			Pos:     args[1].Pos(),
+			End:     args[1].End(),
+			Message: fmt.Sprintf("qtlint: %s is missing its want argument", checker.schematic()),
+		})
+	case len(given) > wants:
+		extra := given[wants:]
+		pass.Report(analysis.Diagnostic{
+			Pos: extra[0].Pos(),
+			End: extra[len(extra)-1].End(),
+			Message: fmt.Sprintf("qtlint: %s takes %s, but is given %d; only qt.Comment values may follow",
+				checker.schematic(), wantsText(wants), len(given)),
+		})
+	}
+}
+
+// checkerWants returns the number of arguments checker takes after got. qt.Not
+// takes what the checker it negates takes, and qt.All and qt.Any what the
+// checker they apply to each element takes.
+func checkerWants(pass *analysis.Pass, checker *v1Checker) (int, bool) {
+	base := checker.base()
+	switch base.name {
+	case "All", "Any":
+		if len(base.extra) != 1 {
+			return 0, false
+		}
+		elem, ok := parseV1Checker(pass, base.extra[0])
+		if !ok {
+			return 0, false
+		}
+		return checkerWants(pass, elem)
+	}
+	wants, ok := v1CheckerWants[base.name]
+	return wants, ok
+}
+
+// wantsText renders a number of arguments after got for a message.
+func wantsText(wants int) string {
+	if wants == 0 {
+		return "no argument after got"
+	}
+	return "one argument after got"
+}
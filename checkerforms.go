@@ -0,0 +1,343 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// v1AssertionArgs returns the arguments of a frankban/quicktest assertion
+// from got onwards: those of c.Assert, or those of qt.Assert after the test.
+func v1AssertionArgs(pass *analysis.Pass, call *ast.CallExpr) []ast.Expr {
+	args := call.Args
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && isPackageQualified(pass, sel) && len(args) > 0 {
+		args = args[1:]
+	}
+	return args
+}
+
+// boolLiteral reports whether expr is the predeclared true or false, and
+// which.
+func boolLiteral(pass *analysis.Pass, expr ast.Expr) (value, ok bool) {
+	ident, isIdent := stripParens(expr).(*ast.Ident)
+	if !isIdent {
+		return false, false
+	}
+	switch pass.TypesInfo.Uses[ident] {
+	case types.Universe.Lookup("true"):
+		return true, true
+	case types.Universe.Lookup("false"):
+		return false, true
+	}
+	return false, false
+}
+
+// isPlainBool reports whether expr is a bool, and not of a named bool type.
+func isPlainBool(pass *analysis.Pass, expr ast.Expr) bool {
+	typ := pass.TypesInfo.TypeOf(expr)
+	return typ != nil && types.Identical(types.Default(typ), types.Typ[types.Bool])
+}
+
+// isBasicComparable reports whether typ is a basic type that == compares by
+// value: a bool, a number or a string, and not of a named type, whose Equal
+// method go-cmp would call instead.
+func isBasicComparable(typ types.Type) bool {
+	basic, ok := types.Unalias(typ).(*types.Basic)
+	return ok && basic.Info()&(types.IsBoolean|types.IsNumeric|types.IsString) != 0
+}
+
+// checkEqualsBoolPattern checks if the pattern is x, qt.Equals, true or
+// x, qt.Equals, false and suggests x, qt.IsTrue or x, qt.IsFalse. Equals
+// boxes both operands, so a named bool type never equals the literal and the
+// assertion is left to the impossible-checker rule; on a plain bool the two
+// agree.
+func checkEqualsBoolPattern(pass *analysis.Pass, call *ast.CallExpr) {
+	args := v1AssertionArgs(pass, call)
+	if len(args) < 3 || call.Ellipsis.IsValid() {
+		return
+	}
+	checkerSel, ok := args[1].(*ast.SelectorExpr)
+	if !ok || checkerSel.Sel.Name != "Equals" || !isPackageQualified(pass, checkerSel) {
+		return
+	}
+	value, ok := boolLiteral(pass, args[2])
+	if !ok || !isPlainBool(pass, args[0]) {
+		return
+	}
+	pkgIdent, ok := checkerSel.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+	replacement, literal := "IsTrue", "true"
+	if !value {
+		replacement, literal = "IsFalse", "false"
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     args[1].Pos(),
+		End:     args[2].End(),
+		Message: fmt.Sprintf("qtlint: use qt.%s instead of qt.Equals, %s", replacement, literal),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Replace with qt." + replacement,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     args[1].Pos(),
+				End:     args[2].End(),
+				NewText: []byte(pkgIdent.Name + "." + replacement),
+			}},
+		}},
+	})
+}
+
+// checkDoubleNotPattern checks if the checker is qt.Not(qt.Not(C)) and
+// suggests C, which asserts the same thing.
+func checkDoubleNotPattern(pass *analysis.Pass, checkerArg ast.Expr) {
+	c, ok := parseV1Checker(pass, checkerArg)
+	if !ok || c.inner == nil || c.inner.inner == nil {
+		return
+	}
+	notCall := checkerArg.(*ast.CallExpr)
+	innerText, ok := formatExpr(pass, notCall.Args[0].(*ast.CallExpr).Args[0])
+	if !ok {
+		return
+	}
+	use := c.inner.inner.schematic()
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     checkerArg.Pos(),
+		End:     checkerArg.End(),
+		Message: fmt.Sprintf("qtlint: use %s instead of qt.Not(qt.Not(%s))", use, use),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Replace with " + use,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     checkerArg.Pos(),
+				End:     checkerArg.End(),
+				NewText: []byte(innerText),
+			}},
+		}},
+	})
+}
+
+// checkCmpEqualsNoOptsPattern checks for qt.CmpEquals() given no options, on
+// its own or negated, and suggests qt.DeepEquals, which is that checker.
+func checkCmpEqualsNoOptsPattern(pass *analysis.Pass, checkerArg ast.Expr) {
+	for {
+		call, ok := checkerArg.(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !isPackageQualified(pass, sel) {
+			return
+		}
+		if sel.Sel.Name == "Not" && len(call.Args) == 1 {
+			checkerArg = call.Args[0]
+			continue
+		}
+		if sel.Sel.Name != "CmpEquals" || len(call.Args) != 0 || call.Ellipsis.IsValid() {
+			return
+		}
+		pkgIdent, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: "qtlint: use qt.DeepEquals instead of qt.CmpEquals()",
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message: "Replace with qt.DeepEquals",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     call.Pos(),
+					End:     call.End(),
+					NewText: []byte(pkgIdent.Name + ".DeepEquals"),
+				}},
+			}},
+		})
+		return
+	}
+}
+
+// checkDeepEqualsBasicPattern checks for qt.DeepEquals, on its own or
+// negated, comparing two values of a basic type, and suggests qt.Equals. go-cmp
+// compares a bool, a number or a string with ==, which is all qt.Equals does,
+// and qt.Equals says so. Both sides must box to one type, as the v2 form
+// requires with sameOperandTypes: an int and an int64, or a float32 and an
+// untyped float constant, which boxes as a float64, are never equal to
+// either checker, but qt.DeepEquals says why in its diff.
+func checkDeepEqualsBasicPattern(pass *analysis.Pass, call *ast.CallExpr) {
+	args := v1AssertionArgs(pass, call)
+	if len(args) < 3 || call.Ellipsis.IsValid() || isV1CommentType(pass.TypesInfo.TypeOf(args[2])) {
+		return
+	}
+	c, ok := parseV1Checker(pass, args[1])
+	if !ok || c.base().name != "DeepEquals" {
+		return
+	}
+	got, want := boxedType(pass, args[0]), boxedType(pass, args[2])
+	if got == nil || want == nil || !types.Identical(got, want) || !isBasicComparable(got) {
+		return
+	}
+	sel := c.base().sels[0]
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     sel.Pos(),
+		End:     sel.End(),
+		Message: fmt.Sprintf("qtlint: use qt.Equals instead of qt.DeepEquals on %s, which == compares", got),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Replace with qt.Equals",
+			TextEdits: []analysis.TextEdit{{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte("Equals")}},
+		}},
+	})
+}
+
+// checkErrorIsNilPattern checks if the pattern is err, qt.ErrorIs, nil and
+// suggests err, qt.IsNil. errors.Is(err, nil) holds exactly when err is nil,
+// and qt.IsNil refuses, as errors.Is does, to call an error holding a nil
+// pointer nil. err must be an interface: a nil pointer of a concrete error
+// type is nil to qt.IsNil, but not once ErrorIs has made an error of it.
+func checkErrorIsNilPattern(pass *analysis.Pass, call *ast.CallExpr) {
+	args := v1AssertionArgs(pass, call)
+	if len(args) < 3 || call.Ellipsis.IsValid() {
+		return
+	}
+	checkerSel, ok := args[1].(*ast.SelectorExpr)
+	if !ok || checkerSel.Sel.Name != "ErrorIs" || !isPackageQualified(pass, checkerSel) {
+		return
+	}
+	if !isNilIdent(args[2]) || !isInterfaceExpr(pass, args[0]) {
+		return
+	}
+	pkgIdent, ok := checkerSel.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     args[1].Pos(),
+		End:     args[2].End(),
+		Message: "qtlint: use qt.IsNil instead of qt.ErrorIs, nil",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Replace with qt.IsNil",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     args[1].Pos(),
+				End:     args[2].End(),
+				NewText: []byte(pkgIdent.Name + ".IsNil"),
+			}},
+		}},
+	})
+}
+
+// isInterfaceExpr reports whether expr is of an interface type.
+func isInterfaceExpr(pass *analysis.Pass, expr ast.Expr) bool {
+	typ := pass.TypesInfo.TypeOf(expr)
+	return typ != nil && types.IsInterface(typ)
+}
+
+// checkV2EqualsBoolPattern checks if the checker is qt.Equals(x, true) or
+// qt.Equals(x, false) and suggests qt.IsTrue(x) or qt.IsFalse(x). Both take
+// x as a type parameter, so a named bool type is as welcome to one as to the
+// other.
+func checkV2EqualsBoolPattern(pass *analysis.Pass, checker v2Checker) {
+	if checker.name != "Equals" || len(checker.call.Args) != 2 {
+		return
+	}
+	value, ok := boolLiteral(pass, checker.call.Args[1])
+	if !ok {
+		return
+	}
+	if typ := pass.TypesInfo.TypeOf(checker.call.Args[0]); typ == nil || !basicHas(typ.Underlying(), types.IsBoolean) {
+		return
+	}
+	argText, ok := formatExpr(pass, checker.call.Args[0])
+	if !ok {
+		return
+	}
+	replacement, literal := "IsTrue", "true"
+	if !value {
+		replacement, literal = "IsFalse", "false"
+	}
+
+	reportV2Rewrite(pass, checker.call,
+		fmt.Sprintf("qtlint: use qt.%s(x) instead of qt.Equals(x, %s)", replacement, literal),
+		"Replace with qt."+replacement,
+		v2Call(checker.qtAlias, replacement, argText))
+}
+
+// checkV2DoubleNotPattern checks if the checker is qt.Not(qt.Not(C)) and
+// suggests C.
+func checkV2DoubleNotPattern(pass *analysis.Pass, checker v2Checker) {
+	inner, negated := unwrapV2Not(pass, checker)
+	if !negated {
+		return
+	}
+	innermost, negated := unwrapV2Not(pass, inner)
+	if !negated {
+		return
+	}
+	innerText, ok := formatExpr(pass, innermost.call)
+	if !ok {
+		return
+	}
+
+	reportV2Rewrite(pass, checker.call,
+		fmt.Sprintf("qtlint: use qt.%s(...) instead of qt.Not(qt.Not(qt.%s(...)))", innermost.name, innermost.name),
+		"Replace with qt."+innermost.name,
+		innerText)
+}
+
+// checkV2CmpEqualsNoOptsPattern checks for qt.CmpEquals(got, want) given no
+// options, on its own or negated, and suggests qt.DeepEquals(got, want).
+func checkV2CmpEqualsNoOptsPattern(pass *analysis.Pass, checker v2Checker) {
+	for checker.name == "Not" {
+		inner, negated := unwrapV2Not(pass, checker)
+		if !negated {
+			return
+		}
+		checker = inner
+	}
+	if checker.name != "CmpEquals" || len(checker.call.Args) != 2 || checker.call.Ellipsis.IsValid() {
+		return
+	}
+	reportV2Rewrite(pass, checker.sel.Sel,
+		"qtlint: use qt.DeepEquals(x, y) instead of qt.CmpEquals(x, y)",
+		"Replace with qt.DeepEquals",
+		"DeepEquals")
+}
+
+// checkV2DeepEqualsBasicPattern checks for qt.DeepEquals(x, y), on its own or
+// negated, on a basic type and suggests qt.Equals(x, y).
+func checkV2DeepEqualsBasicPattern(pass *analysis.Pass, checker v2Checker) {
+	checker, _ = unwrapV2Not(pass, checker)
+	if checker.name != "DeepEquals" || len(checker.call.Args) != 2 {
+		return
+	}
+	typ := pass.TypesInfo.TypeOf(checker.call.Args[0])
+	if typ == nil || !isBasicComparable(typ) || !sameOperandTypes(pass, checker.call.Args[0], checker.call.Args[1]) {
+		return
+	}
+	reportV2Rewrite(pass, checker.sel.Sel,
+		fmt.Sprintf("qtlint: use qt.Equals(x, y) instead of qt.DeepEquals(x, y) on %s, which == compares", typ),
+		"Replace with qt.Equals",
+		"Equals")
+}
+
+// checkV2ErrorIsNilPattern checks if the checker is qt.ErrorIs(err, nil) and
+// suggests qt.IsNil(err), on the terms of checkErrorIsNilPattern.
+func checkV2ErrorIsNilPattern(pass *analysis.Pass, checker v2Checker) {
+	if checker.name != "ErrorIs" || len(checker.call.Args) != 2 || !isNilIdent(checker.call.Args[1]) {
+		return
+	}
+	if !isInterfaceExpr(pass, checker.call.Args[0]) {
+		return
+	}
+	argText, ok := formatExpr(pass, checker.call.Args[0])
+	if !ok {
+		return
+	}
+	reportV2Rewrite(pass, checker.call,
+		"qtlint: use qt.IsNil(err) instead of qt.ErrorIs(err, nil)",
+		"Replace with qt.IsNil",
+		v2Call(checker.qtAlias, "IsNil", argText))
+}
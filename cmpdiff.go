@@ -0,0 +1,222 @@
+package qtlint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// cmpPkgPath is go-cmp, whose Diff both quicktest APIs build DeepEquals and
+// CmpEquals on.
+const cmpPkgPath = "github.com/google/go-cmp/cmp"
+
+// cmpDiffMatch holds the pattern parsed by matchCmpDiff.
+type cmpDiffMatch struct {
+	diff       *types.Var    // the variable the if statement's init declares
+	diffCall   *ast.CallExpr // the cmp.Diff call assigned to it
+	call       *ast.CallExpr
+	sel        *ast.SelectorExpr
+	methodName string // "Fatal", "Fatalf", "Error", or "Errorf"
+	qtMethod   string // "Assert" or "Check"
+}
+
+// matchCmpDiff validates and parses ifStmt into a cmpDiffMatch. The shape is
+//
+//	if diff := cmp.Diff(want, got, opts...); diff != "" {
+//		t.Errorf("mismatch (-want +got):\n%s", diff)
+//	}
+//
+// with the comparison written either way round and any of Error[f] and
+// Fatal[f] in the body. A diff declared before the if is not matched: it is
+// still in scope afterwards, and the rewrite would take it away.
+func matchCmpDiff(pass *analysis.Pass, ifStmt *ast.IfStmt) (cmpDiffMatch, bool) {
+	if ifStmt == nil || ifStmt.Else != nil {
+		return cmpDiffMatch{}, false
+	}
+
+	assign, ok := ifStmt.Init.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return cmpDiffMatch{}, false
+	}
+	diffIdent, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return cmpDiffMatch{}, false
+	}
+	diff, ok := pass.TypesInfo.Defs[diffIdent].(*types.Var)
+	if !ok {
+		return cmpDiffMatch{}, false
+	}
+	diffCall, ok := stripParens(assign.Rhs[0]).(*ast.CallExpr)
+	if !ok || len(diffCall.Args) < 2 {
+		return cmpDiffMatch{}, false
+	}
+	fun, ok := stripParens(diffCall.Fun).(*ast.SelectorExpr)
+	if !ok || fun.Sel.Name != "Diff" || !isQualifiedBy(pass, fun, cmpPkgPath) {
+		return cmpDiffMatch{}, false
+	}
+
+	// Match: diff != "" (or "" != diff).
+	binExpr, ok := stripParens(ifStmt.Cond).(*ast.BinaryExpr)
+	if !ok || binExpr.Op != token.NEQ {
+		return cmpDiffMatch{}, false
+	}
+	switch {
+	case usesVar(pass, binExpr.X, diff) && isEmptyString(pass, binExpr.Y):
+	case usesVar(pass, binExpr.Y, diff) && isEmptyString(pass, binExpr.X):
+	default:
+		return cmpDiffMatch{}, false
+	}
+
+	call, sel, qtMethod, ok := matchFailureCall(ifStmt.Body)
+	if !ok {
+		return cmpDiffMatch{}, false
+	}
+	return cmpDiffMatch{diff, diffCall, call, sel, sel.Sel.Name, qtMethod}, true
+}
+
+// usesVar reports whether expr is an identifier referring to v.
+func usesVar(pass *analysis.Pass, expr ast.Expr, v *types.Var) bool {
+	ident, ok := stripParens(expr).(*ast.Ident)
+	return ok && pass.TypesInfo.Uses[ident] == v
+}
+
+// isEmptyString reports whether expr is a constant empty string.
+func isEmptyString(pass *analysis.Pass, expr ast.Expr) bool {
+	tv, ok := pass.TypesInfo.Types[expr]
+	return ok && tv.Value != nil && tv.Value.Kind() == constant.String && constant.StringVal(tv.Value) == ""
+}
+
+func (a *analyzer) checkCmpDiffPattern(pass *analysis.Pass, ifStmt *ast.IfStmt) {
+	m, ok := matchCmpDiff(pass, ifStmt)
+	if !ok {
+		return
+	}
+
+	// As with any other failure block, only the testing package's own
+	// Fatal and Error are taken to fail the test.
+	selection, selOk := pass.TypesInfo.Selections[m.sel]
+	if !selOk || !isFromTestingPkg(selection) {
+		return
+	}
+
+	// go-cmp documents Diff as reporting (-x +y), and calls it as
+	// Diff(want, got) throughout; the rewrite takes that convention at its
+	// word. The comparison is symmetric, so only the labels depend on it.
+	want, got, opts := m.diffCall.Args[0], m.diffCall.Args[1], m.diffCall.Args[2:]
+	wantText, ok := formatExpr(pass, want)
+	if !ok {
+		return
+	}
+	gotText, ok := formatExpr(pass, got)
+	if !ok {
+		return
+	}
+	optTexts, ok := formatArgs(pass, opts)
+	if !ok {
+		return
+	}
+	if m.diffCall.Ellipsis.IsValid() {
+		optTexts[len(optTexts)-1] += "..."
+	}
+
+	receiverText, ok := formatExpr(pass, m.sel.X)
+	if !ok {
+		return
+	}
+	spelling, ok := assertionSpellingAt(pass, ifStmt, selection, m.sel.X, receiverText)
+	if !ok {
+		return
+	}
+
+	// qt.DeepEquals is qt.CmpEquals with no options in both APIs, so the
+	// choice only tidies the spelling.
+	checker := "DeepEquals"
+	if len(opts) > 0 {
+		checker = "CmpEquals"
+	}
+	shortOpts := optTexts
+	if len(opts) > 0 {
+		shortOpts = []string{"..."}
+	}
+	diag := analysis.Diagnostic{
+		Pos: ifStmt.Pos(),
+		End: ifStmt.End(),
+		Message: "qtlint: use " + spelling.call(m.qtMethod, spelling.comparison(checker, gotText, wantText, shortOpts)) +
+			" instead of cmp.Diff with " + receiverText + "." + m.methodName + "(...)",
+	}
+
+	if stable, ok := cmpDiffFixable(pass, m, spelling, want, got); ok && (stable || !a.onlyStableFixes) {
+		text := spelling.call(m.qtMethod, spelling.comparison(checker, gotText, wantText, optTexts))
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Replace with " + text,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     ifStmt.Pos(),
+				End:     ifStmt.End(),
+				NewText: []byte(text),
+			}},
+		}}
+	}
+
+	pass.Report(diag)
+}
+
+// cmpDiffFixable reports whether the cmp.Diff block can be replaced by a
+// single assertion, and whether that replacement is stable.
+//
+// The assertion prints got, want and the diff itself, so a failure message
+// made of nothing but the diff and constant text is dropped. A message that
+// carries anything else, such as the name of a table row, has no place to go
+// and the block is reported without a fix.
+//
+// The rewrite evaluates got before want where cmp.Diff evaluated want first.
+// That only shows when both operands call something, and such a fix is
+// unstable. In the go-quicktest/qt form both operands are a single type
+// parameter, which cmp.Diff's two interface arguments never required, so
+// operands of different types get no fix there.
+func cmpDiffFixable(pass *analysis.Pass, m cmpDiffMatch, spelling assertionSpelling, want, got ast.Expr) (stable, ok bool) {
+	if m.call.Ellipsis.IsValid() {
+		return false, false
+	}
+	for _, arg := range m.call.Args {
+		if tv, ok := pass.TypesInfo.Types[arg]; (!ok || tv.Value == nil) && !usesVar(pass, arg, m.diff) {
+			return false, false
+		}
+	}
+
+	if spelling.cVar == "" {
+		wantType, gotType := pass.TypesInfo.TypeOf(want), pass.TypesInfo.TypeOf(got)
+		if wantType == nil || gotType == nil || !types.Identical(types.Default(wantType), types.Default(gotType)) ||
+			isUntypedNil(gotType) {
+			return false, false
+		}
+	}
+
+	return !callsFunc(pass, want) || !callsFunc(pass, got), true
+}
+
+// callsFunc reports whether evaluating expr calls a function. Conversions and
+// the builtins that cannot run user code are not counted.
+func callsFunc(pass *analysis.Pass, expr ast.Expr) bool {
+	var calls bool
+	ast.Inspect(expr, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || calls {
+			return !calls
+		}
+		if tv, ok := pass.TypesInfo.Types[call.Fun]; ok && tv.IsType() {
+			return true
+		}
+		if ident, ok := stripParens(call.Fun).(*ast.Ident); ok {
+			if b, ok := pass.TypesInfo.Uses[ident].(*types.Builtin); ok && strings.Contains(" len cap min max ", " "+b.Name()+" ") {
+				return true
+			}
+		}
+		calls = true
+		return false
+	})
+	return calls
+}
@@ -0,0 +1,427 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// isCommentfCall reports whether call calls Commentf from either quicktest
+// package. The callee is resolved through the type checker, so a renamed or
+// dot import is recognised as well as the plain qt.Commentf.
+func isCommentfCall(pass *analysis.Pass, call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Name() != "Commentf" {
+		return false
+	}
+	path := fn.Pkg().Path()
+	return path == quicktestPkgPath || path == quicktestV2PkgPath
+}
+
+// argClass is a set of the kinds of operand a printf verb formats.
+type argClass int
+
+const (
+	argBool argClass = 1 << iota
+	argInt
+	argRune
+	argString
+	argFloat
+	argComplex
+	argPointer
+	argAny argClass = ^0
+)
+
+// commentfVerbs maps each verb fmt.Sprintf knows to the operands it formats,
+// following the table go vet's printf check uses. %w is absent: only
+// fmt.Errorf wraps errors, and Commentf formats with fmt.Sprintf.
+var commentfVerbs = map[rune]argClass{
+	'b': argInt | argFloat | argComplex | argPointer,
+	'c': argRune | argInt,
+	'd': argInt | argPointer,
+	'e': argFloat | argComplex,
+	'E': argFloat | argComplex,
+	'f': argFloat | argComplex,
+	'F': argFloat | argComplex,
+	'g': argFloat | argComplex,
+	'G': argFloat | argComplex,
+	'o': argInt | argPointer,
+	'O': argInt | argPointer,
+	'p': argPointer,
+	'q': argRune | argInt | argString,
+	's': argString,
+	't': argBool,
+	'T': argAny,
+	'U': argRune | argInt,
+	'v': argAny,
+	'x': argRune | argInt | argString | argPointer | argFloat | argComplex,
+	'X': argRune | argInt | argString | argPointer | argFloat | argComplex,
+}
+
+// checkCommentf checks a qt.Commentf call the way go vet checks fmt.Printf.
+// Commentf is a printf wrapper, but vet only recognises one it can see the
+// body of or that is annotated as such, so without this a mistake in the
+// format is only found when the assertion fails and prints %!d(string=...).
+//
+// A constant format is parsed, and each verb is checked against the argument
+// it reads: an unknown verb, a verb reading past the last argument, an
+// argument of a type the verb cannot format, and arguments no verb reads are
+// reported. A format that is not a constant cannot be parsed, but when it is
+// given no arguments it is text meant to be printed as it is, and a % in it
+// would be read as a verb; wrapping it as qt.Commentf("%s", s) prints it
+// plainly.
+func checkCommentf(pass *analysis.Pass, call *ast.CallExpr) {
+	if len(call.Args) == 0 || !isCommentfCall(pass, call) {
+		return
+	}
+	format := call.Args[0]
+	args := call.Args[1:]
+	tv := pass.TypesInfo.Types[format]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		if len(args) == 0 && !call.Ellipsis.IsValid() {
+			text, ok := formatExpr(pass, format)
+			if !ok {
+				return
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos:     format.Pos(),
+				End:     format.End(),
+				Message: fmt.Sprintf("qtlint: qt.Commentf has a non-constant format and no args; use qt.Commentf(\"%%s\", %s) to print it as a plain comment", text),
+				SuggestedFixes: []analysis.SuggestedFix{{
+					Message:   `Format with "%s"`,
+					TextEdits: []analysis.TextEdit{{Pos: format.Pos(), End: format.Pos(), NewText: []byte(`"%s", `)}},
+				}},
+			})
+		}
+		return
+	}
+	checkCommentfFormat(pass, call, constant.StringVal(tv.Value), args)
+}
+
+// checkCommentfFormat parses format and checks each of its verbs against args.
+// Arguments spread with args... are not counted or type checked.
+func checkCommentfFormat(pass *analysis.Pass, call *ast.CallExpr, format string, args []ast.Expr) {
+	spread := call.Ellipsis.IsValid()
+	report := func(msg string, a ...any) {
+		pass.Report(analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: "qtlint: qt.Commentf " + fmt.Sprintf(msg, a...),
+		})
+	}
+
+	argNum := 0 // the index in args of the next argument to read
+	maxArg := 0 // one past the highest index in args any verb read
+	verbs := 0
+	indexed := false
+	// read takes the argument a verb or a * reads, and reports whether it
+	// exists.
+	read := func(directive string) (ast.Expr, bool) {
+		n := argNum
+		argNum++
+		if n+1 > maxArg {
+			maxArg = n + 1
+		}
+		if spread {
+			return nil, false
+		}
+		if n >= len(args) {
+			report("format %s reads arg #%d, but call has %s", directive, n+1, plural(len(args), "arg"))
+			return nil, false
+		}
+		return args[n], true
+	}
+
+	for i := 0; i < len(format); {
+		if format[i] != '%' {
+			i++
+			continue
+		}
+		start := i
+		i++
+		for i < len(format) && strings.IndexByte("#0+- ", format[i]) >= 0 {
+			i++
+		}
+		// index parses an explicit argument index, [n], and reports whether
+		// it was well formed.
+		index := func() bool {
+			if i >= len(format) || format[i] != '[' {
+				return true
+			}
+			end := strings.IndexByte(format[i:], ']')
+			if end < 0 {
+				return false
+			}
+			n, err := strconv.Atoi(format[i+1 : i+end])
+			if err != nil || n < 1 {
+				return false
+			}
+			indexed = true
+			argNum = n - 1
+			i += end + 1
+			return true
+		}
+		// number parses a width or precision, which is digits or a * that
+		// reads an int argument.
+		number := func() bool {
+			if !index() {
+				return false
+			}
+			if i < len(format) && format[i] == '*' {
+				i++
+				if arg, ok := read(format[start:i]); ok {
+					if typ := pass.TypesInfo.TypeOf(arg); typ != nil && !matchesArgClass(typ, argInt, false, nil) {
+						report("format %s uses non-int %s as argument of *", format[start:i], exprText(pass, arg))
+					}
+				}
+				return true
+			}
+			for i < len(format) && '0' <= format[i] && format[i] <= '9' {
+				i++
+			}
+			return true
+		}
+		ok := number()
+		if ok && i < len(format) && format[i] == '.' {
+			i++
+			ok = number()
+		}
+		if ok {
+			ok = index()
+		}
+		if !ok {
+			report("format %s has an invalid argument index", format[start:min(i+1, len(format))])
+			return
+		}
+		if i >= len(format) {
+			report("format %s is missing its verb at the end of the string", format[start:])
+			return
+		}
+		verb, size := utf8.DecodeRuneInString(format[i:])
+		i += size
+		directive := format[start:i]
+		if verb == '%' {
+			continue
+		}
+		verbs++
+		class, known := commentfVerbs[verb]
+		if !known {
+			if verb == 'w' {
+				report("does not support error-wrapping directive %s", directive)
+			} else {
+				report("format %s has unknown verb %c", directive, verb)
+			}
+			return
+		}
+		arg, ok := read(directive)
+		if !ok {
+			continue
+		}
+		if typ := pass.TypesInfo.TypeOf(arg); typ != nil && !matchesArgClass(typ, class, true, nil) {
+			report("format %s has arg %s of wrong type %s", directive, exprText(pass, arg), types.TypeString(typ, types.RelativeTo(pass.Pkg)))
+		}
+	}
+
+	if spread || indexed || maxArg >= len(args) {
+		return
+	}
+	if verbs == 0 {
+		report("call has arguments but no formatting directives")
+		return
+	}
+	report("call needs %s but has %s", plural(maxArg, "arg"), plural(len(args), "arg"))
+}
+
+// matchesArgClass reports whether fmt formats a value of type typ with a verb
+// taking class. A type that formats itself, an error or a fmt.Stringer given
+// to a verb printing strings, and an interface, whose dynamic type is not
+// known, all match. Composite types match when their elements do; top says
+// whether typ is the argument itself, as fmt prints the fields of a pointer to
+// a struct, slice, array or map it is given directly.
+func matchesArgClass(typ types.Type, class argClass, top bool, seen map[types.Type]bool) bool {
+	if class == argAny || hasFormatMethod(typ) {
+		return true
+	}
+	if class&argString != 0 && (hasStringMethod(typ, "Error") || hasStringMethod(typ, "String")) {
+		return true
+	}
+	if seen[typ] {
+		return true
+	}
+	if seen == nil {
+		seen = map[types.Type]bool{}
+	}
+	seen[typ] = true
+
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case t.Kind() == types.UntypedNil:
+			return class&argPointer != 0
+		case t.Kind() == types.UnsafePointer:
+			return class&argPointer != 0
+		case t.Info()&types.IsBoolean != 0:
+			return class&argBool != 0
+		case t.Info()&types.IsInteger != 0:
+			return class&(argInt|argRune) != 0
+		case t.Info()&types.IsFloat != 0:
+			return class&argFloat != 0
+		case t.Info()&types.IsComplex != 0:
+			return class&argComplex != 0
+		case t.Info()&types.IsString != 0:
+			return class&argString != 0
+		}
+		return false
+	case *types.Interface:
+		return true
+	case *types.Pointer:
+		if class&argPointer != 0 {
+			return true
+		}
+		if !top {
+			return false
+		}
+		switch t.Elem().Underlying().(type) {
+		case *types.Struct, *types.Array, *types.Slice, *types.Map:
+			return matchesArgClass(t.Elem(), class, false, seen)
+		}
+		return false
+	case *types.Slice:
+		if basic, ok := t.Elem().Underlying().(*types.Basic); ok && basic.Kind() == types.Byte && class&argString != 0 {
+			return true
+		}
+		return class&argPointer != 0 || matchesArgClass(t.Elem(), class, false, seen)
+	case *types.Array:
+		return matchesArgClass(t.Elem(), class, false, seen)
+	case *types.Map:
+		return class&argPointer != 0 || matchesArgClass(t.Key(), class, false, seen) && matchesArgClass(t.Elem(), class, false, seen)
+	case *types.Struct:
+		for field := range t.Fields() {
+			if !matchesArgClass(field.Type(), class, false, seen) {
+				return false
+			}
+		}
+		return true
+	case *types.Chan, *types.Signature:
+		return class&argPointer != 0
+	}
+	return false
+}
+
+// hasFormatMethod reports whether typ implements fmt.Formatter, and so
+// formats itself whatever the verb.
+func hasFormatMethod(typ types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(typ, false, nil, "Format")
+	fn, ok := obj.(*types.Func)
+	return ok && fn.Signature().Params().Len() == 2
+}
+
+// hasStringMethod reports whether typ has a method name taking nothing and
+// returning a string, as Error and String do.
+func hasStringMethod(typ types.Type, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(typ, false, nil, name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Signature()
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+	basic, ok := sig.Results().At(0).Type().(*types.Basic)
+	return ok && basic.Kind() == types.String
+}
+
+// exprText renders expr for a message, falling back to a placeholder when it
+// cannot be printed.
+func exprText(pass *analysis.Pass, expr ast.Expr) string {
+	if text, ok := formatExpr(pass, expr); ok {
+		return text
+	}
+	return "..."
+}
+
+// plural renders n things, as "1 arg" or "2 args".
+func plural(n int, thing string) string {
+	if n == 1 {
+		return "1 " + thing
+	}
+	return strconv.Itoa(n) + " " + thing + "s"
+}
+
+// checkMisplacedComment checks for a frankban/quicktest assertion giving a
+// comment before the checker's own arguments, as in
+// c.Assert(got, qt.Equals, qt.Commentf("id"), want). quicktest takes comments
+// only off the end of the arguments, so the checker is handed the comment as
+// its want and the real want as an argument too many. When the arguments
+// other than comments are as many as the checker takes, the fix moves the
+// comments to the end; any other count is reported by checkCheckerArgCount.
+func checkMisplacedComment(pass *analysis.Pass, call *ast.CallExpr) {
+	if !isQuicktestAssertion(pass, call) || call.Ellipsis.IsValid() {
+		return
+	}
+	args := v1AssertionArgs(pass, call)
+	if len(args) < 3 {
+		return
+	}
+	checker, ok := parseV1Checker(pass, args[1])
+	if !ok {
+		return
+	}
+	wants, ok := checkerWants(pass, checker)
+	if !ok {
+		return
+	}
+	given, comments, misplaced := splitComments(pass, args[2:])
+	if misplaced == nil || len(given) != wants {
+		return
+	}
+
+	var texts []string
+	for _, arg := range append(given, comments...) {
+		text, ok := formatExpr(pass, arg)
+		if !ok {
+			return
+		}
+		texts = append(texts, text)
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos: misplaced.Pos(),
+		End: misplaced.End(),
+		Message: fmt.Sprintf("qtlint: comment comes before the arguments of %s; quicktest takes comments only off the end, so the checker is given it as an argument",
+			checker.schematic()),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Move the comment to the end",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     args[2].Pos(),
+				End:     args[len(args)-1].End(),
+				NewText: []byte(strings.Join(texts, ", ")),
+			}},
+		}},
+	})
+}
+
+// splitComments divides the arguments an assertion gives after its checker
+// into the checker's own and the qt.Comment values, keeping the order of each,
+// and returns the first comment that is followed by an argument of the
+// checker's, if any.
+func splitComments(pass *analysis.Pass, args []ast.Expr) (given, comments []ast.Expr, misplaced ast.Expr) {
+	for _, arg := range args {
+		if isV1CommentType(pass.TypesInfo.TypeOf(arg)) {
+			comments = append(comments, arg)
+			continue
+		}
+		if misplaced == nil && len(comments) > 0 {
+			misplaced = comments[0]
+		}
+		given = append(given, arg)
+	}
+	return given, comments, misplaced
+}
@@ -0,0 +1,98 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkDeferWithoutDone checks for Defer on a *qt.C made by qt.New in a
+// function that never calls its Done. C.Run calls Done on the *qt.C it hands
+// its function, but qt.New leaves it to the caller, and on a testing.TB with
+// Cleanup the first Defer registers a cleanup that panics with "Done not
+// called after Defer" when Done has not run by the end of the test.
+//
+// The *qt.C is the one collectQtCOrigins finds declared by qt.New. It is not
+// reported when anything in the function names its Done, as in defer c.Done()
+// or t.Cleanup(c.Done), and not when it leaves the function in a way the rule
+// cannot follow: handed to a helper that can reach Defer or Done, where
+// calleeReach can read the helper's body, and anywhere else at all otherwise.
+//
+// The fix adds defer c.Done() on the line after qt.New, so that the deferred
+// functions run when the function that made c returns. Under
+// -modernize-lifecycle, a file that can call testing.TB's Cleanup is left to
+// that rule, which suggests it in place of Defer.
+func (a *analyzer) checkDeferWithoutDone(pass *analysis.Pass) {
+	var callees *calleeReach
+	for _, file := range pass.Files {
+		if a.modernizeLifecycle && supersededIn(pass, file, "Defer") {
+			// -modernize-lifecycle suggests c.Cleanup instead, which needs no
+			// Done.
+			continue
+		}
+		for _, root := range outermostFuncs(file) {
+			origins := collectQtCOrigins(pass, root)
+			if len(origins) == 0 {
+				continue
+			}
+			if callees == nil {
+				callees = newCalleeReach(pass)
+			}
+			objs := make([]types.Object, 0, len(origins))
+			for obj := range origins {
+				objs = append(objs, obj)
+			}
+			sort.Slice(objs, func(i, j int) bool { return objs[i].Pos() < objs[j].Pos() })
+			for _, obj := range objs {
+				checkDeferDone(pass, callees, root, obj, origins[obj])
+			}
+		}
+	}
+}
+
+// checkDeferDone reports the first Defer on obj within root when nothing in
+// root calls obj's Done.
+func checkDeferDone(pass *analysis.Pass, callees *calleeReach, root ast.Node, obj types.Object, origin qtCOrigin) {
+	var deferSel *ast.SelectorExpr
+	done, escaped := false, false
+	inspectWithParent(root, func(n, parent ast.Node) {
+		ident, ok := n.(*ast.Ident)
+		if !ok || pass.TypesInfo.Uses[ident] != obj {
+			return
+		}
+		if sel, ok := parent.(*ast.SelectorExpr); ok && sel.X == ident {
+			switch sel.Sel.Name {
+			case "Defer":
+				if deferSel == nil {
+					deferSel = sel
+				}
+			case "Done":
+				done = true
+			}
+			return
+		}
+		if reach, ok := followedCallReach(callees, parent, ident); ok && !reach.deferred {
+			return
+		}
+		escaped = true
+	})
+	if deferSel == nil || done || escaped {
+		return
+	}
+
+	name := obj.Name()
+	at := lineEnd(pass, origin.decl.End())
+	pass.Report(analysis.Diagnostic{
+		Pos: deferSel.Pos(),
+		End: deferSel.End(),
+		Message: fmt.Sprintf("qtlint: %s.Defer without %s.Done panics with \"Done not called after Defer\": %s comes from qt.New rather than c.Run; add defer %s.Done()",
+			name, name, name, name),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   fmt.Sprintf("Add defer %s.Done() after qt.New", name),
+			TextEdits: []analysis.TextEdit{{Pos: at, End: at, NewText: []byte("\ndefer " + name + ".Done()")}},
+		}},
+	})
+}
@@ -0,0 +1,114 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkEqualsDeepPattern checks for qt.Equals, or qt.Not(qt.Equals), applied
+// to operands that == does not compare the way the test means, and suggests
+// qt.DeepEquals.
+//
+// frankban/quicktest's Equals takes its operands as interfaces, so the
+// compiler accepts a slice, a map or a func there, and a struct holding one;
+// comparing them panics at run time, which Equals reports as a failure however
+// equal they are. A pointer to a struct compiles in both APIs and is compared
+// by address, which is rarely what a test comparing two of them means; but it
+// may be, so that fix is best-effort.
+func (a *analyzer) checkEqualsDeepPattern(pass *analysis.Pass, call *ast.CallExpr) {
+	var equals *ast.SelectorExpr
+	var not bool
+	var operands []ast.Expr
+	if isQuicktestAssertion(pass, call) {
+		checkerArg := getCheckerArg(pass, call)
+		if notCall, ok := checkerArg.(*ast.CallExpr); ok && len(notCall.Args) == 1 {
+			if sel, ok := notCall.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Not" && isPackageQualified(pass, sel) {
+				checkerArg, not = notCall.Args[0], true
+			}
+		}
+		sel, ok := checkerArg.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Equals" || !isPackageQualified(pass, sel) {
+			return
+		}
+		gotIndex := 0
+		if fun, ok := call.Fun.(*ast.SelectorExpr); ok && isPackageQualified(pass, fun) {
+			gotIndex = 1
+		}
+		if len(call.Args) < gotIndex+3 || call.Ellipsis.IsValid() || isV1CommentType(pass.TypesInfo.TypeOf(call.Args[gotIndex+2])) {
+			return
+		}
+		equals, operands = sel, []ast.Expr{call.Args[gotIndex], call.Args[gotIndex+2]}
+	} else if checker, ok := getV2Checker(pass, call); ok {
+		checker, not = unwrapV2Not(pass, checker)
+		if checker.name != "Equals" || len(checker.call.Args) != 2 {
+			return
+		}
+		equals, operands = checker.sel, checker.call.Args
+	} else {
+		return
+	}
+
+	// qt.Equals, nil is checkEqualsNilPattern's, whatever the other operand is.
+	if isNilIdent(operands[0]) || isNilIdent(operands[1]) {
+		return
+	}
+
+	var typ types.Type
+	var why string
+	stable := true
+	for _, operand := range operands {
+		t := pass.TypesInfo.TypeOf(operand)
+		if t == nil {
+			continue
+		}
+		if !types.Comparable(t) {
+			typ, why, stable = t, "which == cannot compare", true
+			break
+		}
+		if isStructPointer(t) && typ == nil {
+			typ, why, stable = t, "which qt.Equals compares by address", false
+		}
+	}
+	if typ == nil {
+		return
+	}
+
+	pkgIdent, ok := equals.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+	use, instead := "qt.DeepEquals", "qt.Equals"
+	if not {
+		use, instead = "qt.Not(qt.DeepEquals)", "qt.Not(qt.Equals)"
+	}
+	diag := analysis.Diagnostic{
+		Pos:     equals.Pos(),
+		End:     equals.End(),
+		Message: fmt.Sprintf("qtlint: use %s instead of %s on %s, %s", use, instead, typeString(typ), why),
+	}
+	if stable || !a.onlyStableFixes {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Replace with " + pkgIdent.Name + ".DeepEquals",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     equals.Pos(),
+				End:     equals.End(),
+				NewText: []byte(pkgIdent.Name + ".DeepEquals"),
+			}},
+		}}
+	}
+	pass.Report(diag)
+}
+
+// isStructPointer reports whether t is a pointer to a struct, which ==
+// compares by address.
+func isStructPointer(t types.Type) bool {
+	ptr, ok := t.Underlying().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	_, ok = ptr.Elem().Underlying().(*types.Struct)
+	return ok
+}
@@ -0,0 +1,271 @@
+package qtlint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// failureCheck is the assertion a failure block's condition turns into. The
+// block fails the test when its condition holds, so the assertion checks the
+// opposite: if got != want { t.Fatal(...) } becomes got, qt.Equals, want.
+type failureCheck struct {
+	got  ast.Expr
+	want ast.Expr // nil for a checker that takes no want
+	// checker is the checker's name, and v2Checker its name in go-quicktest/qt
+	// where the two differ.
+	checker   string
+	v2Checker string
+	not       bool
+	// stable is false when the checker is only close to the condition, as
+	// qt.DeepEquals is to reflect.DeepEqual.
+	stable bool
+}
+
+// render renders the checker applied to gotText and wantText in spelling.
+func (fc failureCheck) render(spelling assertionSpelling, gotText, wantText string) string {
+	name := fc.checker
+	if spelling.cVar == "" && fc.v2Checker != "" {
+		name = fc.v2Checker
+	}
+	return spelling.check(name, fc.not, gotText, wantText)
+}
+
+// failureFuncs maps the predicates a failure condition may call to the
+// checker asserting the same thing. Each takes got and want, in that order.
+var failureFuncs = map[string]map[string]failureCheck{
+	"reflect": {"DeepEqual": {checker: "DeepEquals"}},
+	"errors":  {"Is": {checker: "ErrorIs", stable: true}},
+	"strings": {"Contains": {checker: "Contains", v2Checker: v2ContainsCheckers["strings"], stable: true}},
+	"slices":  {"Contains": {checker: "Contains", v2Checker: v2ContainsCheckers["slices"], stable: true}},
+}
+
+// failureCondition maps the condition of a failure block to the assertion
+// that replaces the block. It recognises
+//
+//	x != nil, x == nil            qt.IsNil, qt.IsNotNil
+//	len(x) != n, len(x) == n      qt.HasLen, qt.Not(qt.HasLen)
+//	got != want, got == want      qt.Equals, qt.Not(qt.Equals)
+//	!reflect.DeepEqual(got, want) qt.DeepEquals
+//	!errors.Is(err, target)       qt.ErrorIs
+//	!strings.Contains(s, sub)     qt.Contains
+//	!slices.Contains(xs, x)       qt.Contains
+//	!ok, ok                       qt.IsTrue, qt.IsFalse
+//
+// with each predicate also matched unnegated, as its qt.Not. The left operand
+// of a comparison is taken as got; no other condition is matched, so a block
+// guarded by x > 3 or a && b is left alone. Nor is got != want on a pointer to
+// a struct: the qt.Equals it would become is one checkEqualsDeepPattern
+// reports, as comparing by address is rarely what a test means, and which of
+// the two the block meant is for its author to say.
+func failureCondition(pass *analysis.Pass, cond ast.Expr) (failureCheck, bool) {
+	cond = stripParens(cond)
+	if unary, ok := cond.(*ast.UnaryExpr); ok && unary.Op == token.NOT {
+		return predicateCheck(pass, stripParens(unary.X), false)
+	}
+
+	binExpr, ok := cond.(*ast.BinaryExpr)
+	if !ok {
+		return predicateCheck(pass, cond, true)
+	}
+	if binExpr.Op != token.NEQ && binExpr.Op != token.EQL {
+		return failureCheck{}, false
+	}
+	eq := binExpr.Op == token.EQL
+
+	var x ast.Expr
+	switch {
+	case isNilIdent(binExpr.Y):
+		x = binExpr.X
+	case isNilIdent(binExpr.X):
+		x = binExpr.Y
+	}
+	if x != nil {
+		checker := "IsNil"
+		if eq {
+			checker = "IsNotNil"
+		}
+		return failureCheck{got: x, checker: checker, stable: nilCheckStable(pass, x)}, true
+	}
+
+	if arg, ok := extractBuiltinLenArg(pass, binExpr.X); ok {
+		return failureCheck{got: arg, want: binExpr.Y, checker: "HasLen", not: eq, stable: true}, true
+	}
+	for _, operand := range []ast.Expr{binExpr.X, binExpr.Y} {
+		if t := pass.TypesInfo.TypeOf(operand); t != nil && isStructPointer(t) {
+			return failureCheck{}, false
+		}
+	}
+	return failureCheck{got: binExpr.X, want: binExpr.Y, checker: "Equals", not: eq, stable: true}, true
+}
+
+// predicateCheck maps a boolean condition to the assertion that it holds, or,
+// when not is set, that it does not. A call to one of failureFuncs gets its
+// own checker; any other boolean variable, field or call is asserted with
+// qt.IsTrue or qt.IsFalse.
+func predicateCheck(pass *analysis.Pass, expr ast.Expr, not bool) (failureCheck, bool) {
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 2 && !call.Ellipsis.IsValid() {
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+			for path, funcs := range failureFuncs {
+				fc, ok := funcs[sel.Sel.Name]
+				if !ok || !isQualifiedBy(pass, sel, path) {
+					continue
+				}
+				fc.got, fc.want, fc.not = call.Args[0], call.Args[1], not
+				if fc.checker == "DeepEquals" {
+					fc.stable = comparesLikeDeepEqual(pass, fc.got) && comparesLikeDeepEqual(pass, fc.want)
+				}
+				return fc, true
+			}
+		}
+	}
+
+	switch expr.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.CallExpr, *ast.IndexExpr:
+	default:
+		return failureCheck{}, false
+	}
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value != nil || !tv.IsValue() {
+		return failureCheck{}, false
+	}
+	if basic, ok := tv.Type.Underlying().(*types.Basic); !ok || basic.Kind() != types.Bool {
+		return failureCheck{}, false
+	}
+	checker := "IsTrue"
+	if not {
+		checker = "IsFalse"
+	}
+	return failureCheck{got: expr, checker: checker, stable: true}, true
+}
+
+// nilCheckStable reports whether qt.IsNil tells nil from non-nil in x the way
+// x == nil does. They part only on an interface holding a nil pointer, which
+// qt.IsNil calls nil; an error is the exception, since quicktest refuses to
+// call an error holding a nil pointer nil as well.
+func nilCheckStable(pass *analysis.Pass, x ast.Expr) bool {
+	typ := pass.TypesInfo.TypeOf(x)
+	return isNilAlike(typ) || isErrorType(pass, x)
+}
+
+// comparesLikeDeepEqual reports whether qt.DeepEquals compares expr the way
+// reflect.DeepEqual does.
+func comparesLikeDeepEqual(pass *analysis.Pass, expr ast.Expr) bool {
+	typ := pass.TypesInfo.TypeOf(expr)
+	return typ != nil && cmpComparesLikeDeepEqual(typ, make(map[types.Type]bool))
+}
+
+// fixable reports whether the assertion type-checks and means what the
+// condition meant once the block is replaced by it.
+//
+// frankban/quicktest's Equals boxes both operands as interfaces and compares
+// those, so an untyped constant, which the comparison gave the other side's
+// type, would arrive with its default type instead; the operands must have one
+// type as boxed. go-quicktest/qt's Equals and DeepEquals take both operands as
+// one type parameter, which reflect.DeepEqual's two interfaces never asked of
+// them.
+func (fc failureCheck) fixable(pass *analysis.Pass, spelling assertionSpelling) bool {
+	switch {
+	case fc.checker == "Equals" && spelling.cVar != "":
+		got, want := boxedType(pass, fc.got), boxedType(pass, fc.want)
+		return got != nil && want != nil && types.Identical(got, want)
+	case fc.checker == "Equals":
+		return sameOperandTypes(pass, fc.got, fc.want)
+	case fc.checker == "DeepEquals" && spelling.cVar == "":
+		got, want := pass.TypesInfo.TypeOf(fc.got), pass.TypesInfo.TypeOf(fc.want)
+		return got != nil && want != nil && types.Identical(got, want) && !isUntypedNil(got)
+	}
+	return true
+}
+
+// boxedType returns the dynamic type expr has once passed as an interface. A
+// comparison converts an untyped constant to the other operand's type, and
+// the type checker records that type; boxed, the constant takes its default
+// type instead. A constant expression with a typed constant or a conversion
+// in it, such as 2*time.Second, is typed, and keeps the recorded type.
+func boxedType(pass *analysis.Pass, expr ast.Expr) types.Type {
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil {
+		return tv.Type
+	}
+	if typ := untypedDefault(pass, expr); typ != nil {
+		return typ
+	}
+	return tv.Type
+}
+
+// untypedKinds orders the default types of untyped numeric constants: an
+// operation on two of them has the later one's kind.
+var untypedKinds = map[types.BasicKind]int{
+	types.Int:        0,
+	types.Int32:      1, // rune
+	types.Float64:    2,
+	types.Complex128: 3,
+}
+
+// untypedDefault returns the default type of expr, a constant expression,
+// when every constant in it is untyped, or nil when one is typed or is not a
+// constant the rule reads, such as the result of a conversion.
+func untypedDefault(pass *analysis.Pass, expr ast.Expr) types.Type {
+	switch e := stripParens(expr).(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.INT:
+			return types.Typ[types.Int]
+		case token.FLOAT:
+			return types.Typ[types.Float64]
+		case token.IMAG:
+			return types.Typ[types.Complex128]
+		case token.CHAR:
+			return types.Universe.Lookup("rune").Type()
+		case token.STRING:
+			return types.Typ[types.String]
+		}
+	case *ast.Ident:
+		return untypedConstDefault(pass.TypesInfo.Uses[e])
+	case *ast.SelectorExpr:
+		return untypedConstDefault(pass.TypesInfo.Uses[e.Sel])
+	case *ast.UnaryExpr:
+		return untypedDefault(pass, e.X)
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			// A comparison is an untyped bool, whatever it compares.
+			return types.Typ[types.Bool]
+		case token.SHL, token.SHR:
+			return untypedDefault(pass, e.X)
+		}
+		x, y := untypedDefault(pass, e.X), untypedDefault(pass, e.Y)
+		if x == nil || y == nil {
+			return nil
+		}
+		xb, _ := x.(*types.Basic)
+		yb, _ := y.(*types.Basic)
+		if xb != nil && yb != nil && untypedKinds[yb.Kind()] > untypedKinds[xb.Kind()] {
+			return y
+		}
+		return x
+	}
+	return nil
+}
+
+// untypedConstDefault returns the default type of obj when it is an untyped
+// constant, and nil otherwise.
+func untypedConstDefault(obj types.Object) types.Type {
+	c, ok := obj.(*types.Const)
+	if !ok {
+		return nil
+	}
+	if basic, ok := c.Type().(*types.Basic); !ok || basic.Info()&types.IsUntyped == 0 {
+		return nil
+	}
+	return types.Default(c.Type())
+}
+
+// isUntypedNil reports whether typ is the type of the nil literal.
+func isUntypedNil(typ types.Type) bool {
+	basic, ok := typ.(*types.Basic)
+	return ok && basic.Kind() == types.UntypedNil
+}
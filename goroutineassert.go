@@ -0,0 +1,210 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// goroutineStarters are the methods that run the function they are given in a
+// goroutine of their own, keyed by their full name.
+var goroutineStarters = map[string]bool{
+	"(*golang.org/x/sync/errgroup.Group).Go":    true,
+	"(*golang.org/x/sync/errgroup.Group).TryGo": true,
+	"(*sync.WaitGroup).Go":                      true,
+}
+
+// isPackageAssert reports whether call is the package-level Assert of either
+// quicktest package, whose first argument is the test it stops.
+func isPackageAssert(pass *analysis.Pass, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Assert" {
+		return false
+	}
+	return isPackageQualified(pass, sel) || isQualifiedBy(pass, sel, quicktestV2PkgPath)
+}
+
+// isAssertCall reports whether call is an assertion that stops the test when
+// it fails: qt.Assert from either package, or Assert on a *qt.C.
+func isAssertCall(pass *analysis.Pass, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Assert" {
+		return false
+	}
+	return isPackageAssert(pass, call) || isQuicktestCMethod(pass, sel)
+}
+
+// startsGoroutine reports whether call runs a function in a new goroutine
+// through one of goroutineStarters.
+func startsGoroutine(pass *analysis.Pass, call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	return ok && goroutineStarters[fn.FullName()]
+}
+
+// isSubtestRun reports whether call is Run on a *qt.C or a *testing.T, whose
+// function runs as a subtest, in a goroutine the testing package manages and
+// from which t.FailNow may be called.
+func isSubtestRun(pass *analysis.Pass, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Run" {
+		return false
+	}
+	if isQuicktestCMethod(pass, sel) {
+		return true
+	}
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "testing"
+}
+
+// checkGoroutineAsserts checks for an Assert made from a goroutine the test
+// started. Assert stops the test with t.FailNow, which the testing package
+// allows only on the goroutine running the test: called anywhere else it
+// ends that goroutine alone, and the test carries on or hangs waiting for it,
+// reporting a failure late or not at all. Check reports with t.Error, which
+// any goroutine may call.
+//
+// A goroutine is the function literal of a go statement, or one given to
+// errgroup.Group.Go or sync.WaitGroup.Go. An Assert written in it is reported,
+// and so is a call handing a *qt.C to a helper declared in the package that
+// Asserts with it, as far as calleeReach can follow the checker. So is a go
+// statement that calls Assert itself, as in go c.Assert(err, qt.IsNil). A subtest's
+// function runs on a goroutine of its own, and is not looked into.
+//
+// The fix turns Assert into Check. Check goes on past a failure where Assert
+// stopped, so the fix is only stable when the Assert is the last statement of
+// the goroutine's function; it is not offered when the result of Assert is
+// used, for a helper, which may be called from the test goroutine too, or for
+// go c.Assert(...), which nothing can wait for: Check made after the test has
+// ended panics.
+func (a *analyzer) checkGoroutineAsserts(pass *analysis.Pass, insp *inspector.Inspector) {
+	var callees *calleeReach
+	nodeFilter := []ast.Node{
+		(*ast.GoStmt)(nil),
+		(*ast.CallExpr)(nil),
+	}
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		var call *ast.CallExpr
+		var lit *ast.FuncLit
+		switch n := n.(type) {
+		case *ast.GoStmt:
+			call = n.Call
+			lit, _ = stripParens(call.Fun).(*ast.FuncLit)
+		case *ast.CallExpr:
+			if !startsGoroutine(pass, n) || len(n.Args) != 1 {
+				return
+			}
+			lit, _ = stripParens(n.Args[0]).(*ast.FuncLit)
+			if lit == nil {
+				return
+			}
+		}
+		if callees == nil {
+			callees = newCalleeReach(pass)
+		}
+		if lit != nil {
+			a.checkGoroutineBody(pass, callees, lit.Body)
+			return
+		}
+		if isAssertCall(pass, call) {
+			a.reportGoroutineAssert(pass, call, "nothing waits for a call started by go, and Check after the test has ended panics", false)
+			return
+		}
+		// go helper(c): the helper's body is the goroutine.
+		checkHelperAsserts(pass, callees, call)
+	})
+}
+
+// checkGoroutineBody reports the Asserts made in body, the body of a function
+// run as a goroutine.
+func (a *analyzer) checkGoroutineBody(pass *analysis.Pass, callees *calleeReach, body *ast.BlockStmt) {
+	statements := make(map[*ast.CallExpr]*ast.ExprStmt)
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GoStmt:
+			// A goroutine of its own, checked when the walk reaches it.
+			return false
+		case *ast.ExprStmt:
+			if call, ok := n.X.(*ast.CallExpr); ok {
+				statements[call] = n
+			}
+		case *ast.CallExpr:
+			if startsGoroutine(pass, n) || isSubtestRun(pass, n) {
+				return false
+			}
+			if isAssertCall(pass, n) {
+				stmt, reason := statements[n], ""
+				if stmt == nil {
+					reason = "the result of Assert is used"
+				}
+				a.reportGoroutineAssert(pass, n, reason, stmt != nil && stmt == lastStmt(body))
+				return true
+			}
+			checkHelperAsserts(pass, callees, n)
+		}
+		return true
+	})
+}
+
+// lastStmt returns the last statement of body, or nil when it is empty.
+func lastStmt(body *ast.BlockStmt) ast.Stmt {
+	if len(body.List) == 0 {
+		return nil
+	}
+	return body.List[len(body.List)-1]
+}
+
+// reportGoroutineAssert reports call, an Assert made in a goroutine, with the
+// fix turning it into Check unless reason says why there is none.
+func (a *analyzer) reportGoroutineAssert(pass *analysis.Pass, call *ast.CallExpr, reason string, stable bool) {
+	sel := call.Fun.(*ast.SelectorExpr)
+	recv, ok := formatExpr(pass, sel.X)
+	if !ok {
+		return
+	}
+	diag := analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("qtlint: %s.Assert in a goroutine stops the test with t.FailNow, which must be called from the test goroutine; use %s.Check",
+			recv, recv),
+	}
+	switch {
+	case reason != "":
+		diag.Message += "; no fix: " + reason
+	case stable || !a.onlyStableFixes:
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Replace Assert with Check",
+			TextEdits: []analysis.TextEdit{{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte("Check")}},
+		}}
+	}
+	pass.Report(diag)
+}
+
+// checkHelperAsserts reports call when it hands a *qt.C to a helper that
+// Asserts with it, in a goroutine.
+func checkHelperAsserts(pass *analysis.Pass, callees *calleeReach, call *ast.CallExpr) {
+	for i, arg := range call.Args {
+		ident, ok := stripParens(arg).(*ast.Ident)
+		if !ok || !isQuicktestCType(pass.TypesInfo.TypeOf(ident)) {
+			continue
+		}
+		reach, ok := callees.follow(call, i)
+		if !ok || !reach.asserts {
+			continue
+		}
+		helper, ok := formatExpr(pass, call.Fun)
+		if !ok {
+			return
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos: call.Pos(),
+			End: call.End(),
+			Message: fmt.Sprintf("qtlint: %s calls %s.Assert in a goroutine, and t.FailNow must be called from the test goroutine; no fix: the helper may also be called from the test goroutine",
+				helper, ident.Name),
+		})
+		return
+	}
+}
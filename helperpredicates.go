@@ -0,0 +1,271 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"regexp"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// helperChecker is the checker asserting what a helper function's result
+// says, with the helper's arguments as got and want.
+type helperChecker struct {
+	// checker is the checker's name, and v2Checker its name in
+	// go-quicktest/qt where the two differ.
+	checker   string
+	v2Checker string
+	// form turns the helper's second argument into a pattern, for a checker
+	// that takes one.
+	form patternForm
+	// bytes marks a helper over []byte, whose arguments the checker is given
+	// as strings.
+	bytes bool
+}
+
+// helperCheckers maps the helper functions an assertion may wrap in qt.IsTrue
+// or qt.IsFalse, by package path, to the checker that asserts the same thing
+// and prints got and want when it fails.
+var helperCheckers = map[string]map[string]helperChecker{
+	"reflect": {"DeepEqual": {checker: "DeepEquals"}},
+	"bytes": {
+		"Equal":    {checker: "DeepEquals"},
+		"Contains": {checker: "Contains", v2Checker: "StringContains", bytes: true},
+	},
+	"slices": {"Equal": {checker: "DeepEquals"}},
+	"maps":   {"Equal": {checker: "DeepEquals"}},
+	"strings": {
+		"HasPrefix": {checker: "Matches", form: quotePrefix},
+		"HasSuffix": {checker: "Matches", form: quoteSuffix},
+	},
+}
+
+// helperRewrite is the assertion a helper call wrapped in qt.IsTrue becomes.
+type helperRewrite struct {
+	// helper is the call as a message writes it, such as
+	// reflect.DeepEqual(x, y).
+	helper  string
+	checker helperChecker
+	// gotText and wantText are the checker's operands, and edits what the
+	// operands need besides, such as an import.
+	gotText, wantText string
+	edits             []analysis.TextEdit
+	// fixable is false when there is no fix, and stable false when the
+	// checker is only close to the helper.
+	fixable, stable bool
+}
+
+// matchHelperRewrite parses expr, the operand of qt.IsTrue or qt.IsFalse, into
+// the assertion it becomes. v2 is set for a go-quicktest/qt assertion, whose
+// generic checkers accept less than the helpers do.
+func matchHelperRewrite(pass *analysis.Pass, expr ast.Expr, v2 bool) (helperRewrite, bool) {
+	call, ok := stripParens(expr).(*ast.CallExpr)
+	if !ok || call.Ellipsis.IsValid() {
+		return helperRewrite{}, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return helperRewrite{}, false
+	}
+	if pkgName, re, ok := matchMustCompile(pass, sel.X); ok && sel.Sel.Name == "MatchString" && len(call.Args) == 1 {
+		return matchStringRewrite(pass, pkgName, re, call.Args[0])
+	}
+	if len(call.Args) != 2 {
+		return helperRewrite{}, false
+	}
+	pkgIdent, pkgPath, ok := qualifyingPackage(pass, sel)
+	if !ok {
+		return helperRewrite{}, false
+	}
+	hc, ok := helperCheckers[pkgPath][sel.Sel.Name]
+	if !ok {
+		return helperRewrite{}, false
+	}
+	got, want := call.Args[0], call.Args[1]
+	r := helperRewrite{
+		helper:  pkgIdent.Name + "." + sel.Sel.Name + "(x, y)",
+		checker: hc,
+		fixable: !isNilIdent(got) && !isNilIdent(want),
+		stable:  true,
+	}
+
+	if r.gotText, ok = formatExpr(pass, got); !ok {
+		return helperRewrite{}, false
+	}
+	if hc.checker == "Matches" {
+		var quoted bool
+		r.wantText, r.edits, quoted = quotedPattern(pass, hc.form, want)
+		r.fixable = r.fixable && quoted && isPlainString(pass, got)
+	} else if r.wantText, ok = formatExpr(pass, want); !ok {
+		return helperRewrite{}, false
+	}
+	if hc.bytes {
+		r.gotText, r.wantText = "string("+r.gotText+")", "string("+r.wantText+")"
+	}
+
+	if hc.checker == "DeepEquals" {
+		// reflect.DeepEqual is what qt.DeepEquals is closest to; the Equal
+		// helpers call a nil slice or map equal to an empty one, which
+		// qt.DeepEquals does not.
+		r.stable = pkgPath == "reflect" && comparesLikeDeepEqual(pass, got) && comparesLikeDeepEqual(pass, want)
+		if v2 {
+			gotType, wantType := pass.TypesInfo.TypeOf(got), pass.TypesInfo.TypeOf(want)
+			r.fixable = r.fixable && gotType != nil && wantType != nil && types.Identical(gotType, wantType)
+		}
+	}
+	return r, true
+}
+
+// matchMustCompile returns the constant pattern expr compiles with
+// regexp.MustCompile, and the name regexp is qualified by there.
+func matchMustCompile(pass *analysis.Pass, expr ast.Expr) (pkgName, pattern string, ok bool) {
+	call, ok := stripParens(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return "", "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "MustCompile" {
+		return "", "", false
+	}
+	pkgIdent, pkgPath, ok := qualifyingPackage(pass, sel)
+	if !ok || pkgPath != "regexp" {
+		return "", "", false
+	}
+	tv, ok := pass.TypesInfo.Types[call.Args[0]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", "", false
+	}
+	return pkgIdent.Name, constant.StringVal(tv.Value), true
+}
+
+// matchStringRewrite returns the qt.Matches assertion a
+// regexp.MustCompile(pattern).MatchString(s) call becomes. MatchString looks
+// for a match anywhere in s where qt.Matches matches the whole of it, so the
+// pattern is put between two (?s:.*); the group keeps the flag from reaching
+// the pattern itself. regexp.MatchString, the function, returns an error as
+// well, and is never an operand of qt.IsTrue.
+func matchStringRewrite(pass *analysis.Pass, pkgName, re string, s ast.Expr) (helperRewrite, bool) {
+	if _, err := regexp.Compile(re); err != nil {
+		return helperRewrite{}, false
+	}
+	gotText, ok := formatExpr(pass, s)
+	if !ok {
+		return helperRewrite{}, false
+	}
+	return helperRewrite{
+		helper:   pkgName + ".MustCompile(p).MatchString(x)",
+		checker:  helperChecker{checker: "Matches", form: asPattern},
+		gotText:  gotText,
+		wantText: patternLiteral("(?s:.*)(?:" + re + ")(?s:.*)"),
+		fixable:  isPlainString(pass, s),
+		stable:   true,
+	}, true
+}
+
+// isPlainString reports whether expr is a string, which qt.Matches takes, and
+// not of a named string type, which frankban/quicktest refuses as a bad check
+// and go-quicktest/qt does not compile.
+func isPlainString(pass *analysis.Pass, expr ast.Expr) bool {
+	typ := pass.TypesInfo.TypeOf(expr)
+	return typ != nil && types.Identical(types.Default(typ), types.Typ[types.String])
+}
+
+// checkHelperPredicatePattern checks for a call to a helper such as
+// reflect.DeepEqual or strings.HasPrefix wrapped in qt.IsTrue or qt.IsFalse,
+// in either API, and suggests the checker asserting the same thing. The
+// helper's bool hides both values from the failure message; the checker prints
+// them. It extends the strings.Contains and slices.Contains rule to the
+// other helpers a checker can stand in for.
+func (a *analyzer) checkHelperPredicatePattern(pass *analysis.Pass, call *ast.CallExpr) {
+	if isQuicktestAssertion(pass, call) {
+		a.checkV1HelperPredicate(pass, call)
+	} else if checker, ok := getV2Checker(pass, call); ok {
+		a.checkV2HelperPredicate(pass, checker)
+	}
+}
+
+func (a *analyzer) checkV1HelperPredicate(pass *analysis.Pass, call *ast.CallExpr) {
+	args := call.Args
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && isPackageQualified(pass, sel) && len(args) > 0 {
+		args = args[1:]
+	}
+	if len(args) < 2 {
+		return
+	}
+	gotArg, checkerArg := args[0], args[1]
+	checkerSel, ok := checkerArg.(*ast.SelectorExpr)
+	if !ok || (checkerSel.Sel.Name != "IsTrue" && checkerSel.Sel.Name != "IsFalse") || !isPackageQualified(pass, checkerSel) {
+		return
+	}
+	qtPkgIdent, ok := checkerSel.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+	r, ok := matchHelperRewrite(pass, gotArg, false)
+	if !ok {
+		return
+	}
+
+	use := "qt." + r.checker.checker
+	newCheckerText := qtPkgIdent.Name + "." + r.checker.checker
+	if checkerSel.Sel.Name == "IsFalse" {
+		use = "qt.Not(" + use + ")"
+		newCheckerText = qtPkgIdent.Name + ".Not(" + newCheckerText + ")"
+	}
+	diag := analysis.Diagnostic{
+		Pos:     gotArg.Pos(),
+		End:     checkerArg.End(),
+		Message: fmt.Sprintf("qtlint: use %s instead of %s, qt.%s", use, r.helper, checkerSel.Sel.Name),
+	}
+	if r.fixable && (r.stable || !a.onlyStableFixes) {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Replace with " + use,
+			TextEdits: append(r.edits,
+				analysis.TextEdit{Pos: gotArg.Pos(), End: gotArg.End(), NewText: []byte(r.gotText)},
+				analysis.TextEdit{Pos: checkerArg.Pos(), End: checkerArg.End(), NewText: []byte(newCheckerText + ", " + r.wantText)},
+			),
+		}}
+	}
+	pass.Report(diag)
+}
+
+func (a *analyzer) checkV2HelperPredicate(pass *analysis.Pass, checker v2Checker) {
+	arg, isTrue, ok := matchV2BoolChecker(checker)
+	if !ok {
+		return
+	}
+	r, ok := matchHelperRewrite(pass, arg, true)
+	if !ok {
+		return
+	}
+	name := r.checker.checker
+	if r.checker.v2Checker != "" {
+		name = r.checker.v2Checker
+	}
+
+	message := fmt.Sprintf("qtlint: use qt.%s(x, y) instead of qt.IsTrue(%s)", name, r.helper)
+	fixMessage := "Replace with qt." + name
+	if !isTrue {
+		message = fmt.Sprintf("qtlint: use qt.Not(qt.%s(x, y)) instead of qt.IsFalse(%s)", name, r.helper)
+		fixMessage = "Replace with qt.Not(qt." + name + ")"
+	}
+	diag := analysis.Diagnostic{
+		Pos:     checker.call.Pos(),
+		End:     checker.call.End(),
+		Message: message,
+	}
+	if r.fixable && (r.stable || !a.onlyStableFixes) {
+		text := v2Negate(checker.qtAlias, v2Call(checker.qtAlias, name, r.gotText, r.wantText), !isTrue)
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: fixMessage,
+			TextEdits: append(r.edits, analysis.TextEdit{
+				Pos:     checker.call.Pos(),
+				End:     checker.call.End(),
+				NewText: []byte(text),
+			}),
+		}}
+	}
+	pass.Report(diag)
+}
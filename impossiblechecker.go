@@ -0,0 +1,217 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkImpossibleChecker reports a frankban/quicktest assertion whose checker
+// cannot check the operand it is given, so that the assertion has the same
+// outcome whatever the operand's value. Every checker takes its operands as
+// interfaces, so the compiler accepts all of these:
+//
+//   - qt.IsNil or qt.IsNotNil on a type that cannot be nil;
+//   - qt.HasLen on a type with no length;
+//   - qt.ErrorIs or qt.ErrorAs on a type that is not an error;
+//   - qt.ErrorAs with a target that is not a pointer to an interface or to a
+//     type implementing error, which errors.As panics on;
+//   - qt.PanicMatches on anything but a func();
+//   - qt.Equals between operands whose dynamic types can never be the same.
+//
+// A checker wrapped in qt.Not is reported too. qt.Not turns the plain failure
+// of qt.IsNil and qt.Equals into a pass, but the others refuse the operand as
+// a bad check, and qt.Not passes that on. An operand of interface type is
+// never reported: what it holds is decided at run time. There is no fix; what
+// the test meant to check is not in the code.
+func checkImpossibleChecker(pass *analysis.Pass, call *ast.CallExpr, checkerArg ast.Expr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	gotIndex := 0
+	if isPackageQualified(pass, sel) {
+		gotIndex = 1
+	}
+	if len(call.Args) < gotIndex+2 || call.Ellipsis.IsValid() {
+		return
+	}
+	got, args := call.Args[gotIndex], call.Args[gotIndex+2:]
+
+	checker, not := checkerArg, false
+	if notCall, ok := checker.(*ast.CallExpr); ok && len(notCall.Args) == 1 {
+		if notSel, ok := notCall.Fun.(*ast.SelectorExpr); ok && notSel.Sel.Name == "Not" && isPackageQualified(pass, notSel) {
+			checker, not = notCall.Args[0], true
+		}
+	}
+	checkerSel, ok := checker.(*ast.SelectorExpr)
+	if !ok || !isPackageQualified(pass, checkerSel) {
+		return
+	}
+
+	var want ast.Expr
+	if len(args) > 0 && !isV1CommentType(pass.TypesInfo.TypeOf(args[0])) {
+		want = args[0]
+	}
+	if message := impossibleCheck(pass, checkerSel.Sel.Name, not, got, want); message != "" {
+		pass.Report(analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: message,
+		})
+	}
+}
+
+// checkV2ImpossibleChecker is the go-quicktest/qt counterpart of
+// checkImpossibleChecker. The generic signatures rule out most of its cases at
+// compile time; qt.IsNil, qt.IsNotNil and qt.HasLen take any T, and qt.ErrorAs
+// any *T as its target, and those are still checked.
+func checkV2ImpossibleChecker(pass *analysis.Pass, checker v2Checker) {
+	inner, not := unwrapV2Not(pass, checker)
+	switch inner.name {
+	case "IsNil", "IsNotNil", "HasLen", "ErrorAs":
+	default:
+		return
+	}
+	if len(inner.call.Args) == 0 {
+		return
+	}
+	var want ast.Expr
+	if len(inner.call.Args) > 1 {
+		want = inner.call.Args[1]
+	}
+	if message := impossibleCheck(pass, inner.name, not, inner.call.Args[0], want); message != "" {
+		pass.Report(analysis.Diagnostic{
+			Pos:     checker.call.Pos(),
+			End:     checker.call.End(),
+			Message: message,
+		})
+	}
+}
+
+// impossibleCheck returns the message reporting checker, negated when not is
+// set, applied to got and want, or "" when the operands may be what the
+// checker checks.
+func impossibleCheck(pass *analysis.Pass, checker string, not bool, got, want ast.Expr) string {
+	gotType := concreteType(pass, got)
+	written := "qt." + checker
+	if not {
+		written = "qt.Not(" + written + ")"
+	}
+	outcome := func(fails bool) string {
+		if fails {
+			return written + " always fails"
+		}
+		return written + " always passes"
+	}
+
+	switch checker {
+	case "IsNil", "IsNotNil":
+		if gotType != nil && !isNillable(gotType) {
+			return fmt.Sprintf("qtlint: %s on %s, which cannot be nil", outcome((checker == "IsNil") != not), typeString(gotType))
+		}
+	case "HasLen":
+		if gotType != nil && !hasLength(gotType) {
+			return fmt.Sprintf("qtlint: %s on %s, which has no length", outcome(true), typeString(gotType))
+		}
+	case "ErrorIs", "ErrorAs":
+		if gotType != nil && !types.AssignableTo(gotType, errorType()) {
+			return fmt.Sprintf("qtlint: %s on %s, which is not an error", outcome(true), typeString(gotType))
+		}
+		if checker == "ErrorAs" && want != nil {
+			return errorAsTarget(pass, want)
+		}
+	case "PanicMatches":
+		if gotType != nil && !isNiladicFunc(gotType) {
+			return fmt.Sprintf("qtlint: %s on %s, which is not a func()", outcome(true), typeString(gotType))
+		}
+	case "Equals":
+		wantType := concreteType(pass, want)
+		if want == nil || isNilIdent(want) || isNilIdent(got) {
+			return ""
+		}
+		if neverSameDynamicType(gotType, wantType, pass.TypesInfo.TypeOf(got), pass.TypesInfo.TypeOf(want)) {
+			return fmt.Sprintf("qtlint: %s on %s and %s, which are never equal", outcome(!not),
+				typeString(pass.TypesInfo.TypeOf(got)), typeString(pass.TypesInfo.TypeOf(want)))
+		}
+	}
+	return ""
+}
+
+// concreteType returns the type expr has as an argument to an interface
+// parameter, or nil when that is an interface, whose dynamic type is only
+// known at run time, or is not known at all. The type checker records an
+// untyped constant passed as an interface with the default type it is boxed
+// with.
+func concreteType(pass *analysis.Pass, expr ast.Expr) types.Type {
+	if expr == nil {
+		return nil
+	}
+	typ := pass.TypesInfo.TypeOf(expr)
+	if typ == nil || types.IsInterface(typ) || isUntypedNil(typ) {
+		return nil
+	}
+	return types.Default(typ)
+}
+
+// hasLength reports whether quicktest's HasLen accepts a value of typ: a
+// string, array, slice, map or channel. A pointer to an array has a length
+// for len but not for HasLen, which asks reflect.
+func hasLength(typ types.Type) bool {
+	switch under := typ.Underlying().(type) {
+	case *types.Array, *types.Slice, *types.Map, *types.Chan:
+		return true
+	case *types.Basic:
+		return under.Info()&types.IsString != 0
+	}
+	return false
+}
+
+// isNiladicFunc reports whether a value of typ is a func() once boxed. A
+// named func type is not: quicktest asserts got.(func()), which only an
+// unnamed func() type satisfies.
+func isNiladicFunc(typ types.Type) bool {
+	sig, ok := types.Unalias(typ).(*types.Signature)
+	return ok && sig.Params().Len() == 0 && sig.Results().Len() == 0
+}
+
+// errorAsTarget returns the message reporting a qt.ErrorAs target that
+// errors.As panics on, or "" when target is a pointer to an interface or to
+// a type implementing error.
+func errorAsTarget(pass *analysis.Pass, target ast.Expr) string {
+	typ := pass.TypesInfo.TypeOf(target)
+	switch {
+	case typ == nil || types.IsInterface(typ):
+		return ""
+	case isUntypedNil(typ):
+		return "qtlint: qt.ErrorAs panics on a nil target"
+	}
+	ptr, ok := typ.Underlying().(*types.Pointer)
+	if !ok {
+		return fmt.Sprintf("qtlint: qt.ErrorAs panics on target %s, which is not a pointer", typeString(typ))
+	}
+	if !types.IsInterface(ptr.Elem()) && !types.AssignableTo(ptr.Elem(), errorType()) {
+		return fmt.Sprintf("qtlint: qt.ErrorAs panics on target %s, which points to neither an interface nor an error",
+			typeString(typ))
+	}
+	return ""
+}
+
+// neverSameDynamicType reports whether two operands boxed as interfaces can
+// never hold the same dynamic type: both are concrete and differ, or one is
+// concrete and does not implement the other's interface. got and want are the
+// operands' concrete types, nil for an interface, and gotStatic and
+// wantStatic their static types.
+func neverSameDynamicType(got, want, gotStatic, wantStatic types.Type) bool {
+	switch {
+	case got != nil && want != nil:
+		return !types.Identical(got, want)
+	case got != nil && wantStatic != nil && types.IsInterface(wantStatic):
+		return !types.Implements(got, wantStatic.Underlying().(*types.Interface))
+	case want != nil && gotStatic != nil && types.IsInterface(gotStatic):
+		return !types.Implements(want, gotStatic.Underlying().(*types.Interface))
+	}
+	return false
+}
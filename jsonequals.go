@@ -0,0 +1,404 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// jsonPkgPath is encoding/json, whose Unmarshal and Marshal the JSONEquals rule
+// looks for.
+const jsonPkgPath = "encoding/json"
+
+// jsonCall returns the arguments of expr when it calls encoding/json's
+// function name.
+func jsonCall(pass *analysis.Pass, expr ast.Expr, name string) ([]ast.Expr, bool) {
+	call, ok := stripParens(expr).(*ast.CallExpr)
+	if !ok || call.Ellipsis.IsValid() {
+		return nil, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name || !isQualifiedBy(pass, sel, jsonPkgPath) {
+		return nil, false
+	}
+	return call.Args, true
+}
+
+// localVar returns the local variable expr names.
+func localVar(pass *analysis.Pass, expr ast.Expr) (*types.Var, bool) {
+	ident, ok := stripParens(expr).(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+	v, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || v.Parent() == nil || v.Parent() == v.Pkg().Scope() {
+		return nil, false
+	}
+	return v, true
+}
+
+// usesAnyObject reports whether any statement of stmts uses an object expr
+// refers to.
+func usesAnyObject(pass *analysis.Pass, stmts []ast.Stmt, expr ast.Expr) bool {
+	var objs []types.Object
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok {
+			if obj := pass.TypesInfo.Uses[ident]; obj != nil {
+				objs = append(objs, obj)
+			}
+		}
+		return true
+	})
+	for _, stmt := range stmts {
+		for _, obj := range objs {
+			if usesObject(pass, stmt, obj) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// countUses counts the identifiers in the package that refer to obj.
+func countUses(pass *analysis.Pass, obj types.Object) int {
+	var count int
+	for _, used := range pass.TypesInfo.Uses {
+		if used == obj {
+			count++
+		}
+	}
+	return count
+}
+
+// jsonDocument renders expr, which holds JSON, as an operand qt.JSONEquals
+// accepts. Both APIs take a []byte or a string and nothing of another type,
+// however alike: frankban/quicktest asserts got to one of the two, and
+// go-quicktest/qt constrains its type parameter to exactly those.
+func jsonDocument(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	text, ok := formatExpr(pass, expr)
+	if !ok {
+		return "", false
+	}
+	typ := pass.TypesInfo.TypeOf(expr)
+	if typ == nil {
+		return "", false
+	}
+	switch {
+	case types.Identical(typ, types.NewSlice(types.Typ[types.Byte])), types.Identical(types.Default(typ), types.Typ[types.String]):
+		return text, true
+	case types.AssignableTo(typ.Underlying(), types.NewSlice(types.Typ[types.Byte])):
+		return "[]byte(" + text + ")", true
+	case basicHas(typ, types.IsString):
+		return "string(" + text + ")", true
+	}
+	return "", false
+}
+
+// decodesLikeJSONEquals reports whether a value of typ holds what
+// json.Unmarshal decodes into an interface: an interface, or a map or slice
+// of interfaces. qt.JSONEquals decodes got that way, so comparing the two
+// compares the same values; decoding into a struct drops the fields it does
+// not name, which qt.JSONEquals would go on to compare.
+func decodesLikeJSONEquals(typ types.Type) bool {
+	switch under := typ.Underlying().(type) {
+	case *types.Interface:
+		return true
+	case *types.Map:
+		return types.IsInterface(under.Elem())
+	case *types.Slice:
+		return types.IsInterface(under.Elem())
+	}
+	return false
+}
+
+// jsonEqualsSite is one assertion the JSONEquals rule reports and what it
+// becomes.
+type jsonEqualsSite struct {
+	assertion assertionStmt
+	message   string
+	// gotText and wantText are the operands qt.JSONEquals is given; either is
+	// empty when the assertion's own is kept.
+	gotText, wantText string
+	// removals are the statements the rewrite leaves with nothing to do.
+	removals []ast.Node
+	// reason says why there is no fix, and is empty when there is one.
+	reason string
+	stable bool
+}
+
+// checkPreferJSONEquals checks each block for JSON decoded only to be
+// compared, and suggests qt.JSONEquals, which decodes it itself and reports
+// the document and the value it is compared with. The shapes are
+//
+//	var got map[string]any
+//	c.Assert(json.Unmarshal(body, &got), qt.IsNil)
+//	c.Assert(got, qt.DeepEquals, want)
+//
+// which becomes c.Assert(body, qt.JSONEquals, want), and
+//
+//	wantJSON, err := json.Marshal(want)
+//	c.Assert(err, qt.IsNil)
+//	c.Assert(string(gotJSON), qt.Equals, string(wantJSON))
+//
+// which becomes c.Assert(string(gotJSON), qt.JSONEquals, want), in either API.
+// The statements producing the value compared go with the rewrite, so it is
+// reported without a fix when that value is used anywhere else.
+func (a *analyzer) checkPreferJSONEquals(pass *analysis.Pass, insp *inspector.Inspector) {
+	insp.Preorder([]ast.Node{(*ast.BlockStmt)(nil)}, func(n ast.Node) {
+		block := n.(*ast.BlockStmt)
+		for i, stmt := range block.List {
+			if site, ok := matchUnmarshalCompare(pass, block, i, stmt); ok {
+				a.reportJSONEquals(pass, site)
+			}
+			if site, ok := matchMarshalCompare(pass, block, i); ok {
+				a.reportJSONEquals(pass, site)
+			}
+		}
+	})
+}
+
+// matchUnmarshalCompare matches stmt, the i-th of block, as the assertion
+// that json.Unmarshal succeeded, and the first later statement using the
+// variable it decodes into as that variable's comparison with qt.DeepEquals.
+func matchUnmarshalCompare(pass *analysis.Pass, block *ast.BlockStmt, i int, stmt ast.Stmt) (jsonEqualsSite, bool) {
+	decode, ok := matchAssertionStmt(pass, stmt)
+	if !ok || decode.checker.Sel.Name != "IsNil" {
+		return jsonEqualsSite{}, false
+	}
+	args, ok := jsonCall(pass, decode.got, "Unmarshal")
+	if !ok || len(args) != 2 {
+		return jsonEqualsSite{}, false
+	}
+	data := args[0]
+	addr, ok := stripParens(args[1]).(*ast.UnaryExpr)
+	if !ok || addr.Op != token.AND {
+		return jsonEqualsSite{}, false
+	}
+	v, ok := localVar(pass, addr.X)
+	if !ok {
+		return jsonEqualsSite{}, false
+	}
+
+	j := i + 1
+	for j < len(block.List) && !usesObject(pass, block.List[j], v) {
+		j++
+	}
+	if j == len(block.List) {
+		return jsonEqualsSite{}, false
+	}
+	compare, ok := matchAssertionStmt(pass, block.List[j])
+	if !ok || compare.checker.Sel.Name != "DeepEquals" {
+		return jsonEqualsSite{}, false
+	}
+	if got, ok := localVar(pass, compare.got); !ok || got != v || usesObject(pass, compare.want, v) {
+		return jsonEqualsSite{}, false
+	}
+
+	site := jsonEqualsSite{
+		assertion: compare,
+		message:   fmt.Sprintf("qtlint: use qt.JSONEquals instead of json.Unmarshal into %s and qt.DeepEquals", v.Name()),
+		stable:    decodesLikeJSONEquals(v.Type()),
+	}
+	var removeDecl ast.Node
+	for _, s := range block.List[:i] {
+		if declaresVarAlone(pass, s, v) {
+			removeDecl = s
+		}
+	}
+	switch {
+	case countUses(pass, v) != 2:
+		site.reason = fmt.Sprintf("%s is used again", v.Name())
+	case removeDecl == nil:
+		site.reason = fmt.Sprintf("%s is not declared with a bare var in this block", v.Name())
+	case !decode.bare:
+		site.reason = "the json.Unmarshal assertion carries a comment"
+	case usesAnyObject(pass, block.List[i+1:j], data):
+		site.reason = "the statements between the two change what json.Unmarshal decodes"
+	}
+	if site.reason != "" {
+		return site, true
+	}
+	if site.gotText, ok = jsonDocument(pass, data); !ok {
+		site.reason = "the decoded document is neither a []byte nor a string"
+		return site, true
+	}
+	site.removals = []ast.Node{removeDecl, stmt}
+	return site, true
+}
+
+// matchMarshalCompare matches the i-th statement of block as
+// x, err := json.Marshal(v), the next as the assertion that err is nil, and
+// the first later statement using x as its comparison with qt.Equals or
+// qt.DeepEquals against the JSON compared.
+func matchMarshalCompare(pass *analysis.Pass, block *ast.BlockStmt, i int) (jsonEqualsSite, bool) {
+	if i+1 >= len(block.List) {
+		return jsonEqualsSite{}, false
+	}
+	assign, ok := block.List[i].(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 2 || len(assign.Rhs) != 1 {
+		return jsonEqualsSite{}, false
+	}
+	args, ok := jsonCall(pass, assign.Rhs[0], "Marshal")
+	if !ok || len(args) != 1 {
+		return jsonEqualsSite{}, false
+	}
+	value := args[0]
+	xIdent, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return jsonEqualsSite{}, false
+	}
+	x, ok := pass.TypesInfo.ObjectOf(xIdent).(*types.Var)
+	if !ok {
+		return jsonEqualsSite{}, false
+	}
+	errCheck, ok := matchAssertionStmt(pass, block.List[i+1])
+	if !ok || errCheck.checker.Sel.Name != "IsNil" {
+		return jsonEqualsSite{}, false
+	}
+	errIdent, ok := assign.Lhs[1].(*ast.Ident)
+	if !ok {
+		return jsonEqualsSite{}, false
+	}
+	errVar, ok := localVar(pass, errCheck.got)
+	if !ok || pass.TypesInfo.ObjectOf(errIdent) != errVar {
+		return jsonEqualsSite{}, false
+	}
+
+	j := i + 2
+	for j < len(block.List) && !usesObject(pass, block.List[j], x) {
+		j++
+	}
+	if j == len(block.List) {
+		return jsonEqualsSite{}, false
+	}
+	compare, ok := matchAssertionStmt(pass, block.List[j])
+	if !ok || compare.want == nil {
+		return jsonEqualsSite{}, false
+	}
+	checker := compare.checker.Sel.Name
+	if checker != "Equals" && checker != "DeepEquals" {
+		return jsonEqualsSite{}, false
+	}
+	// The marshalled value is want; got is whatever the test compares it
+	// with, itself a json.Marshal result as often as not.
+	if !isMarshalled(pass, compare.want, x) || usesObject(pass, compare.got, x) {
+		return jsonEqualsSite{}, false
+	}
+
+	site := jsonEqualsSite{
+		assertion: compare,
+		message: fmt.Sprintf("qtlint: use qt.JSONEquals instead of comparing the json.Marshal output %s with qt.%s",
+			x.Name(), checker),
+		stable: true,
+	}
+	switch {
+	case countUses(pass, x) != 1:
+		site.reason = fmt.Sprintf("%s is used again", x.Name())
+	case !errCheck.bare:
+		site.reason = "the assertion on json.Marshal's error carries a comment"
+	case usesLater(pass, block.List[i+2:], errVar):
+		// Removing the assignment leaves a later read of err with the
+		// value it had before, or with no declaration at all.
+		site.reason = fmt.Sprintf("%s is used again", errVar.Name())
+	case usesAnyObject(pass, block.List[i+2:j], value):
+		site.reason = "the statements between the two change what json.Marshal encodes"
+	}
+	if site.reason != "" {
+		return site, true
+	}
+	if site.wantText, ok = formatExpr(pass, value); !ok {
+		return jsonEqualsSite{}, false
+	}
+	if site.gotText, ok = jsonDocument(pass, compare.got); !ok {
+		site.reason = "the JSON compared is neither a []byte nor a string"
+		return site, true
+	}
+	site.removals = []ast.Node{block.List[i], block.List[i+1]}
+	return site, true
+}
+
+// usesLater reports whether any of stmts uses v.
+func usesLater(pass *analysis.Pass, stmts []ast.Stmt, v *types.Var) bool {
+	for _, stmt := range stmts {
+		if usesObject(pass, stmt, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// isMarshalled reports whether expr is x, or x converted to a string.
+func isMarshalled(pass *analysis.Pass, expr ast.Expr, x *types.Var) bool {
+	expr = stripParens(expr)
+	if conv, ok := expr.(*ast.CallExpr); ok && len(conv.Args) == 1 {
+		if tv, ok := pass.TypesInfo.Types[conv.Fun]; ok && tv.IsType() && basicHas(tv.Type, types.IsString) {
+			expr = stripParens(conv.Args[0])
+		}
+	}
+	v, ok := localVar(pass, expr)
+	return ok && v == x
+}
+
+// declaresVarAlone reports whether stmt declares v with a bare var and
+// nothing else, so that removing stmt removes v alone.
+func declaresVarAlone(pass *analysis.Pass, stmt ast.Stmt, v *types.Var) bool {
+	decl, ok := stmt.(*ast.DeclStmt)
+	if !ok {
+		return false
+	}
+	gen, ok := decl.Decl.(*ast.GenDecl)
+	if !ok || gen.Tok != token.VAR || len(gen.Specs) != 1 {
+		return false
+	}
+	spec, ok := gen.Specs[0].(*ast.ValueSpec)
+	if !ok || len(spec.Names) != 1 || len(spec.Values) != 0 {
+		return false
+	}
+	return pass.TypesInfo.Defs[spec.Names[0]] == v
+}
+
+func (a *analyzer) reportJSONEquals(pass *analysis.Pass, site jsonEqualsSite) {
+	diag := analysis.Diagnostic{
+		Pos:     site.assertion.stmt.Pos(),
+		End:     site.assertion.stmt.End(),
+		Message: site.message,
+	}
+	if site.reason != "" {
+		diag.Message += "; no fix: " + site.reason
+		pass.Report(diag)
+		return
+	}
+	if !site.stable && a.onlyStableFixes {
+		pass.Report(diag)
+		return
+	}
+
+	var edits []analysis.TextEdit
+	for _, node := range site.removals {
+		start, end, ok := wholeLineSpan(pass, node)
+		if !ok {
+			pass.Report(diag)
+			return
+		}
+		edits = append(edits, analysis.TextEdit{Pos: start, End: end})
+	}
+	checker := site.assertion.checker
+	edits = append(edits, analysis.TextEdit{Pos: checker.Sel.Pos(), End: checker.Sel.End(), NewText: []byte("JSONEquals")})
+	if site.gotText != "" {
+		got := site.assertion.got
+		edits = append(edits, analysis.TextEdit{Pos: got.Pos(), End: got.End(), NewText: []byte(site.gotText)})
+	}
+	if site.wantText != "" {
+		want := site.assertion.want
+		edits = append(edits, analysis.TextEdit{Pos: want.Pos(), End: want.End(), NewText: []byte(site.wantText)})
+	}
+	diag.SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   "Replace with qt.JSONEquals",
+		TextEdits: edits,
+	}}
+	pass.Report(diag)
+}
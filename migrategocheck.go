@@ -0,0 +1,717 @@
+package qtlint
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// gocheckPkgPath is the import path of gocheck, the library quicktest was
+// modelled on.
+const gocheckPkgPath = "gopkg.in/check.v1"
+
+// gocheckCheckers maps every gocheck checker the migration rewrites to its
+// quicktest name. FitsTypeOf and Panics have no quicktest counterpart.
+var gocheckCheckers = map[string]string{
+	"Equals":       "Equals",
+	"DeepEquals":   "DeepEquals",
+	"IsNil":        "IsNil",
+	"NotNil":       "IsNotNil",
+	"HasLen":       "HasLen",
+	"ErrorMatches": "ErrorMatches",
+	"Matches":      "Matches",
+	"PanicMatches": "PanicMatches",
+	"Implements":   "Implements",
+}
+
+// gocheckCMethods maps the *check.C methods a migrated test may call to their
+// *qt.C names. Most come to *qt.C from the testing.TB it embeds; MkDir is
+// quicktest's Mkdir. The rest of *check.C — TestName, Succeed, the benchmark
+// timers — has no counterpart.
+var gocheckCMethods = map[string]string{
+	"Assert":  "Assert",
+	"Check":   "Check",
+	"Error":   "Error",
+	"Errorf":  "Errorf",
+	"Fail":    "Fail",
+	"FailNow": "FailNow",
+	"Failed":  "Failed",
+	"Fatal":   "Fatal",
+	"Fatalf":  "Fatalf",
+	"Log":     "Log",
+	"Logf":    "Logf",
+	"MkDir":   "Mkdir",
+	"Skip":    "Skip",
+	"Skipf":   "Skipf",
+}
+
+// gocheckFixtures are the suite methods gocheck runs around a suite's tests.
+var gocheckFixtures = []string{"SetUpSuite", "SetUpTest", "TearDownTest", "TearDownSuite"}
+
+// gocheckTest is one gocheck test method and the plain test function it
+// becomes.
+type gocheckTest struct {
+	decl  *ast.FuncDecl
+	suite string
+	// c is the *check.C parameter, or nil when it is unnamed.
+	c types.Object
+	// name and tName are the function's new name and its *testing.T.
+	name  string
+	tName string
+	// edits rewrite the method, and reason says why there are none. stable
+	// is false when a checker compares a little differently in quicktest.
+	edits  []analysis.TextEdit
+	reason string
+	stable bool
+}
+
+// gocheckMigration is the plan for one file's gocheck test methods.
+type gocheckMigration struct {
+	pass *analysis.Pass
+	file *ast.File
+	spec *ast.ImportSpec
+	// qtName and testingName qualify the rewritten code. imports adds
+	// quicktest after gocheck's import when the file does not import it yet,
+	// and stdImports adds testing among the file's standard-library imports.
+	// blocker says why neither can be named, when that is so.
+	qtName      string
+	testingName string
+	imports     *analysis.TextEdit
+	stdImports  *analysis.TextEdit
+	blocker     string
+	tests       []*gocheckTest
+	// covered are the identifiers the plans rewrite. Anything else in the
+	// file that refers to gocheck keeps its import.
+	covered map[*ast.Ident]bool
+}
+
+// checkMigrateFromGocheck reports every gocheck test method and suggests the
+// plain test function it becomes.
+//
+// gocheck is where quicktest's shape comes from, so an assertion carries over
+// by renaming its checker: c.Assert(x, check.Equals, y) becomes
+// c.Assert(x, qt.Equals, y), and check.Commentf becomes qt.Commentf. What
+// changes is the test around it. A suite method named Test… taking a *check.C
+// becomes a top-level func TestX(t *testing.T) whose first statement makes
+// the *qt.C under the name the method gave its *check.C.
+//
+// A suite with fixtures (SetUpTest, TearDownSuite and the rest) does not come
+// apart mechanically, because a plain test function has nothing that runs
+// around it; nor does a test that reaches its suite through the receiver.
+// Those are reported without a fix.
+//
+// Unlike every other rule, this one does not need a quicktest import to fire:
+// its job is to bring a file to quicktest, and gocheck is quicktest's own
+// ancestor.
+func (a *analyzer) checkMigrateFromGocheck(pass *analysis.Pass) {
+	counts := make(map[string]int)
+	var plans []*gocheckMigration
+	for _, file := range pass.Files {
+		spec := importSpecFor(file, gocheckPkgPath)
+		if spec == nil {
+			continue
+		}
+		m := &gocheckMigration{pass: pass, file: file, spec: spec, covered: make(map[*ast.Ident]bool)}
+		for _, decl := range file.Decls {
+			if t := m.matchTest(decl); t != nil {
+				m.tests = append(m.tests, t)
+				counts[t.decl.Name.Name]++
+			}
+		}
+		if len(m.tests) > 0 {
+			plans = append(plans, m)
+		}
+	}
+
+	for _, m := range plans {
+		m.planImports()
+		for _, t := range m.tests {
+			m.plan(t, counts)
+		}
+		m.report(a.onlyStableFixes)
+	}
+}
+
+// matchTest returns the test decl declares when it is a gocheck test method:
+// a method whose name starts with Test and whose one parameter is a *check.C.
+func (m *gocheckMigration) matchTest(decl ast.Decl) *gocheckTest {
+	fn, ok := decl.(*ast.FuncDecl)
+	if !ok || fn.Recv == nil || fn.Body == nil || !strings.HasPrefix(fn.Name.Name, "Test") {
+		return nil
+	}
+	if fn.Type.Results != nil || fn.Type.Params.NumFields() != 1 {
+		return nil
+	}
+	param := fn.Type.Params.List[0]
+	if !isGocheckCPtr(m.pass.TypesInfo.TypeOf(param.Type)) {
+		return nil
+	}
+	suite, ok := receiverNamed(m.pass, fn)
+	if !ok {
+		return nil
+	}
+	t := &gocheckTest{decl: fn, suite: suite.Obj().Name(), stable: true}
+	if len(param.Names) == 1 && param.Names[0].Name != "_" {
+		t.c = m.pass.TypesInfo.Defs[param.Names[0]]
+	}
+	return t
+}
+
+// isGocheckCPtr reports whether typ is *check.C.
+func isGocheckCPtr(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	ptr, ok := types.Unalias(typ).(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := types.Unalias(ptr.Elem()).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == gocheckPkgPath && obj.Name() == "C"
+}
+
+// receiverNamed returns the named type fn is a method of.
+func receiverNamed(pass *analysis.Pass, fn *ast.FuncDecl) (*types.Named, bool) {
+	typ := pass.TypesInfo.TypeOf(fn.Recv.List[0].Type)
+	if typ == nil {
+		return nil, false
+	}
+	if ptr, ok := types.Unalias(typ).(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := types.Unalias(typ).(*types.Named)
+	return named, ok
+}
+
+// isGocheckObj reports whether obj is declared by gocheck, or is an import
+// of it.
+func isGocheckObj(obj types.Object) bool {
+	if pkgName, ok := obj.(*types.PkgName); ok {
+		return pkgName.Imported().Path() == gocheckPkgPath
+	}
+	return obj != nil && obj.Pkg() != nil && obj.Pkg().Path() == gocheckPkgPath
+}
+
+// planImports picks the names the rewritten code is qualified by and plans
+// the import of whichever of quicktest and testing the file lacks, grouped as
+// goimports groups them. quicktest goes on the line after gocheck's, and
+// testing in order among the standard-library imports of gocheck's
+// declaration, or in a group of its own ahead of the rest when there are
+// none.
+func (m *gocheckMigration) planImports() {
+	var added, addedStd []string
+	name := func(path, want string) string {
+		if spec := importSpecFor(m.file, path); spec != nil {
+			if name := importedPkgName(m.pass, m.file, path); name != "" {
+				return name
+			}
+			m.blocker = fmt.Sprintf("%s is imported without a name the rewrite can qualify with", path)
+			return ""
+		}
+		if m.pass.Pkg.Scope().Lookup(want) != nil || importedPkgNameIn(m.file, want) {
+			m.blocker = fmt.Sprintf("the name %s, which the rewrite would import %s under, is taken", want, path)
+			return ""
+		}
+		if path == quicktestPkgPath {
+			added = append(added, want+" "+strconv.Quote(path))
+		} else {
+			addedStd = append(addedStd, strconv.Quote(path))
+		}
+		return want
+	}
+	m.qtName = name(quicktestPkgPath, "qt")
+	m.testingName = name(testingPkgPath, "testing")
+	if m.blocker != "" || len(added)+len(addedStd) == 0 {
+		return
+	}
+
+	var node ast.Node = m.spec
+	decl := importDeclOf(m.file, m.spec)
+	grouped := true
+	if decl != nil && !decl.Lparen.IsValid() {
+		node, grouped = decl, false
+	}
+	_, end, ok := wholeLineSpan(m.pass, node)
+	if !ok {
+		m.blocker = "gocheck's import shares its line with other code"
+		return
+	}
+	lines := func(specs []string) string {
+		var text strings.Builder
+		for _, spec := range specs {
+			if !grouped {
+				text.WriteString("import ")
+			}
+			text.WriteString(spec + "\n")
+		}
+		return text.String()
+	}
+	text := lines(added)
+	if len(addedStd) > 0 {
+		// When gocheck's import is a declaration of its own, testing's is
+		// one too, after it and ahead of quicktest's.
+		pos, std, ok := token.NoPos, "", false
+		if grouped && decl != nil {
+			pos, std, ok = m.stdImportAt(decl, addedStd[0])
+		}
+		if !ok || pos == end {
+			text = lines(addedStd) + text
+		} else {
+			m.stdImports = &analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(std)}
+		}
+	}
+	if text != "" {
+		m.imports = &analysis.TextEdit{Pos: end, End: end, NewText: []byte(text)}
+	}
+}
+
+// stdImportAt returns where spec, a standard-library import, goes in decl and
+// the text to insert there: in order among decl's standard-library imports,
+// or as a group of its own at the top when it has none.
+func (m *gocheckMigration) stdImportAt(decl *ast.GenDecl, spec string) (token.Pos, string, bool) {
+	var last ast.Spec
+	for _, s := range decl.Specs {
+		imp := s.(*ast.ImportSpec)
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || strings.Contains(strings.Split(path, "/")[0], ".") {
+			continue
+		}
+		if imp.Path.Value > spec {
+			start, _, ok := wholeLineSpan(m.pass, imp)
+			return start, spec + "\n", ok
+		}
+		last = imp
+	}
+	if last != nil {
+		_, end, ok := wholeLineSpan(m.pass, last)
+		return end, spec + "\n", ok
+	}
+	if len(decl.Specs) == 0 {
+		return token.NoPos, "", false
+	}
+	start, _, ok := wholeLineSpan(m.pass, decl.Specs[0])
+	return start, spec + "\n\n", ok
+}
+
+// importedPkgNameIn reports whether file imports a package under name.
+func importedPkgNameIn(file *ast.File, name string) bool {
+	for _, imp := range file.Imports {
+		if imp.Name != nil && imp.Name.Name == name {
+			return true
+		}
+		if path, err := strconv.Unquote(imp.Path.Value); err == nil && imp.Name == nil && path[strings.LastIndex(path, "/")+1:] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// importDeclOf returns the import declaration holding spec.
+func importDeclOf(file *ast.File, spec *ast.ImportSpec) *ast.GenDecl {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if ok && gen.Tok == token.IMPORT && slices.Contains(gen.Specs, ast.Spec(spec)) {
+			return gen
+		}
+	}
+	return nil
+}
+
+// plan works out the test function t becomes.
+func (m *gocheckMigration) plan(t *gocheckTest, counts map[string]int) {
+	decl := t.decl
+	if m.blocker != "" {
+		t.reason = m.blocker
+		return
+	}
+	if fixtures := m.fixtures(decl); len(fixtures) > 0 {
+		t.reason = fmt.Sprintf("%s has %s, which gocheck runs around its tests", t.suite, strings.Join(fixtures, " and "))
+		return
+	}
+	name, ok := m.testName(t, counts)
+	if !ok {
+		t.reason = fmt.Sprintf("neither %s nor %s is free as a top-level name", decl.Name.Name, name)
+		return
+	}
+	t.name = name
+
+	taken := identNamesIn(decl)
+	for _, qualifier := range []string{m.qtName, m.testingName} {
+		if taken[qualifier] && !m.qualifiesOnly(decl, qualifier) {
+			t.reason = fmt.Sprintf("%s names something else inside the test", qualifier)
+			return
+		}
+	}
+	t.tName = freeName("t", taken)
+
+	var edits []analysis.TextEdit
+	cover := func(ids ...*ast.Ident) {
+		for _, id := range ids {
+			m.covered[id] = true
+		}
+	}
+	var recv types.Object
+	if names := decl.Recv.List[0].Names; len(names) == 1 {
+		recv = m.pass.TypesInfo.Defs[names[0]]
+	}
+	cover(gocheckIdents(m.pass, decl.Type.Params)...)
+
+	inspectWithParent(decl.Body, func(n, parent ast.Node) {
+		if t.reason != "" {
+			return
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			more, stable, reason := m.assertion(t, n)
+			t.reason = reason
+			t.stable = t.stable && stable
+			edits = append(edits, more...)
+		case *ast.SelectorExpr:
+			recvIdent, ok := n.X.(*ast.Ident)
+			if !ok || t.c == nil || m.pass.TypesInfo.Uses[recvIdent] != t.c {
+				return
+			}
+			method, ok := gocheckCMethods[n.Sel.Name]
+			if !ok {
+				t.reason = fmt.Sprintf("%s.%s has no counterpart on *qt.C", recvIdent.Name, n.Sel.Name)
+				return
+			}
+			cover(recvIdent, n.Sel)
+			if method != n.Sel.Name {
+				edits = append(edits, analysis.TextEdit{Pos: n.Sel.Pos(), End: n.Sel.End(), NewText: []byte(method)})
+			}
+		}
+	})
+	if t.reason != "" {
+		return
+	}
+	if t.reason = m.stray(decl.Body, t.c, recv); t.reason != "" {
+		return
+	}
+
+	params := fmt.Sprintf("%s(%s *%s.T)", t.name, t.tName, m.testingName)
+	edits = append(edits, analysis.TextEdit{Pos: decl.Recv.Pos(), End: decl.Type.Params.End(), NewText: []byte(params)})
+	if t.c != nil && usesObject(m.pass, decl.Body, t.c) {
+		edits = append(edits, prependStmtEdit(m.pass, decl.Body, fmt.Sprintf("%s := %s.New(%s)", t.c.Name(), m.qtName, t.tName)))
+	}
+	t.edits = edits
+}
+
+// usesObject reports whether obj is used anywhere within n.
+func usesObject(pass *analysis.Pass, n ast.Node, obj types.Object) bool {
+	used := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[id] == obj {
+			used = true
+		}
+		return !used
+	})
+	return used
+}
+
+// fixtures returns the names of the fixtures the suite of decl has.
+func (m *gocheckMigration) fixtures(decl *ast.FuncDecl) []string {
+	suite, _ := receiverNamed(m.pass, decl)
+	var found []string
+	for _, name := range gocheckFixtures {
+		obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(suite), true, suite.Obj().Pkg(), name)
+		if _, ok := obj.(*types.Func); ok {
+			found = append(found, name)
+		}
+	}
+	return found
+}
+
+// testName returns the name the test function takes: the method's own when
+// no other suite in the package has a test of that name and nothing else in
+// the package is called it, and otherwise the method's name with the suite's
+// spliced in after Test. ok is false when that is taken too, and name is then
+// the second candidate.
+func (m *gocheckMigration) testName(t *gocheckTest, counts map[string]int) (string, bool) {
+	scope := m.pass.Pkg.Scope()
+	name := t.decl.Name.Name
+	if counts[name] == 1 && scope.Lookup(name) == nil {
+		return name, true
+	}
+	name = "Test" + t.suite + strings.TrimPrefix(name, "Test")
+	return name, counts[name] == 0 && scope.Lookup(name) == nil
+}
+
+// qualifiesOnly reports whether every use of name within decl refers to an
+// imported package, so that writing name as a qualifier there means what the
+// rewrite intends.
+func (m *gocheckMigration) qualifiesOnly(decl *ast.FuncDecl, name string) bool {
+	only := true
+	ast.Inspect(decl, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Name == name {
+			if _, isPkg := m.pass.TypesInfo.Uses[id].(*types.PkgName); !isPkg {
+				only = false
+			}
+		}
+		return only
+	})
+	return only
+}
+
+// gocheckIdents returns the identifiers within n that refer to gocheck.
+func gocheckIdents(pass *analysis.Pass, n ast.Node) []*ast.Ident {
+	var ids []*ast.Ident
+	ast.Inspect(n, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && isGocheckObj(pass.TypesInfo.Uses[id]) {
+			ids = append(ids, id)
+		}
+		return true
+	})
+	return ids
+}
+
+// assertion plans call when it is c.Assert or c.Check on the test's *check.C:
+// the checker is renamed into quicktest, and so is any check.Commentf among
+// the arguments after it.
+func (m *gocheckMigration) assertion(t *gocheckTest, call *ast.CallExpr) ([]analysis.TextEdit, bool, string) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || (sel.Sel.Name != "Assert" && sel.Sel.Name != "Check") {
+		return nil, true, ""
+	}
+	recv, ok := sel.X.(*ast.Ident)
+	if !ok || t.c == nil || m.pass.TypesInfo.Uses[recv] != t.c {
+		return nil, true, ""
+	}
+	if len(call.Args) < 2 || call.Ellipsis.IsValid() {
+		return nil, true, "an assertion's arguments are spread from a slice"
+	}
+
+	edits, base, reason := m.checker(call.Args[1])
+	if reason != "" {
+		return nil, false, reason
+	}
+	stable := true
+	got := m.pass.TypesInfo.TypeOf(call.Args[0])
+	switch base {
+	case "DeepEquals":
+		for _, arg := range call.Args[:min(3, len(call.Args))] {
+			if arg != call.Args[1] && !cmpComparesLikeDeepEqual(m.pass.TypesInfo.TypeOf(arg), make(map[types.Type]bool)) {
+				stable = false
+			}
+		}
+	case "IsNil", "NotNil":
+		stable = isNilAlike(got)
+	case "Matches":
+		stable = basicHas(got, types.IsString)
+	}
+
+	for _, arg := range call.Args[2:] {
+		commentf, ok := arg.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		if edit, ok := m.rename(commentf.Fun, "Commentf", "Commentf"); ok {
+			edits = append(edits, edit)
+		}
+	}
+	return edits, stable, ""
+}
+
+// checker plans the renaming of a gocheck checker expression, returning the
+// name of the checker qt.Not wrappers negate.
+func (m *gocheckMigration) checker(expr ast.Expr) ([]analysis.TextEdit, string, string) {
+	expr = stripParens(expr)
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		edit, ok := m.rename(call.Fun, "Not", "Not")
+		if !ok {
+			return nil, "", "the checker is not one of gocheck's"
+		}
+		edits, base, reason := m.checker(call.Args[0])
+		return append(edits, edit), base, reason
+	}
+	name := gocheckName(m.pass, expr)
+	qtName, ok := gocheckCheckers[name]
+	if !ok {
+		if name != "" {
+			return nil, "", fmt.Sprintf("check.%s has no quicktest counterpart", name)
+		}
+		return nil, "", "the checker is not one of gocheck's"
+	}
+	edit, _ := m.rename(expr, name, qtName)
+	return []analysis.TextEdit{edit}, name, ""
+}
+
+// gocheckName returns the name of the gocheck declaration expr refers to,
+// spelled check.X or, under a dot import, X. It is "" for anything else.
+func gocheckName(pass *analysis.Pass, expr ast.Expr) string {
+	var id *ast.Ident
+	switch e := expr.(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return ""
+	}
+	obj := pass.TypesInfo.Uses[id]
+	if _, isPkg := obj.(*types.PkgName); isPkg || !isGocheckObj(obj) {
+		return ""
+	}
+	return obj.Name()
+}
+
+// rename plans the edit that turns expr, a reference to gocheck's name, into
+// a reference to quicktest's qtName.
+func (m *gocheckMigration) rename(expr ast.Expr, name, qtName string) (analysis.TextEdit, bool) {
+	if gocheckName(m.pass, expr) != name {
+		return analysis.TextEdit{}, false
+	}
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		m.covered[e.Sel] = true
+		if pkg, ok := e.X.(*ast.Ident); ok {
+			m.covered[pkg] = true
+		}
+	case *ast.Ident:
+		m.covered[e] = true
+	}
+	return analysis.TextEdit{Pos: expr.Pos(), End: expr.End(), NewText: []byte(m.qtName + "." + qtName)}, true
+}
+
+// stray returns why the test cannot move when something in body refers to
+// gocheck, to the test's *check.C or to its suite in a way the plan does not
+// rewrite.
+func (m *gocheckMigration) stray(body *ast.BlockStmt, c, recv types.Object) string {
+	var reason string
+	inspectWithParent(body, func(n, parent ast.Node) {
+		id, ok := n.(*ast.Ident)
+		if !ok || reason != "" || m.covered[id] {
+			return
+		}
+		obj := m.pass.TypesInfo.Uses[id]
+		switch {
+		case obj == nil:
+		case recv != nil && obj == recv:
+			reason = fmt.Sprintf("the test uses its suite through %s", id.Name)
+		case c != nil && obj == c:
+			reason = fmt.Sprintf("%s is used as a value, where a *qt.C may not fit", id.Name)
+		case isGocheckObj(obj):
+			name := obj.Name()
+			if sel, ok := parent.(*ast.SelectorExpr); ok && sel.X == id {
+				name = sel.Sel.Name
+			}
+			reason = fmt.Sprintf("check.%s is used in a form the migration does not rewrite", name)
+		}
+	})
+	return reason
+}
+
+// report reports every test, each carrying its own fix, or every movable
+// test's when together they remove the file's last use of gocheck.
+func (m *gocheckMigration) report(onlyStableFixes bool) {
+	fixable := func(t *gocheckTest) bool {
+		return t.reason == "" && (t.stable || !onlyStableFixes)
+	}
+	group := m.groupEdits(fixable)
+
+	for _, t := range m.tests {
+		decl := t.decl
+		name := t.name
+		if name == "" {
+			name = decl.Name.Name
+		}
+		tName, testingName := cmp.Or(t.tName, "t"), cmp.Or(m.testingName, "testing")
+		diag := analysis.Diagnostic{
+			Pos: decl.Pos(),
+			End: decl.Type.End(),
+			Message: fmt.Sprintf("qtlint: use func %s(%s *%s.T) with quicktest instead of gocheck's %s.%s",
+				name, tName, testingName, t.suite, decl.Name.Name),
+		}
+		edits := t.edits
+		if group != nil {
+			edits = group
+		}
+		switch {
+		case t.reason != "":
+			diag.Message += "; no fix: " + t.reason
+		case fixable(t):
+			if group == nil {
+				edits = slices.Clone(edits)
+				for _, imports := range []*analysis.TextEdit{m.stdImports, m.imports} {
+					if imports != nil {
+						edits = append(edits, *imports)
+					}
+				}
+			}
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   fmt.Sprintf("Rewrite as func %s(%s *%s.T)", name, tName, testingName),
+				TextEdits: edits,
+			}}
+		}
+		m.pass.Report(diag)
+	}
+}
+
+// groupEdits returns the edits that move every test in the file and remove
+// gocheck's import, or nil when a use of gocheck would remain.
+func (m *gocheckMigration) groupEdits(fixable func(*gocheckTest) bool) []analysis.TextEdit {
+	var edits []analysis.TextEdit
+	for _, t := range m.tests {
+		if !fixable(t) {
+			return nil
+		}
+		edits = append(edits, t.edits...)
+	}
+	remaining := false
+	ast.Inspect(m.file, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && !m.covered[id] && isGocheckObj(m.pass.TypesInfo.Uses[id]) {
+			remaining = true
+		}
+		return !remaining
+	})
+	if remaining {
+		return nil
+	}
+
+	// gocheck's import line gives way to the imports the file now needs, or
+	// its whole declaration goes when that held nothing else and nothing
+	// takes its place. A parenthesized declaration keeps its parentheses
+	// around the new imports. testing, when it goes on the line gocheck's
+	// held, comes first in the text that replaces it.
+	var node ast.Node = m.spec
+	var text []byte
+	if m.imports != nil {
+		text = m.imports.NewText
+	}
+	std := m.stdImports
+	if specStart, _, ok := wholeLineSpan(m.pass, m.spec); ok && std != nil && std.Pos == specStart {
+		if text == nil {
+			text = bytes.TrimSuffix(std.NewText, []byte("\n"))
+		} else {
+			text = append(slices.Clone(std.NewText), text...)
+		}
+		std = nil
+	}
+	if decl := importDeclOf(m.file, m.spec); decl != nil && len(decl.Specs) == 1 && (!decl.Lparen.IsValid() || text == nil) {
+		node = decl
+	}
+	start, end, ok := wholeLineSpan(m.pass, node)
+	if !ok {
+		return nil
+	}
+	edits = append(edits, analysis.TextEdit{Pos: start, End: end, NewText: text})
+	if std != nil {
+		edits = append(edits, *std)
+	}
+	slices.SortFunc(edits, func(x, y analysis.TextEdit) int {
+		return cmp.Compare(x.Pos, y.Pos)
+	})
+	return edits
+}
@@ -0,0 +1,800 @@
+package qtlint
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// v1CheckerWants maps each frankban/quicktest checker to the number of
+// arguments it takes after got: the want, or none. Anything after those is a
+// comment.
+var v1CheckerWants = map[string]int{
+	"IsNil":         0,
+	"IsNotNil":      0,
+	"IsTrue":        0,
+	"IsFalse":       0,
+	"Equals":        1,
+	"DeepEquals":    1,
+	"CmpEquals":     1,
+	"ContentEquals": 1,
+	"CodecEquals":   1,
+	"JSONEquals":    1,
+	"Matches":       1,
+	"ErrorMatches":  1,
+	"PanicMatches":  1,
+	"HasLen":        1,
+	"Contains":      1,
+	"ErrorIs":       1,
+	"ErrorAs":       1,
+	"Implements":    1,
+	"Satisfies":     1,
+	"All":           1,
+	"Any":           1,
+}
+
+// v1Checker is a frankban/quicktest checker as written in an assertion:
+// qt.Equals, qt.CmpEquals(opts...), or qt.Not wrapped around either.
+type v1Checker struct {
+	// name is the checker's name and extra the arguments a checker
+	// constructor such as qt.CmpEquals was called with.
+	name  string
+	extra []ast.Expr
+	// inner is the checker qt.Not negates. name is "Not" when it is set.
+	inner *v1Checker
+	// sels are the qualified selectors the checker is spelled with.
+	sels []*ast.SelectorExpr
+}
+
+// parseV1Checker parses expr into a v1Checker.
+func parseV1Checker(pass *analysis.Pass, expr ast.Expr) (*v1Checker, bool) {
+	switch expr := expr.(type) {
+	case *ast.SelectorExpr:
+		if !isPackageQualified(pass, expr) {
+			return nil, false
+		}
+		return &v1Checker{name: expr.Sel.Name, sels: []*ast.SelectorExpr{expr}}, true
+	case *ast.CallExpr:
+		sel, ok := expr.Fun.(*ast.SelectorExpr)
+		if !ok || !isPackageQualified(pass, sel) || expr.Ellipsis != token.NoPos {
+			return nil, false
+		}
+		if sel.Sel.Name != "Not" {
+			return &v1Checker{name: sel.Sel.Name, extra: expr.Args, sels: []*ast.SelectorExpr{sel}}, true
+		}
+		if len(expr.Args) != 1 {
+			return nil, false
+		}
+		inner, ok := parseV1Checker(pass, expr.Args[0])
+		if !ok {
+			return nil, false
+		}
+		return &v1Checker{name: "Not", inner: inner, sels: append([]*ast.SelectorExpr{sel}, inner.sels...)}, true
+	}
+	return nil, false
+}
+
+// base returns the checker qt.Not wrappers, if any, negate.
+func (c *v1Checker) base() *v1Checker {
+	for c.inner != nil {
+		c = c.inner
+	}
+	return c
+}
+
+// v1Site is one frankban/quicktest assertion and what it becomes.
+type v1Site struct {
+	call *ast.CallExpr
+	// message describes the rewrite, and reason says why the site has none.
+	message string
+	reason  string
+	// text replaces the call, and stable is false when the replacement is
+	// only close to the original.
+	text   string
+	stable bool
+}
+
+// v2Migration is the plan for moving one file from frankban/quicktest to
+// go-quicktest/qt.
+//
+// The file moves as a unit, because the import line is shared: rewriting it
+// reinterprets every reference to the package at once, so a fix that rewrites
+// one assertion and leaves a sibling in the old spelling does not compile. A
+// site the plan cannot rewrite therefore withholds the fix from every site in
+// the file, and every fix carries the whole file's edits, which the drivers
+// coalesce when they apply more than one.
+type v2Migration struct {
+	pass *analysis.Pass
+	// qtAlias is the name the file imports frankban/quicktest under.
+	qtAlias string
+	sites   []*v1Site
+	// edits are the edits no single site owns: the import line, the qt.New
+	// declarations and the receivers of *qt.C methods testing.TB also has.
+	edits []analysis.TextEdit
+	// blocker is the first reason the file cannot move, when it is not a
+	// site's own.
+	blocker string
+	// covered are the package selectors and *qt.C uses the plan accounts
+	// for. Anything else in the file that names the package keeps the file
+	// where it is.
+	covered map[ast.Node]bool
+}
+
+// checkMigrateToQtV2 reports every frankban/quicktest assertion and suggests
+// its go-quicktest/qt form.
+//
+// The rewrite is mechanical where the two APIs agree: the checker becomes a
+// call owning got and its want, a *qt.C made by qt.New(t) gives way to t, and
+// the import line changes path under the name the file already uses, so
+// qt.Commentf and the checker names read the same before and after. Where they
+// do not agree — a checker with no counterpart, a *qt.C handed to a helper or
+// given to c.Run, a generic checker whose type parameter the operands do not
+// agree on — the site is reported without a fix and so is the rest of its
+// file.
+//
+// qt.CodecEquals is rewritten and marked best-effort: the go-quicktest/qt
+// form takes got as a type parameter, so it is checked against the type of
+// got rather than as an any, and -only-stable-fixes withholds it.
+func (a *analyzer) checkMigrateToQtV2(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		spec := importSpecFor(file, quicktestPkgPath)
+		if spec == nil {
+			continue
+		}
+		m := &v2Migration{
+			pass:    pass,
+			qtAlias: importedPkgName(pass, file, quicktestPkgPath),
+			covered: make(map[ast.Node]bool),
+		}
+		if m.qtAlias == "" {
+			m.block(spec.Pos(), "the package is imported without a name the rewrite can qualify with")
+		}
+		if importSpecFor(file, quicktestV2PkgPath) != nil {
+			m.block(spec.Pos(), "the file already imports go-quicktest/qt")
+		}
+		for _, root := range outermostFuncs(file) {
+			m.collect(root, collectQtCOrigins(pass, root))
+		}
+		m.checkStrays(file)
+		m.importEdit(spec)
+		m.checkOverlaps()
+		m.report(a.onlyStableFixes)
+	}
+}
+
+// importSpecFor returns file's import of path, or nil.
+func importSpecFor(file *ast.File, path string) *ast.ImportSpec {
+	for _, imp := range file.Imports {
+		if imported, err := strconv.Unquote(imp.Path.Value); err == nil && imported == path {
+			return imp
+		}
+	}
+	return nil
+}
+
+// block records why the file cannot move, keeping the first reason found.
+func (m *v2Migration) block(pos token.Pos, reason string) {
+	if m.blocker == "" {
+		m.blocker = fmt.Sprintf("%s (line %d)", reason, m.pass.Fset.Position(pos).Line)
+	}
+}
+
+// collect plans every assertion, *qt.C method and qt.New declaration within
+// root.
+func (m *v2Migration) collect(root ast.Node, origins map[types.Object]qtCOrigin) {
+	objs := slices.SortedFunc(maps.Keys(origins), func(x, y types.Object) int {
+		return cmp.Compare(x.Pos(), y.Pos())
+	})
+	for _, obj := range objs {
+		m.removeDecl(obj, origins[obj])
+	}
+	inspectWithParent(root, func(n, parent ast.Node) {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if isQuicktestAssertion(m.pass, n) {
+				m.addSite(n, parent, origins)
+			}
+		case *ast.SelectorExpr:
+			m.retargetReceiver(n, origins)
+		}
+	})
+}
+
+// removeDecl plans the removal of c := qt.New(t), whose every use the plan
+// rewrites to use t instead.
+func (m *v2Migration) removeDecl(obj types.Object, origin qtCOrigin) {
+	ast.Inspect(origin.decl, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok && sel.Sel.Name == "New" && isPackageQualified(m.pass, sel) {
+			m.covered[sel] = true
+		}
+		return true
+	})
+	start, end, ok := wholeLineSpan(m.pass, origin.decl)
+	if !ok {
+		m.block(origin.decl.Pos(), fmt.Sprintf("the declaration of %s shares its line with other code", obj.Name()))
+		return
+	}
+	m.edits = append(m.edits, analysis.TextEdit{Pos: start, End: end})
+}
+
+// handleAt returns the name of the test handle the *qt.C used at ident was
+// made from, provided that name still refers to the handle at ident.
+func (m *v2Migration) handleAt(ident *ast.Ident, origins map[types.Object]qtCOrigin) (string, types.Object, string) {
+	obj := m.pass.TypesInfo.Uses[ident]
+	origin, ok := origins[obj]
+	if !ok {
+		return "", nil, fmt.Sprintf("%s is not made by qt.New in this function, so there is no test handle to pass instead", ident.Name)
+	}
+	arg, ok := origin.arg.(*ast.Ident)
+	if !ok {
+		return "", nil, fmt.Sprintf("%s is made from an expression rather than a named test handle", ident.Name)
+	}
+	argObj := m.pass.TypesInfo.Uses[arg]
+	scope := m.pass.Pkg.Scope().Innermost(ident.Pos())
+	if argObj == nil || scope == nil {
+		return "", nil, fmt.Sprintf("%s is made from a test handle the rewrite cannot resolve", ident.Name)
+	}
+	if _, found := scope.LookupParent(arg.Name, ident.Pos()); found != argObj {
+		return "", nil, fmt.Sprintf("%s is hidden here by another declaration of the same name", arg.Name)
+	}
+	return arg.Name, argObj, ""
+}
+
+// retargetReceiver plans c.TempDir() as t.TempDir(), for a *qt.C method the
+// handle c was made from has as well.
+func (m *v2Migration) retargetReceiver(sel *ast.SelectorExpr, origins map[types.Object]qtCOrigin) {
+	recv, ok := sel.X.(*ast.Ident)
+	if !ok || !isQuicktestCType(m.pass.TypesInfo.TypeOf(recv)) {
+		return
+	}
+	if sel.Sel.Name == "Assert" || sel.Sel.Name == "Check" {
+		return
+	}
+	handle, handleObj, reason := m.handleAt(recv, origins)
+	if reason != "" {
+		m.block(sel.Pos(), reason)
+		return
+	}
+	method, _, _ := types.LookupFieldOrMethod(handleObj.Type(), true, m.pass.Pkg, sel.Sel.Name)
+	if _, ok := method.(*types.Func); !ok {
+		m.block(sel.Pos(), fmt.Sprintf("%s.%s has no counterpart on %s", recv.Name, sel.Sel.Name, handle))
+		return
+	}
+	m.covered[recv] = true
+	m.edits = append(m.edits, analysis.TextEdit{Pos: recv.Pos(), End: recv.End(), NewText: []byte(handle)})
+}
+
+// addSite plans one assertion.
+func (m *v2Migration) addSite(call *ast.CallExpr, parent ast.Node, origins map[types.Object]qtCOrigin) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	site := &v1Site{call: call, stable: true}
+	m.sites = append(m.sites, site)
+
+	method := sel.Sel.Name
+	args := call.Args
+	oldRecv, handle := "qt", "t"
+	if isPackageQualified(m.pass, sel) {
+		m.covered[sel] = true
+		if len(args) > 0 {
+			if text, ok := formatExpr(m.pass, args[0]); ok {
+				handle = text
+			}
+			args = args[1:]
+		}
+	} else if recv, ok := sel.X.(*ast.Ident); ok {
+		m.covered[recv] = true
+		oldRecv = recv.Name
+		if name, _, reason := m.handleAt(recv, origins); reason != "" {
+			site.reason = reason
+		} else {
+			handle = name
+		}
+	} else {
+		site.reason = "the *qt.C is not a named variable"
+	}
+
+	oldText := fmt.Sprintf("%s.%s(got, ...)", oldRecv, method)
+	if oldRecv == "qt" {
+		oldText = fmt.Sprintf("qt.%s(%s, got, ...)", method, handle)
+	}
+	site.message = fmt.Sprintf("qtlint: use go-quicktest/qt instead of %s", oldText)
+
+	if len(args) < 2 {
+		site.setReason("the assertion has no checker")
+		return
+	}
+	checker, ok := parseV1Checker(m.pass, args[1])
+	if !ok {
+		site.setReason("the checker is not written as a qt checker")
+		return
+	}
+	for _, s := range checker.sels {
+		m.covered[s] = true
+	}
+	wants, ok := v1CheckerWants[checker.base().name]
+	if !ok {
+		site.setReason(fmt.Sprintf("qt.%s has no go-quicktest/qt counterpart", checker.base().name))
+		return
+	}
+	placeholders := []string{"got"}
+	oldArgs := []string{"got", checker.schematic()}
+	if wants == 1 {
+		placeholders = append(placeholders, "want")
+		oldArgs = append(oldArgs, "want")
+	}
+	oldText = fmt.Sprintf("%s.%s(%s)", oldRecv, method, strings.Join(oldArgs, ", "))
+	if oldRecv == "qt" {
+		oldText = fmt.Sprintf("qt.%s(%s, %s)", method, handle, strings.Join(oldArgs, ", "))
+	}
+	site.message = fmt.Sprintf("qtlint: use go-quicktest/qt instead of %s", oldText)
+
+	switch {
+	case call.Ellipsis != token.NoPos:
+		site.setReason("spread arguments cannot be divided between the checker and its comments")
+		return
+	case len(args) < 2+wants:
+		site.setReason(fmt.Sprintf("qt.%s is missing its want argument", checker.base().name))
+		return
+	}
+	if _, isStmt := parent.(*ast.ExprStmt); method == "Assert" && !isStmt {
+		site.setReason("the result of Assert is used, and go-quicktest/qt's Assert returns nothing")
+		return
+	}
+	if site.reason != "" {
+		return
+	}
+	got, want := args[0], args[2:2+wants]
+	for _, comment := range args[2+wants:] {
+		if !isV1CommentType(m.pass.TypesInfo.TypeOf(comment)) {
+			site.setReason("an argument after the checker's own is not a qt.Comment")
+			return
+		}
+	}
+
+	newChecker, stable, reason := m.counterpart(checker, got, want)
+	if reason != "" {
+		site.setReason(reason)
+		return
+	}
+	gotText, ok := formatExpr(m.pass, got)
+	if !ok {
+		return
+	}
+	tail, ok := formatArgs(m.pass, args[2:])
+	if !ok {
+		return
+	}
+	texts, ok := newChecker.render(m.pass, m.qtAlias, append([]string{gotText}, tail[:wants]...))
+	if !ok {
+		return
+	}
+
+	site.text = m.qtAlias + "." + method + "(" + strings.Join(append([]string{handle, texts}, tail[wants:]...), ", ") + ")"
+	site.stable = stable
+	schematic, _ := newChecker.render(nil, "qt", placeholders)
+	site.message = fmt.Sprintf("qtlint: use qt.%s(%s, %s) instead of %s", method, handle, schematic, oldText)
+}
+
+// setReason records why the site has no rewrite.
+func (s *v1Site) setReason(reason string) {
+	if s.reason == "" {
+		s.reason = reason
+	}
+}
+
+// schematic renders the checker as the message shows it.
+func (c *v1Checker) schematic() string {
+	switch {
+	case c.inner != nil:
+		return "qt.Not(" + c.inner.schematic() + ")"
+	case len(c.extra) > 0:
+		return "qt." + c.name + "(...)"
+	}
+	return "qt." + c.name
+}
+
+// v2Form is a go-quicktest/qt checker call in the making.
+type v2Form struct {
+	name  string
+	extra []ast.Expr
+	// negated wraps the call in qt.Not.
+	negated *v2Form
+}
+
+// render writes the checker applied to operands, followed by the arguments
+// its frankban/quicktest constructor took. A nil pass renders the extra
+// arguments as an ellipsis, for a message.
+func (f *v2Form) render(pass *analysis.Pass, qtAlias string, operands []string) (string, bool) {
+	if f.negated != nil {
+		inner, ok := f.negated.render(pass, qtAlias, operands)
+		return qtAlias + ".Not(" + inner + ")", ok
+	}
+	args := slices.Clone(operands)
+	switch {
+	case len(f.extra) == 0:
+	case pass == nil:
+		args = append(args, "...")
+	default:
+		extra, ok := formatArgs(pass, f.extra)
+		if !ok {
+			return "", false
+		}
+		args = append(args, extra...)
+	}
+	return v2Call(qtAlias, f.name, args...), true
+}
+
+// counterpart finds the go-quicktest/qt checker the frankban/quicktest one
+// becomes, and reports why there is none when the operands would not
+// type-check against it.
+//
+// Every frankban/quicktest checker takes its operands as interface{}, and most
+// of their go-quicktest/qt counterparts do not: the check here stands in for
+// the compiler, which only sees the new call once the fix is applied.
+func (m *v2Migration) counterpart(c *v1Checker, got ast.Expr, want []ast.Expr) (*v2Form, bool, string) {
+	if c.inner != nil {
+		inner, stable, reason := m.counterpart(c.inner, got, want)
+		if reason != "" {
+			return nil, false, reason
+		}
+		return &v2Form{negated: inner}, stable, ""
+	}
+
+	info := m.pass.TypesInfo
+	gotType := info.TypeOf(got)
+	form := &v2Form{name: c.name, extra: c.extra}
+	switch c.name {
+	case "IsNil", "IsNotNil":
+		return form, true, ""
+	case "IsTrue", "IsFalse":
+		if !basicHas(gotType, types.IsBoolean) {
+			return nil, false, fmt.Sprintf("qt.%s takes a bool in go-quicktest/qt, and got is %s", c.name, typeString(gotType))
+		}
+		return form, true, ""
+	case "Equals", "DeepEquals", "CmpEquals":
+		if !m.inferable(got, want[0]) {
+			return nil, false, fmt.Sprintf("qt.%s takes got and want as one type parameter, and they are %s and %s",
+				c.name, typeString(gotType), typeString(info.TypeOf(want[0])))
+		}
+		// An untyped constant is boxed as its default type today, and takes
+		// the other operand's type once inferred: the rewrite would make an
+		// assertion that can never pass start passing.
+		constType, otherType := gotType, info.TypeOf(want[0])
+		if !m.isUntypedConst(got) {
+			constType, otherType = otherType, constType
+		}
+		if (m.isUntypedConst(got) || m.isUntypedConst(want[0])) && !types.Identical(constType, otherType) {
+			return nil, false, fmt.Sprintf("frankban/quicktest compares the untyped constant as %s, which is never equal to %s, and go-quicktest/qt would convert it to %s",
+				typeString(constType), typeString(otherType), typeString(otherType))
+		}
+		return form, true, ""
+	case "CodecEquals":
+		return form, false, ""
+	case "ErrorMatches":
+		if !m.assignable(got, errorType()) || !m.isPattern(want[0]) {
+			return nil, false, "qt.ErrorMatches takes an error and a string or *regexp.Regexp in go-quicktest/qt"
+		}
+		return form, true, ""
+	case "Matches":
+		if !m.assignable(got, types.Typ[types.String]) || !m.isPattern(want[0]) {
+			return nil, false, "qt.Matches takes a string and a string or *regexp.Regexp in go-quicktest/qt"
+		}
+		return form, true, ""
+	case "PanicMatches":
+		if !m.assignable(got, types.NewSignatureType(nil, nil, nil, nil, nil, false)) || !m.isPattern(want[0]) {
+			return nil, false, "qt.PanicMatches takes a func() and a string or *regexp.Regexp in go-quicktest/qt"
+		}
+		return form, true, ""
+	case "HasLen":
+		if !m.assignable(want[0], types.Typ[types.Int]) {
+			return nil, false, fmt.Sprintf("qt.HasLen takes an int length in go-quicktest/qt, and want is %s",
+				typeString(info.TypeOf(want[0])))
+		}
+		return form, true, ""
+	case "ErrorIs":
+		if !m.assignable(got, errorType()) || !m.assignable(want[0], errorType()) {
+			return nil, false, "qt.ErrorIs takes two errors in go-quicktest/qt"
+		}
+		return form, true, ""
+	case "ErrorAs":
+		if _, isPtr := types.Unalias(info.TypeOf(want[0])).(*types.Pointer); !m.assignable(got, errorType()) || !isPtr {
+			return nil, false, "qt.ErrorAs takes an error and a pointer target in go-quicktest/qt"
+		}
+		return form, true, ""
+	case "JSONEquals":
+		if !types.Identical(gotType, types.Typ[types.String]) &&
+			!types.Identical(gotType, types.NewSlice(types.Typ[types.Byte])) {
+			return nil, false, fmt.Sprintf("qt.JSONEquals takes a string or []byte in go-quicktest/qt, and got is %s",
+				typeString(gotType))
+		}
+		return form, true, ""
+	case "Contains":
+		return m.containsCounterpart(got, want[0])
+	case "Satisfies":
+		sig, ok := types.Unalias(info.TypeOf(want[0])).(*types.Signature)
+		if !ok || sig.Params().Len() != 1 || !types.Identical(gotType, sig.Params().At(0).Type()) {
+			return nil, false, "qt.Satisfies takes got as the predicate's own parameter type in go-quicktest/qt"
+		}
+		return form, true, ""
+	}
+	return nil, false, fmt.Sprintf("qt.%s has no direct go-quicktest/qt counterpart", c.name)
+}
+
+// containsCounterpart picks qt.StringContains, qt.SliceContains or
+// qt.MapContains from the type of the container, which frankban/quicktest's
+// qt.Contains decides at run time.
+func (m *v2Migration) containsCounterpart(got, want ast.Expr) (*v2Form, bool, string) {
+	gotType := m.pass.TypesInfo.TypeOf(got)
+	if gotType == nil {
+		return nil, false, "the container's type is unknown"
+	}
+	switch under := gotType.Underlying().(type) {
+	case *types.Basic:
+		if under.Info()&types.IsString != 0 && m.inferable(got, want) {
+			return &v2Form{name: "StringContains"}, true, ""
+		}
+	case *types.Slice:
+		if m.assignable(want, under.Elem()) {
+			return &v2Form{name: "SliceContains"}, true, ""
+		}
+	case *types.Map:
+		if m.assignable(want, under.Elem()) {
+			return &v2Form{name: "MapContains"}, true, ""
+		}
+	}
+	return nil, false, fmt.Sprintf("qt.Contains on %s has no go-quicktest/qt counterpart that %s infers for",
+		typeString(gotType), typeString(m.pass.TypesInfo.TypeOf(want)))
+}
+
+// inferable reports whether x and y agree on one type parameter: they have
+// identical types, or one is an untyped constant or nil the other can hold.
+func (m *v2Migration) inferable(x, y ast.Expr) bool {
+	xt, yt := m.pass.TypesInfo.TypeOf(x), m.pass.TypesInfo.TypeOf(y)
+	if xt == nil || yt == nil {
+		return false
+	}
+	switch {
+	case m.isUntypedConst(y):
+		return constantFits(yt, xt)
+	case m.isUntypedConst(x):
+		return constantFits(xt, yt)
+	case isNilIdent(y):
+		return isNillable(xt)
+	case isNilIdent(x):
+		return isNillable(yt)
+	}
+	return types.Identical(xt, yt)
+}
+
+// assignable reports whether expr can be passed where target is expected. An
+// untyped constant is asked about its kind rather than about the default type
+// it was given as an interface{} argument.
+func (m *v2Migration) assignable(expr ast.Expr, target types.Type) bool {
+	typ := m.pass.TypesInfo.TypeOf(expr)
+	switch {
+	case typ == nil:
+		return false
+	case isNilIdent(expr):
+		return isNillable(target)
+	case m.isUntypedConst(expr):
+		return constantFits(typ, target)
+	}
+	return types.AssignableTo(typ, target)
+}
+
+// isPattern reports whether expr is a string or a *regexp.Regexp, the two
+// pattern types go-quicktest/qt's regexp checkers accept.
+func (m *v2Migration) isPattern(expr ast.Expr) bool {
+	if m.assignable(expr, types.Typ[types.String]) {
+		return true
+	}
+	ptr, ok := types.Unalias(m.pass.TypesInfo.TypeOf(expr)).(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := types.Unalias(ptr.Elem()).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "regexp" && named.Obj().Name() == "Regexp"
+}
+
+// isUntypedConst reports whether expr is a constant that was written without a
+// type, such as 3 or a constant declared as const n = 3.
+//
+// The type checker has already given such an operand the default type it
+// takes as an interface{} argument, so its recorded type cannot say so.
+func (m *v2Migration) isUntypedConst(expr ast.Expr) bool {
+	switch expr := stripParens(expr).(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.Ident:
+		c, ok := m.pass.TypesInfo.Uses[expr].(*types.Const)
+		if !ok {
+			return false
+		}
+		basic, ok := c.Type().(*types.Basic)
+		return ok && basic.Info()&types.IsUntyped != 0
+	case *ast.UnaryExpr:
+		return m.isUntypedConst(expr.X)
+	case *ast.BinaryExpr:
+		return m.isUntypedConst(expr.X) && m.isUntypedConst(expr.Y)
+	}
+	return false
+}
+
+// constantFits reports whether an untyped constant given the default type
+// constType can be used as a value of target: both are numbers, both strings,
+// or both booleans.
+func constantFits(constType, target types.Type) bool {
+	if types.IsInterface(target) {
+		return true
+	}
+	for _, kind := range []types.BasicInfo{types.IsNumeric, types.IsString, types.IsBoolean} {
+		if basicHas(constType, kind) && basicHas(target, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// basicHas reports whether typ's underlying type is a basic type with any of
+// the properties in info.
+func basicHas(typ types.Type, info types.BasicInfo) bool {
+	if typ == nil {
+		return false
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&info != 0
+}
+
+// isNillable reports whether nil is a value of typ.
+func isNillable(typ types.Type) bool {
+	switch under := typ.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return true
+	case *types.Basic:
+		return under.Kind() == types.UnsafePointer
+	}
+	return false
+}
+
+// errorType returns the predeclared error type.
+func errorType() types.Type {
+	return types.Universe.Lookup("error").Type()
+}
+
+// typeString renders typ for a message, or "untyped" when it is unknown.
+func typeString(typ types.Type) string {
+	if typ == nil {
+		return "of unknown type"
+	}
+	return typ.String()
+}
+
+// isV1CommentType reports whether typ is frankban/quicktest's Comment.
+func isV1CommentType(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == quicktestPkgPath && obj.Name() == "Comment"
+}
+
+// checkStrays blocks the file on any reference to the package, or to a *qt.C,
+// that no planned edit accounts for. qt.Commentf and qt.Comment read the same
+// in both packages, and are left as they are.
+func (m *v2Migration) checkStrays(file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if m.covered[n] || !isPackageQualified(m.pass, n) {
+				return true
+			}
+			switch n.Sel.Name {
+			case "Commentf", "Comment":
+				return true
+			}
+			m.block(n.Pos(), fmt.Sprintf("qt.%s is used in a form the migration does not rewrite", n.Sel.Name))
+		case *ast.Ident:
+			if m.covered[n] || m.pass.TypesInfo.Uses[n] == nil {
+				return true
+			}
+			if _, isVar := m.pass.TypesInfo.Uses[n].(*types.Var); isVar && isQuicktestCType(m.pass.TypesInfo.TypeOf(n)) {
+				m.block(n.Pos(), fmt.Sprintf("%s is used as a value with no go-quicktest/qt counterpart", n.Name))
+			}
+		}
+		return true
+	})
+}
+
+// importEdit plans the import line, which keeps the name the file already
+// uses so that nothing qualified by it needs to change.
+func (m *v2Migration) importEdit(spec *ast.ImportSpec) {
+	if m.qtAlias == "" {
+		return
+	}
+	text := strconv.Quote(quicktestV2PkgPath)
+	if m.qtAlias != "qt" {
+		text = m.qtAlias + " " + text
+	}
+	m.edits = append(m.edits, analysis.TextEdit{Pos: spec.Pos(), End: spec.Path.End(), NewText: []byte(text)})
+}
+
+// checkOverlaps blocks the file when two planned edits overlap: an assertion
+// nested in another's arguments, or a retargeted receiver inside an
+// assertion, is rendered from source the other edit replaces.
+func (m *v2Migration) checkOverlaps() {
+	all := m.allEdits()
+	for i := 1; i < len(all); i++ {
+		if all[i].Pos < all[i-1].End {
+			m.block(all[i].Pos, "two rewrites overlap")
+			return
+		}
+	}
+}
+
+// allEdits returns the file's edits in position order.
+func (m *v2Migration) allEdits() []analysis.TextEdit {
+	all := slices.Clone(m.edits)
+	for _, s := range m.sites {
+		if s.text != "" {
+			all = append(all, analysis.TextEdit{Pos: s.call.Pos(), End: s.call.End(), NewText: []byte(s.text)})
+		}
+	}
+	slices.SortFunc(all, func(x, y analysis.TextEdit) int {
+		return cmp.Compare(x.Pos, y.Pos)
+	})
+	return all
+}
+
+// report reports every site, each carrying the whole file's fix when the file
+// can move.
+func (m *v2Migration) report(onlyStableFixes bool) {
+	var blocker string
+	stable := true
+	for _, s := range m.sites {
+		if s.reason != "" && blocker == "" {
+			blocker = fmt.Sprintf("the assertion at line %d cannot move", m.pass.Fset.Position(s.call.Pos()).Line)
+		}
+		stable = stable && s.stable
+	}
+	if blocker == "" {
+		blocker = m.blocker
+	}
+
+	var fixes []analysis.SuggestedFix
+	if blocker == "" && (stable || !onlyStableFixes) {
+		fixes = []analysis.SuggestedFix{{
+			Message:   "Migrate the file to go-quicktest/qt",
+			TextEdits: m.allEdits(),
+		}}
+	}
+
+	for _, s := range m.sites {
+		message := s.message
+		switch {
+		case s.reason != "":
+			message += "; no fix: " + s.reason
+		case blocker != "":
+			message += "; no fix: the file moves as a unit, and " + blocker
+		}
+		diag := analysis.Diagnostic{Pos: s.call.Pos(), End: s.call.End(), Message: message}
+		if s.reason == "" {
+			diag.SuggestedFixes = fixes
+		}
+		m.pass.Report(diag)
+	}
+}
@@ -0,0 +1,599 @@
+package qtlint
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// The testify packages whose package-level assertions -migrate-from-testify
+// rewrites. assert reports and carries on, as c.Check does; require stops the
+// test, as c.Assert does.
+const (
+	testifyAssertPkgPath  = "github.com/stretchr/testify/assert"
+	testifyRequirePkgPath = "github.com/stretchr/testify/require"
+)
+
+// testifyForm is the quicktest spelling of one testify assertion.
+type testifyForm struct {
+	// checker is the quicktest checker, wrapped in qt.Not when not is set.
+	checker string
+	not     bool
+	// params name testify's operands, in testify's order, for the message.
+	// The first is got unless swap is set, in which case testify takes them
+	// as (want, got), the way Equal does.
+	params []string
+	swap   bool
+	// want is the checker's want when testify has no operand for it, as
+	// with the 0 that qt.HasLen takes for Empty.
+	want string
+	// check, when set, says whether the rewrite keeps the assertion's meaning
+	// for these operands: stable is false when it is only close, and reason
+	// is set when it is not close enough to offer at all.
+	check func(pass *analysis.Pass, args []ast.Expr) (stable bool, reason string)
+}
+
+// testifyForms maps every testify assertion the rule rewrites to its quicktest
+// form. The f variants (Equalf and the rest) take a format and its arguments
+// where these take msgAndArgs, and are looked up by their base name.
+var testifyForms = map[string]testifyForm{
+	"NoError":     {checker: "IsNil", params: []string{"err"}},
+	"Error":       {checker: "IsNotNil", params: []string{"err"}},
+	"Nil":         {checker: "IsNil", params: []string{"x"}, check: testifyNilCheck},
+	"NotNil":      {checker: "IsNotNil", params: []string{"x"}, check: testifyNilCheck},
+	"True":        {checker: "IsTrue", params: []string{"b"}},
+	"False":       {checker: "IsFalse", params: []string{"b"}},
+	"Equal":       {checker: "DeepEquals", params: []string{"want", "got"}, swap: true, check: testifyEqualCheck},
+	"NotEqual":    {checker: "DeepEquals", not: true, params: []string{"want", "got"}, swap: true, check: testifyEqualCheck},
+	"Len":         {checker: "HasLen", params: []string{"x", "n"}},
+	"Empty":       {checker: "HasLen", params: []string{"x"}, want: "0", check: testifyEmptyCheck},
+	"NotEmpty":    {checker: "HasLen", not: true, params: []string{"x"}, want: "0", check: testifyEmptyCheck},
+	"Contains":    {checker: "Contains", params: []string{"container", "elem"}, check: testifyContainsCheck},
+	"NotContains": {checker: "Contains", not: true, params: []string{"container", "elem"}, check: testifyContainsCheck},
+	"ErrorIs":     {checker: "ErrorIs", params: []string{"err", "target"}},
+	"NotErrorIs":  {checker: "ErrorIs", not: true, params: []string{"err", "target"}},
+	"ErrorAs":     {checker: "ErrorAs", params: []string{"err", "target"}},
+	"EqualError":  {checker: "ErrorMatches", params: []string{"err", "msg"}, check: testifyEqualErrorCheck},
+}
+
+// testifySite is one testify assertion and what it becomes.
+type testifySite struct {
+	call *ast.CallExpr
+	// pkg is the testify package ident the call is qualified by.
+	pkg *ast.Ident
+	// message describes the rewrite, and reason says why the site has none.
+	message string
+	reason  string
+	// summary names the replacement in the fix's message.
+	summary string
+	// edits replace the call and, when the site needs a *qt.C that is not
+	// there yet, create it. stable is false when the replacement is only
+	// close to the original.
+	edits  []analysis.TextEdit
+	stable bool
+}
+
+// testifyMigration is the plan for one file's testify assertions.
+type testifyMigration struct {
+	pass *analysis.Pass
+	file *ast.File
+	// qtAlias is the name the file imports frankban/quicktest under.
+	qtAlias string
+	sites   []*testifySite
+}
+
+// checkMigrateFromTestify reports testify's package-level assertions in files
+// that import frankban/quicktest, and suggests the *qt.C assertion each one
+// becomes.
+//
+// The file's own quicktest import is the anchor: a file that does not already
+// import frankban/quicktest is not reported, however much testify it uses.
+// The assertion goes through a *qt.C made from the same test handle when one
+// is visible, and otherwise through one created at the top of the function
+// that binds the handle, as -require-qt-c-receiver does.
+//
+// The import of a testify package goes with its last use. When every use of
+// it in the file has a fix, each of those fixes carries the rest, so applying
+// any one of them leaves a file that compiles; otherwise each fix replaces its
+// own call, and the import stays for the uses that remain.
+func (a *analyzer) checkMigrateFromTestify(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		qtAlias := importedPkgName(pass, file, quicktestPkgPath)
+		if qtAlias == "" {
+			continue
+		}
+		if importSpecFor(file, testifyAssertPkgPath) == nil && importSpecFor(file, testifyRequirePkgPath) == nil {
+			continue
+		}
+		m := &testifyMigration{pass: pass, file: file, qtAlias: qtAlias}
+		m.collect()
+		for _, path := range []string{testifyAssertPkgPath, testifyRequirePkgPath} {
+			m.report(path, a.onlyStableFixes)
+		}
+	}
+}
+
+// collect plans every testify assertion in the file.
+func (m *testifyMigration) collect() {
+	var stack []ast.Node
+	ast.Inspect(m.file, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		if call, ok := n.(*ast.CallExpr); ok {
+			if pkg, fn, ok := m.matchAssertion(call); ok {
+				m.sites = append(m.sites, m.plan(stack, call, pkg, fn))
+			}
+		}
+		stack = append(stack, n)
+		return true
+	})
+}
+
+// matchAssertion reports whether call is a package-level testify assertion,
+// returning the package ident it is qualified by and the function called.
+//
+// An assertion is a function whose first parameter is the package's TestingT
+// and which returns a bool, in assert, or nothing, in require. That is what
+// tells Equal from the package's helpers, such as New and ObjectsAreEqual,
+// which the rule leaves alone.
+func (m *testifyMigration) matchAssertion(call *ast.CallExpr) (*ast.Ident, *types.Func, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil, false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil, nil, false
+	}
+	pkgName, ok := m.pass.TypesInfo.Uses[pkg].(*types.PkgName)
+	if !ok || !isTestifyPkg(pkgName.Imported().Path()) {
+		return nil, nil, false
+	}
+	fn, ok := m.pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok {
+		return nil, nil, false
+	}
+	sig := fn.Type().(*types.Signature)
+	params, results := sig.Params(), sig.Results()
+	if params.Len() == 0 || len(call.Args) == 0 {
+		return nil, nil, false
+	}
+	if results.Len() > 1 || (results.Len() == 1 && !basicHas(results.At(0).Type(), types.IsBoolean)) {
+		return nil, nil, false
+	}
+	named, ok := types.Unalias(params.At(0).Type()).(*types.Named)
+	if !ok || named.Obj().Name() != "TestingT" || named.Obj().Pkg() != fn.Pkg() {
+		return nil, nil, false
+	}
+	return pkg, fn, true
+}
+
+// isTestifyPkg reports whether path is one of the testify packages the rule
+// rewrites.
+func isTestifyPkg(path string) bool {
+	return path == testifyAssertPkgPath || path == testifyRequirePkgPath
+}
+
+// plan works out what call becomes.
+func (m *testifyMigration) plan(stack []ast.Node, call *ast.CallExpr, pkg *ast.Ident, fn *types.Func) *testifySite {
+	site := &testifySite{call: call, pkg: pkg, stable: true}
+
+	method := "Check"
+	if m.pass.TypesInfo.Uses[pkg].(*types.PkgName).Imported().Path() == testifyRequirePkgPath {
+		method = "Assert"
+	}
+	handleText := "t"
+	if ident, ok := call.Args[0].(*ast.Ident); ok {
+		handleText = ident.Name
+	}
+
+	name := fn.Name()
+	form, ok := testifyForms[name]
+	formatted := false
+	if !ok {
+		if base, cut := strings.CutSuffix(name, "f"); cut {
+			form, ok = testifyForms[base]
+			formatted = ok
+		}
+	}
+	if !ok {
+		site.message = fmt.Sprintf("qtlint: use quicktest instead of %s.%s(%s, ...)", pkg.Name, name, handleText)
+		site.reason = fmt.Sprintf("%s.%s has no quicktest counterpart", pkg.Name, name)
+		return site
+	}
+
+	oldArgs := append([]string{handleText}, form.params...)
+	if formatted {
+		oldArgs = append(oldArgs, "format", "...")
+	}
+	cName, prepend, reason := m.receiver(stack, call)
+	if cName == "" {
+		cName = "c"
+	}
+	site.summary = form.render(cName, method, m.qtAlias, form.params)
+	site.message = fmt.Sprintf("qtlint: use %s instead of %s.%s(%s)", site.summary, pkg.Name, name, strings.Join(oldArgs, ", "))
+	if reason != "" {
+		site.reason = reason
+		return site
+	}
+
+	operands := len(form.params)
+	if len(call.Args) < 1+operands {
+		site.reason = fmt.Sprintf("%s.%s is missing its %s argument", pkg.Name, name, form.params[len(form.params)-1])
+		return site
+	}
+	args := call.Args[1 : 1+operands]
+	if call.Ellipsis.IsValid() && len(call.Args) == 1+operands {
+		site.reason = "the operands are spread from a slice"
+		return site
+	}
+	if form.check != nil {
+		site.stable, site.reason = form.check(m.pass, args)
+		if site.reason != "" {
+			return site
+		}
+	}
+
+	operandTexts, ok := formatArgs(m.pass, args)
+	if !ok {
+		site.reason = "an operand could not be rendered"
+		return site
+	}
+	comment, reason := m.comment(call, call.Args[1+operands:], formatted)
+	if reason != "" {
+		site.reason = reason
+		return site
+	}
+
+	text := form.render(cName, method, m.qtAlias, operandTexts)
+	if comment != "" {
+		text = strings.TrimSuffix(text, ")") + ", " + comment + ")"
+	}
+	if prepend != nil {
+		site.edits = append(site.edits, *prepend)
+	}
+	site.edits = append(site.edits, analysis.TextEdit{Pos: call.Pos(), End: call.End(), NewText: []byte(text)})
+	return site
+}
+
+// render spells the assertion through cName with operands, in testify's
+// order, as its arguments.
+func (f testifyForm) render(cName, method, qtAlias string, operands []string) string {
+	got, want := operands[0], ""
+	if len(operands) > 1 {
+		want = operands[1]
+	}
+	if f.swap {
+		got, want = want, got
+	}
+	if f.want != "" {
+		want = f.want
+	}
+	checker := qtAlias + "." + f.checker
+	if f.not {
+		checker = qtAlias + ".Not(" + checker + ")"
+	}
+	args := []string{got, checker}
+	if want != "" {
+		args = append(args, want)
+	}
+	return cName + "." + method + "(" + strings.Join(args, ", ") + ")"
+}
+
+// receiver returns the name of the *qt.C the assertion at call goes through
+// and, when that *qt.C does not exist yet, the edit that creates it. reason
+// says why there is none.
+//
+// A handle that is already a *qt.C is used as it is: a *qt.C satisfies
+// testify's TestingT, and asserting through it directly is what the rewrite
+// is for. Any other handle must be a named testing.TB that a function on the
+// stack binds, for the same reason -require-qt-c-receiver insists on one:
+// qt.New(t) has to be written somewhere t is visible.
+func (m *testifyMigration) receiver(stack []ast.Node, call *ast.CallExpr) (string, *analysis.TextEdit, string) {
+	if !packageQualifies(m.pass, m.qtAlias, quicktestPkgPath, call.Pos()) {
+		return "", nil, fmt.Sprintf("%s does not name quicktest here", m.qtAlias)
+	}
+	ident, ok := stripParens(call.Args[0]).(*ast.Ident)
+	if !ok {
+		return "", nil, "the test handle is not a named variable"
+	}
+	obj := m.pass.TypesInfo.Uses[ident]
+	if obj == nil {
+		return "", nil, "the test handle is not a named variable"
+	}
+	if isQuicktestCType(obj.Type()) {
+		return ident.Name, nil, ""
+	}
+	if !isTestingHandle(obj.Type()) {
+		return "", nil, fmt.Sprintf("%s is not a testing.TB that qt.New can be given", ident.Name)
+	}
+
+	binder, body := enclosingBinder(m.pass, stack, obj)
+	if body == nil {
+		return "", nil, fmt.Sprintf("%s is not a parameter of an enclosing function, so there is nowhere to make a *qt.C from it", ident.Name)
+	}
+	if cName, reused := visibleQtCFrom(m.pass, body, obj, call.Pos()); reused {
+		return cName, nil, ""
+	}
+	cName := freeName("c", identNamesIn(binder))
+	edit := prependStmtEdit(m.pass, body, fmt.Sprintf("%s := %s.New(%s)", cName, m.qtAlias, ident.Name))
+	return cName, &edit, ""
+}
+
+// comment renders the message arguments that follow the operands as a
+// qt.Commentf, or "" when there are none.
+//
+// An f variant passes its format and arguments straight through, which
+// Commentf formats the same way. The other variants take msgAndArgs, which
+// testify formats only when there is more than one: a lone message is used as
+// it is, so it is passed through a "%+v" unless it is a literal with nothing
+// for Commentf to interpret.
+func (m *testifyMigration) comment(call *ast.CallExpr, args []ast.Expr, formatted bool) (string, string) {
+	if len(args) == 0 {
+		return "", ""
+	}
+	if call.Ellipsis.IsValid() {
+		return "", "spread message arguments cannot be divided into a qt.Commentf format and its arguments"
+	}
+	texts, ok := formatArgs(m.pass, args)
+	if !ok {
+		return "", "a message argument could not be rendered"
+	}
+	commentf := m.qtAlias + ".Commentf("
+	switch {
+	case formatted:
+	case len(args) == 1:
+		if lit, ok := args[0].(*ast.BasicLit); !ok || lit.Kind != token.STRING || strings.Contains(lit.Value, "%") {
+			texts = append([]string{`"%+v"`}, texts...)
+		}
+	case !basicHas(m.pass.TypesInfo.TypeOf(args[0]), types.IsString):
+		return "", "the message format is not a string"
+	}
+	return commentf + strings.Join(texts, ", ") + ")", ""
+}
+
+// testifyNilCheck marks Nil and NotNil best-effort where qt.IsNil may decide
+// differently from testify. See isNilAlike.
+func testifyNilCheck(pass *analysis.Pass, args []ast.Expr) (bool, string) {
+	return isNilAlike(pass.TypesInfo.TypeOf(args[0])), ""
+}
+
+// isNilAlike reports whether qt.IsNil decides a value of typ the way testify
+// and gocheck do, which look only at whether the value is a nil pointer, map
+// or the like. qt.IsNil also fails for an error holding a nil pointer, so an
+// error, or an interface that may hold one, is decided differently.
+func isNilAlike(typ types.Type) bool {
+	if typ == nil || types.IsInterface(typ) {
+		return false
+	}
+	errIface := errorType().Underlying().(*types.Interface)
+	return !types.Implements(typ, errIface) && !types.Implements(types.NewPointer(typ), errIface)
+}
+
+// testifyEqualCheck marks Equal and NotEqual best-effort unless both operands
+// are compared the same way by testify and by qt.DeepEquals.
+//
+// testify compares with reflect.DeepEqual, and qt.DeepEquals with go-cmp,
+// which fails on an unexported struct field and defers to a type's own Equal
+// method. They agree on everything else. testify also compares two []byte
+// with bytes.Equal, for which a nil slice and an empty one are equal.
+func testifyEqualCheck(pass *analysis.Pass, args []ast.Expr) (bool, string) {
+	for _, arg := range args {
+		typ := pass.TypesInfo.TypeOf(arg)
+		if typ == nil || isByteSlice(typ) || !cmpComparesLikeDeepEqual(typ, make(map[types.Type]bool)) {
+			return false, ""
+		}
+	}
+	return true, ""
+}
+
+// isByteSlice reports whether typ is a slice of bytes.
+func isByteSlice(typ types.Type) bool {
+	slice, ok := typ.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+	elem, ok := slice.Elem().Underlying().(*types.Basic)
+	return ok && elem.Kind() == types.Byte
+}
+
+// cmpComparesLikeDeepEqual reports whether go-cmp, given no options, compares
+// two values of typ the way reflect.DeepEqual does. It walks everything a
+// value of typ can reach, so an interface, whose dynamic type is unknown, is
+// never accepted.
+func cmpComparesLikeDeepEqual(typ types.Type, seen map[types.Type]bool) bool {
+	if seen[typ] {
+		return true
+	}
+	seen[typ] = true
+	if named, ok := types.Unalias(typ).(*types.Named); ok {
+		if obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), true, nil, "Equal"); obj != nil {
+			return false
+		}
+	}
+	switch under := typ.Underlying().(type) {
+	case *types.Basic:
+		return true
+	case *types.Pointer:
+		return cmpComparesLikeDeepEqual(under.Elem(), seen)
+	case *types.Slice:
+		return cmpComparesLikeDeepEqual(under.Elem(), seen)
+	case *types.Array:
+		return cmpComparesLikeDeepEqual(under.Elem(), seen)
+	case *types.Map:
+		return cmpComparesLikeDeepEqual(under.Key(), seen) && cmpComparesLikeDeepEqual(under.Elem(), seen)
+	case *types.Struct:
+		for field := range under.Fields() {
+			if !field.Exported() || !cmpComparesLikeDeepEqual(field.Type(), seen) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// testifyEmptyCheck refuses Empty and NotEmpty on anything but a string,
+// slice, map or channel. For those testify checks the length, as qt.HasLen
+// does; for anything else it checks for the zero value.
+func testifyEmptyCheck(pass *analysis.Pass, args []ast.Expr) (bool, string) {
+	typ := pass.TypesInfo.TypeOf(args[0])
+	if typ != nil {
+		switch typ.Underlying().(type) {
+		case *types.Slice, *types.Map, *types.Chan:
+			return true, ""
+		}
+		if basicHas(typ, types.IsString) {
+			return true, ""
+		}
+	}
+	return false, fmt.Sprintf("testify checks a %s for its zero value, not its length", typeString(typ))
+}
+
+// testifyContainsCheck refuses Contains and NotContains on a map, where
+// testify looks for a key and qt.Contains for a value. A slice or array is
+// searched with == by qt.Contains and with reflect.DeepEqual by testify, so
+// one whose elements are not basic values is best-effort.
+func testifyContainsCheck(pass *analysis.Pass, args []ast.Expr) (bool, string) {
+	typ := pass.TypesInfo.TypeOf(args[0])
+	if typ == nil {
+		return false, ""
+	}
+	switch under := typ.Underlying().(type) {
+	case *types.Map:
+		return false, "testify looks for a map key, and qt.Contains for a value"
+	case *types.Slice:
+		_, basic := under.Elem().Underlying().(*types.Basic)
+		return basic, ""
+	case *types.Array:
+		_, basic := under.Elem().Underlying().(*types.Basic)
+		return basic, ""
+	}
+	return basicHas(typ, types.IsString), ""
+}
+
+// testifyEqualErrorCheck accepts EqualError only for a literal message that
+// reads the same as a regular expression: qt.ErrorMatches matches the whole
+// message against a pattern, where testify compares it as a string.
+func testifyEqualErrorCheck(pass *analysis.Pass, args []ast.Expr) (bool, string) {
+	if lit, ok := args[1].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		if s, err := strconv.Unquote(lit.Value); err == nil && regexp.QuoteMeta(s) == s {
+			return true, ""
+		}
+	}
+	return false, "the message is not a literal free of the metacharacters qt.ErrorMatches would interpret"
+}
+
+// report reports every assertion qualified by the import of path.
+func (m *testifyMigration) report(path string, onlyStableFixes bool) {
+	var sites []*testifySite
+	for _, s := range m.sites {
+		if m.pass.TypesInfo.Uses[s.pkg].(*types.PkgName).Imported().Path() == path {
+			sites = append(sites, s)
+		}
+	}
+	if len(sites) == 0 {
+		return
+	}
+
+	fixable := func(s *testifySite) bool {
+		return s.reason == "" && (s.stable || !onlyStableFixes)
+	}
+	group := m.groupEdits(path, sites, fixable)
+
+	for _, s := range sites {
+		diag := analysis.Diagnostic{Pos: s.call.Pos(), End: s.call.End(), Message: s.message}
+		switch {
+		case s.reason != "":
+			diag.Message += "; no fix: " + s.reason
+		case !fixable(s):
+		case group != nil:
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   fmt.Sprintf("Replace every %s assertion in the file with quicktest", s.pkg.Name),
+				TextEdits: group,
+			}}
+		default:
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "Replace with " + s.summary,
+				TextEdits: s.edits,
+			}}
+		}
+		m.pass.Report(diag)
+	}
+}
+
+// groupEdits returns the edits that replace every one of sites and remove the
+// import of path, or nil when some use of the import would remain.
+//
+// Two sites in one function that both need a *qt.C plan the same qt.New, so
+// the edits are de-duplicated rather than merely concatenated.
+func (m *testifyMigration) groupEdits(path string, sites []*testifySite, fixable func(*testifySite) bool) []analysis.TextEdit {
+	covered := make(map[*ast.Ident]bool)
+	var edits []analysis.TextEdit
+	for _, s := range sites {
+		if !fixable(s) {
+			return nil
+		}
+		covered[s.pkg] = true
+		for _, e := range s.edits {
+			if !slices.ContainsFunc(edits, func(x analysis.TextEdit) bool {
+				return x.Pos == e.Pos && x.End == e.End && string(x.NewText) == string(e.NewText)
+			}) {
+				edits = append(edits, e)
+			}
+		}
+	}
+	for ident, obj := range m.pass.TypesInfo.Uses {
+		pkgName, ok := obj.(*types.PkgName)
+		if ok && !covered[ident] && pkgName.Imported().Path() == path &&
+			ident.Pos() >= m.file.Pos() && ident.Pos() < m.file.End() {
+			return nil
+		}
+	}
+
+	removal, ok := m.importRemoval(path)
+	if !ok {
+		return nil
+	}
+	edits = append(edits, removal)
+	slices.SortFunc(edits, func(x, y analysis.TextEdit) int {
+		return cmp.Compare(x.Pos, y.Pos)
+	})
+	for i := 1; i < len(edits); i++ {
+		if edits[i].Pos < edits[i-1].End {
+			return nil
+		}
+	}
+	return edits
+}
+
+// importRemoval returns the edit deleting the file's import of path: the spec's
+// own line in a grouped import, and the whole declaration otherwise.
+func (m *testifyMigration) importRemoval(path string) (analysis.TextEdit, bool) {
+	spec := importSpecFor(m.file, path)
+	if spec == nil {
+		return analysis.TextEdit{}, false
+	}
+	var node ast.Node = spec
+	for _, decl := range m.file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if ok && gen.Tok == token.IMPORT && slices.Contains(gen.Specs, ast.Spec(spec)) && len(gen.Specs) == 1 {
+			node = gen
+		}
+	}
+	start, end, ok := wholeLineSpan(m.pass, node)
+	if !ok {
+		return analysis.TextEdit{}, false
+	}
+	return analysis.TextEdit{Pos: start, End: end}, true
+}
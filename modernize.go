@@ -0,0 +1,171 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// supersededCMethod is a *qt.C helper that testing.TB has since gained a
+// method for.
+type supersededCMethod struct {
+	// method is the testing.TB method that replaces it, and since the Go
+	// release that added it.
+	method string
+	since  string
+}
+
+// supersededCMethods are the *qt.C helpers with a testing.TB counterpart.
+// Unsetenv, Patch and Parallel have none, and Done goes with Defer.
+var supersededCMethods = map[string]supersededCMethod{
+	"Mkdir":  {method: "TempDir", since: "go1.15"},
+	"Defer":  {method: "Cleanup", since: "go1.14"},
+	"Setenv": {method: "Setenv", since: "go1.17"},
+}
+
+// checkModernizeLifecycle checks for a *qt.C helper that testing.TB has
+// superseded, and suggests the testing.TB method: c.Mkdir() becomes
+// c.TempDir() and c.Defer(f) becomes c.Cleanup(f), both reached through the
+// testing.TB a *qt.C embeds, and c.Setenv becomes the Setenv of the test the
+// *qt.C was made from.
+//
+// A call is only reported when the file's Go version, which the module's go
+// directive sets and a //go:build constraint can raise, is at least the
+// release that added the method. A file whose version is not known is not
+// reported, so that the rule never suggests an API the module cannot call.
+//
+// On a testing.TB with Cleanup, which every test has from go1.14, the version
+// the rule requires for it, c.Defer(f) hands f to Cleanup and also registers a
+// cleanup that panics with "Done not called after Defer" unless c.Done has run
+// by then. Cleanup runs f at the same point and has no such check, so the
+// rewrite is only made when no Done is involved: a c.Defer(f) in a function
+// that names c.Done is reported without a fix, as the Done would be left
+// behind with nothing to do, and so is one on a *qt.C the rule cannot follow,
+// such as a field. c.Run calls Done on the *qt.C it hands its function, so a
+// Defer there needs none written. Setenv is best-effort: testing's panics in a
+// parallel test, where quicktest's does not.
+func (a *analyzer) checkModernizeLifecycle(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		for _, root := range outermostFuncs(file) {
+			origins := collectQtCOrigins(pass, root)
+			ast.Inspect(root, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				superseded, ok := supersededCMethods[sel.Sel.Name]
+				if !ok || !supersededIn(pass, file, sel.Sel.Name) {
+					return true
+				}
+				fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+				if !ok || fn.Pkg() == nil || fn.Pkg().Path() != quicktestPkgPath {
+					return true
+				}
+				a.reportSupersededCall(pass, root, origins, call, sel, superseded)
+				return true
+			})
+		}
+	}
+}
+
+// supersededIn reports whether the *qt.C helper method is superseded in file:
+// whether the file's Go version is known and has the testing.TB method that
+// replaces it.
+func supersededIn(pass *analysis.Pass, file *ast.File, method string) bool {
+	superseded, ok := supersededCMethods[method]
+	fileVersion := pass.TypesInfo.FileVersions[file]
+	return ok && fileVersion != "" && version.Compare(fileVersion, superseded.since) >= 0
+}
+
+// reportSupersededCall reports call, a call to a superseded *qt.C helper
+// within root, with the fix that calls the testing.TB method instead.
+func (a *analyzer) reportSupersededCall(pass *analysis.Pass, root ast.Node, origins map[types.Object]qtCOrigin, call *ast.CallExpr, sel *ast.SelectorExpr, superseded supersededCMethod) {
+	recv, ok := formatExpr(pass, sel.X)
+	if !ok {
+		return
+	}
+	// newRecv is what the testing.TB method is called on, when it is not the
+	// *qt.C itself.
+	newRecv, stable, reason := recv, true, ""
+	var obj types.Object
+	if ident, ok := stripParens(sel.X).(*ast.Ident); ok {
+		obj = pass.TypesInfo.Uses[ident]
+	}
+	switch sel.Sel.Name {
+	case "Defer":
+		switch {
+		case obj == nil:
+			reason = "cannot tell whether Done is called on " + recv
+		case namesMethod(pass, root, obj, "Done"):
+			reason = recv + ".Done would be left with nothing to do"
+		}
+	case "Setenv":
+		// C's own Setenv hides the testing.TB one it embeds.
+		newRecv, stable = recv+".TB", false
+		if origin, ok := origins[obj]; ok {
+			if t, ok := stripParens(origin.arg).(*ast.Ident); ok && sameObjectAt(pass, t, call.Pos()) {
+				newRecv = t.Name
+			}
+		}
+	}
+
+	diag := analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("qtlint: use %s.%s instead of %s.%s, which testing.TB has superseded since %s",
+			newRecv, superseded.method, recv, sel.Sel.Name, superseded.since),
+	}
+	switch {
+	case reason != "":
+		diag.Message += "; no fix: " + reason
+	case stable || !a.onlyStableFixes:
+		edit := analysis.TextEdit{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte(superseded.method)}
+		if newRecv != recv {
+			edit.Pos = sel.X.Pos()
+			edit.NewText = []byte(newRecv + "." + superseded.method)
+		}
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   fmt.Sprintf("Replace with %s.%s", newRecv, superseded.method),
+			TextEdits: []analysis.TextEdit{edit},
+		}}
+	}
+	pass.Report(diag)
+}
+
+// namesMethod reports whether anything within root selects method on the
+// variable obj, calling it or taking it as a method value.
+func namesMethod(pass *analysis.Pass, root ast.Node, obj types.Object, method string) bool {
+	found := false
+	ast.Inspect(root, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != method {
+			return !found
+		}
+		if ident, ok := stripParens(sel.X).(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == obj {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// sameObjectAt reports whether ident's name still refers to the object ident
+// refers to at pos, so that a rewrite can write the name there.
+func sameObjectAt(pass *analysis.Pass, ident *ast.Ident, pos token.Pos) bool {
+	obj := pass.TypesInfo.Uses[ident]
+	scope := pass.Pkg.Scope().Innermost(pos)
+	if obj == nil || scope == nil {
+		return false
+	}
+	_, found := scope.LookupParent(ident.Name, pos)
+	return found == obj
+}
@@ -0,0 +1,421 @@
+package qtlint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// recoverBlock is a deferred function that fails the test when the code after
+// it does not panic, parsed by matchRecoverBlock.
+type recoverBlock struct {
+	// r is the variable the panic value is assigned to, nil when it is only
+	// compared with nil.
+	r types.Object
+	// ifs are the failure blocks the deferred function holds, and fails the
+	// Fatal[f] or Error[f] calls they make.
+	ifs   []*ast.IfStmt
+	fails []*ast.CallExpr
+	// method is the quicktest method the assertion uses: "Assert" when any
+	// of fails stops the test, "Check" otherwise.
+	method string
+	// want is what the panic value is compared with, nil when it is only
+	// checked to be there, and form how want becomes a pattern.
+	want ast.Expr
+	form patternForm
+	// stable is false when the panic value is compared as it is rather than
+	// as text, which qt.PanicMatches matches.
+	stable bool
+}
+
+// matchRecoverBlock parses the function deferStmt defers into a recoverBlock.
+// The shapes are
+//
+//	defer func() {
+//		r := recover()
+//		if r == nil {
+//			t.Fatal("did not panic")
+//		}
+//		if r != "boom" {
+//			t.Fatalf("unexpected panic %v", r)
+//		}
+//	}()
+//
+// with the comparison of the panic value left out, written as an assertion
+// such as c.Assert(r, qt.Equals, "boom"), or written as an else if of an
+// if r := recover(); r == nil statement; and if recover() == nil alone.
+func matchRecoverBlock(pass *analysis.Pass, deferStmt *ast.DeferStmt) (recoverBlock, bool) {
+	lit, ok := deferStmt.Call.Fun.(*ast.FuncLit)
+	if !ok || len(deferStmt.Call.Args) != 0 || lit.Type.Params.NumFields() != 0 || lit.Type.Results.NumFields() != 0 {
+		return recoverBlock{}, false
+	}
+	var b recoverBlock
+	var nilIf *ast.IfStmt
+	var check ast.Stmt
+	switch stmts := lit.Body.List; {
+	case len(stmts) == 2 || len(stmts) == 3:
+		if b.r = recoverAssignee(pass, stmts[0]); b.r == nil {
+			return recoverBlock{}, false
+		}
+		if nilIf, ok = stmts[1].(*ast.IfStmt); !ok || nilIf.Init != nil || nilIf.Else != nil {
+			return recoverBlock{}, false
+		}
+		if len(stmts) == 3 {
+			check = stmts[2]
+		}
+	case len(stmts) == 1:
+		if nilIf, ok = stmts[0].(*ast.IfStmt); !ok {
+			return recoverBlock{}, false
+		}
+		if nilIf.Init != nil {
+			if b.r = recoverAssignee(pass, nilIf.Init); b.r == nil {
+				return recoverBlock{}, false
+			}
+		}
+		if nilIf.Else != nil {
+			elseIf, ok := nilIf.Else.(*ast.IfStmt)
+			if !ok || b.r == nil || elseIf.Init != nil || elseIf.Else != nil {
+				return recoverBlock{}, false
+			}
+			check = elseIf
+		}
+	default:
+		return recoverBlock{}, false
+	}
+	if !isRecoverNilCheck(pass, nilIf.Cond, b.r) || !b.addFailure(pass, nilIf) {
+		return recoverBlock{}, false
+	}
+
+	b.stable = true
+	switch check := check.(type) {
+	case nil:
+	case *ast.IfStmt:
+		if check.Init != nil || check.Else != nil || !b.addFailure(pass, check) {
+			return recoverBlock{}, false
+		}
+		if !b.parseCondition(pass, check.Cond) {
+			return recoverBlock{}, false
+		}
+	default:
+		if !b.parseAssertion(pass, check) {
+			return recoverBlock{}, false
+		}
+	}
+	return b, true
+}
+
+// recoverAssignee returns the variable stmt, r := recover(), assigns.
+func recoverAssignee(pass *analysis.Pass, stmt ast.Stmt) types.Object {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil
+	}
+	ident, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok || !isRecoverCall(pass, assign.Rhs[0]) {
+		return nil
+	}
+	return pass.TypesInfo.Defs[ident]
+}
+
+// isRecoverCall reports whether expr calls the recover builtin.
+func isRecoverCall(pass *analysis.Pass, expr ast.Expr) bool {
+	call, ok := stripParens(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+	ident, ok := stripParens(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	_, ok = pass.TypesInfo.Uses[ident].(*types.Builtin)
+	return ok && ident.Name == "recover"
+}
+
+// isRecoverNilCheck reports whether cond is r == nil, or recover() == nil
+// when there is no r.
+func isRecoverNilCheck(pass *analysis.Pass, cond ast.Expr, r types.Object) bool {
+	bin, ok := stripParens(cond).(*ast.BinaryExpr)
+	if !ok || bin.Op != token.EQL {
+		return false
+	}
+	value := bin.X
+	if isNilIdent(value) {
+		value = bin.Y
+	} else if !isNilIdent(bin.Y) {
+		return false
+	}
+	if r == nil {
+		return isRecoverCall(pass, value)
+	}
+	ident, ok := stripParens(value).(*ast.Ident)
+	return ok && pass.TypesInfo.Uses[ident] == r
+}
+
+// addFailure records ifStmt as a failure block of b, whose body is one
+// Fatal[f] or Error[f] call from the testing package.
+func (b *recoverBlock) addFailure(pass *analysis.Pass, ifStmt *ast.IfStmt) bool {
+	call, sel, method, ok := matchFailureCall(ifStmt.Body)
+	if !ok {
+		return false
+	}
+	selection, ok := pass.TypesInfo.Selections[sel]
+	if !ok || !isFromTestingPkg(selection) {
+		return false
+	}
+	b.ifs = append(b.ifs, ifStmt)
+	b.fails = append(b.fails, call)
+	if b.method != "Assert" {
+		b.method = method
+	}
+	return true
+}
+
+// panicValue reports whether expr is r, the panic value, or fmt.Sprint(r),
+// the text qt.PanicMatches matches; sprinted tells which.
+func panicValue(pass *analysis.Pass, expr ast.Expr, r types.Object) (sprinted, ok bool) {
+	expr = stripParens(expr)
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 && !call.Ellipsis.IsValid() {
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Sprint" || !isQualifiedBy(pass, sel, "fmt") {
+			return false, false
+		}
+		sprinted, expr = true, stripParens(call.Args[0])
+	}
+	ident, ok := expr.(*ast.Ident)
+	return sprinted, ok && pass.TypesInfo.Uses[ident] == r
+}
+
+// isStringExpr reports whether expr is of a string type.
+func isStringExpr(pass *analysis.Pass, expr ast.Expr) bool {
+	return basicHas(pass.TypesInfo.TypeOf(expr), types.IsString)
+}
+
+// parseCondition parses cond, the condition under which the panic value is
+// the wrong one: r != want, or !strings.Contains(fmt.Sprint(r), want).
+func (b *recoverBlock) parseCondition(pass *analysis.Pass, cond ast.Expr) bool {
+	r := b.r
+	switch cond := stripParens(cond).(type) {
+	case *ast.BinaryExpr:
+		if cond.Op != token.NEQ {
+			return false
+		}
+		value, want := cond.X, cond.Y
+		if _, ok := panicValue(pass, want, r); ok {
+			value, want = want, value
+		}
+		sprinted, ok := panicValue(pass, value, r)
+		if !ok || !isStringExpr(pass, want) {
+			return false
+		}
+		b.want, b.form, b.stable = want, quoteWhole, sprinted
+		return true
+	case *ast.UnaryExpr:
+		call, ok := stripParens(cond.X).(*ast.CallExpr)
+		if cond.Op != token.NOT || !ok || len(call.Args) != 2 {
+			return false
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Contains" || !isQualifiedBy(pass, sel, "strings") {
+			return false
+		}
+		if sprinted, ok := panicValue(pass, call.Args[0], r); !ok || !sprinted {
+			return false
+		}
+		b.want, b.form = call.Args[1], quoteWithin
+		return true
+	}
+	return false
+}
+
+// parseAssertion parses stmt, an assertion on the panic value: qt.Equals with
+// a string, or qt.Matches.
+func (b *recoverBlock) parseAssertion(pass *analysis.Pass, stmt ast.Stmt) bool {
+	assertion, ok := matchAssertionStmt(pass, stmt)
+	if !ok || !assertion.bare || assertion.want == nil {
+		return false
+	}
+	sprinted, ok := panicValue(pass, assertion.got, b.r)
+	if !ok {
+		return false
+	}
+	switch assertion.checker.Sel.Name {
+	case "Equals":
+		if !isStringExpr(pass, assertion.want) {
+			return false
+		}
+		b.form = quoteWhole
+	case "Matches":
+		b.form = asPattern
+	default:
+		return false
+	}
+	b.want, b.stable = assertion.want, sprinted
+	if assertion.stmt.X.(*ast.CallExpr).Fun.(*ast.SelectorExpr).Sel.Name == "Assert" {
+		b.method = "Assert"
+	}
+	return true
+}
+
+// failureMessageOnlyPanic reports whether the failure calls of b say nothing
+// but constant text and the panic value, which qt.PanicMatches reports itself.
+func (b *recoverBlock) failureMessageOnlyPanic(pass *analysis.Pass) bool {
+	for _, call := range b.fails {
+		if call.Ellipsis.IsValid() {
+			return false
+		}
+		for _, arg := range call.Args {
+			if tv, ok := pass.TypesInfo.Types[arg]; ok && tv.Value != nil {
+				continue
+			}
+			if ident, ok := stripParens(arg).(*ast.Ident); ok && b.r != nil && pass.TypesInfo.Uses[ident] == b.r {
+				continue
+			}
+			return false
+		}
+	}
+	return true
+}
+
+// returnsFrom reports whether any of stmts returns from the function holding
+// them, which it no longer would inside a func literal.
+func returnsFrom(stmts []ast.Stmt) bool {
+	found := false
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ReturnStmt:
+				found = true
+			case *ast.BranchStmt:
+				found = found || n.Tok == token.GOTO
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+// lineEnd returns the position of the newline ending the line pos is on, or
+// the end of the file.
+func lineEnd(pass *analysis.Pass, pos token.Pos) token.Pos {
+	file := pass.Fset.File(pos)
+	if line := file.Line(pos); line < file.LineCount() {
+		return file.LineStart(line+1) - 1
+	}
+	return token.Pos(file.Base() + file.Size())
+}
+
+// checkPanicMatchesPattern checks each function body for a deferred function
+// that recovers a panic and fails the test when there was none, and suggests
+// asserting qt.PanicMatches on the code after it instead. The deferred check
+// runs when the function returns, so the code under test is everything that
+// follows the defer statement, and the fix wraps exactly that in a func
+// literal; the defer statement has to be one of the function body's own.
+// A comparison of the panic value with a string becomes an anchored pattern
+// the way quotedPattern quotes one for checkErrorStringPattern.
+//
+// It returns the failure blocks of the deferred functions it reported, which
+// checkFailureBlockPattern leaves to it.
+func (a *analyzer) checkPanicMatchesPattern(pass *analysis.Pass, insp *inspector.Inspector) map[*ast.IfStmt]bool {
+	taken := make(map[*ast.IfStmt]bool)
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}, func(n ast.Node) {
+		var body *ast.BlockStmt
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			body = n.Body
+		case *ast.FuncLit:
+			body = n.Body
+		}
+		if body == nil {
+			return
+		}
+		for i, stmt := range body.List {
+			deferStmt, ok := stmt.(*ast.DeferStmt)
+			if !ok || i == len(body.List)-1 {
+				continue
+			}
+			for _, ifStmt := range a.checkRecoverBlock(pass, deferStmt, body.List[i+1:], body.Rbrace) {
+				taken[ifStmt] = true
+			}
+		}
+	})
+	return taken
+}
+
+// checkRecoverBlock reports deferStmt when it defers a recoverBlock, with rest
+// the statements after it and rbrace the closing brace of the body they are
+// in, and returns the block's failure blocks when it did.
+func (a *analyzer) checkRecoverBlock(pass *analysis.Pass, deferStmt *ast.DeferStmt, rest []ast.Stmt, rbrace token.Pos) []*ast.IfStmt {
+	b, ok := matchRecoverBlock(pass, deferStmt)
+	if !ok {
+		return nil
+	}
+	failSel := b.fails[0].Fun.(*ast.SelectorExpr)
+	handleText, ok := formatExpr(pass, failSel.X)
+	if !ok {
+		return nil
+	}
+	lit := deferStmt.Call.Fun.(*ast.FuncLit)
+	spelling, ok := assertionSpellingAt(pass, lit.Type, pass.TypesInfo.Selections[failSel], failSel.X, handleText)
+	if !ok {
+		return nil
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     deferStmt.Pos(),
+		End:     deferStmt.End(),
+		Message: "qtlint: use qt.PanicMatches instead of checking recover() in a deferred function",
+	}
+	switch {
+	case returnsFrom(rest):
+		diag.Message += "; no fix: the code after the defer leaves the function, which it cannot do from a func literal"
+		pass.Report(diag)
+		return b.ifs
+	case !b.failureMessageOnlyPanic(pass):
+		diag.Message += "; no fix: the failure message says more than that the panic was missing or wrong"
+		pass.Report(diag)
+		return b.ifs
+	case !b.stable && a.onlyStableFixes:
+		pass.Report(diag)
+		return b.ifs
+	}
+
+	pattern, edits, ok := patternLiteral("(?s).*"), []analysis.TextEdit(nil), true
+	if b.want != nil {
+		pattern, edits, ok = quotedPattern(pass, b.form, b.want)
+	}
+	if !ok {
+		pass.Report(diag)
+		return b.ifs
+	}
+	// The assertion is written around the code after the defer statement,
+	// which stays where it is: the fix only adds what goes before and after
+	// it, so that fixes other rules make within it still apply. It closes at
+	// the end of the last statement's line, after any comment there, unless
+	// the body's own brace shares that line.
+	closeAt := lineEnd(pass, rest[len(rest)-1].End())
+	if rbrace < closeAt {
+		closeAt = rest[len(rest)-1].End()
+	}
+	const placeholder = "\x00"
+	text := spelling.call(b.method, spelling.check("PanicMatches", false, placeholder, pattern))
+	before, after, _ := strings.Cut(text, placeholder)
+	diag.SuggestedFixes = []analysis.SuggestedFix{{
+		Message: "Replace with qt.PanicMatches",
+		TextEdits: append(edits,
+			analysis.TextEdit{Pos: deferStmt.Pos(), End: deferStmt.End(), NewText: []byte(before + "func() {")},
+			analysis.TextEdit{
+				Pos:     closeAt,
+				End:     closeAt,
+				NewText: []byte("\n}" + after),
+			},
+		),
+	}}
+	pass.Report(diag)
+	return b.ifs
+}
@@ -0,0 +1,272 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// parallelTest is a test function or a subtest's function, as far as running
+// in parallel goes: whether it calls Parallel, and the test it runs under.
+type parallelTest struct {
+	parent *parallelTest
+	// run is the position of the Run call that starts the subtest within its
+	// parent, or token.NoPos for a test.
+	run token.Pos
+	// parallel is the first Parallel call made on one of the test's handles,
+	// or nil.
+	parallel *ast.CallExpr
+}
+
+// parallelCall returns the Parallel call that makes t run in parallel, made
+// by t itself or by a test it runs under, or nil. A test's own Parallel counts
+// wherever it is, but a parent's counts only when it comes before the Run that
+// starts the subtest: a subtest run before it has finished by then.
+func (t *parallelTest) parallelCall() *ast.CallExpr {
+	for run := token.NoPos; t != nil; run, t = t.run, t.parent {
+		if t.parallel != nil && (run == token.NoPos || t.parallel.Pos() < run) {
+			return t.parallel
+		}
+	}
+	return nil
+}
+
+// checkParallelMutations checks for a test that changes state the whole
+// process shares while it runs in parallel. testing's Setenv and Chdir panic
+// when the test or a test it runs under has called Parallel, and the Setenv,
+// Unsetenv and Patch of a *qt.C, which set the environment or a variable as
+// they are, race with every test running alongside it.
+//
+// A test is a function with a *testing.T, testing.TB or *qt.C parameter, or a
+// function literal given to c.Run or t.Run, whose subtest runs under the test
+// whose handle Run is called on. The *qt.C a test makes with qt.New(t) is a
+// handle on t's test, which collectQtCOrigins connects it to, so t.Parallel()
+// and c.Setenv(k, v) are made on the same test. A handle passed to a helper is
+// not followed.
+//
+// Patch is reported when it sets a package-level variable, or a field of
+// one. A pointer it is given as a variable may point anywhere.
+func checkParallelMutations(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		for _, root := range outermostFuncs(file) {
+			checkParallelTests(pass, root)
+		}
+	}
+}
+
+// checkParallelTests reports the process-wide changes made within root by a
+// test that runs in parallel.
+func checkParallelTests(pass *analysis.Pass, root ast.Node) {
+	handles := make(map[types.Object]*parallelTest)
+	origins := collectQtCOrigins(pass, root)
+	// testOf returns the test obj is a handle on, following a *qt.C to the
+	// handle it was made from.
+	var testOf func(obj types.Object) *parallelTest
+	testOf = func(obj types.Object) *parallelTest {
+		if test, ok := handles[obj]; ok {
+			return test
+		}
+		if origin, ok := origins[obj]; ok {
+			if t := testHandleOf(pass, origin.arg); t != obj {
+				return testOf(t)
+			}
+		}
+		return nil
+	}
+	addParams := func(ftype *ast.FuncType, test *parallelTest) {
+		for _, field := range ftype.Params.List {
+			for _, name := range field.Names {
+				if obj := pass.TypesInfo.Defs[name]; obj != nil && isTestHandleType(obj.Type(), true) {
+					handles[obj] = test
+				}
+			}
+		}
+	}
+
+	var calls []*ast.CallExpr
+	var walk func(n ast.Node, test *parallelTest)
+	walk = func(n ast.Node, test *parallelTest) {
+		ast.Inspect(n, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			calls = append(calls, call)
+			if !isSubtestRun(pass, call) || len(call.Args) != 2 {
+				return true
+			}
+			lit, ok := stripParens(call.Args[1]).(*ast.FuncLit)
+			if !ok {
+				return true
+			}
+			walk(call.Fun, test)
+			walk(call.Args[0], test)
+			// The subtest runs under the test Run is called on, which is not
+			// always the test the call is written in.
+			parent := test
+			if on := testOf(testHandleOf(pass, call.Fun.(*ast.SelectorExpr).X)); on != nil {
+				parent = on
+			}
+			sub := &parallelTest{parent: parent, run: call.Pos()}
+			addParams(lit.Type, sub)
+			walk(lit.Body, sub)
+			return false
+		})
+	}
+	top := &parallelTest{}
+	switch fn := root.(type) {
+	case *ast.FuncDecl:
+		addParams(fn.Type, top)
+		if fn.Body != nil {
+			walk(fn.Body, top)
+		}
+	case *ast.FuncLit:
+		addParams(fn.Type, top)
+		walk(fn.Body, top)
+	}
+
+	for _, call := range calls {
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Name() != "Parallel" || !isTestHandleMethod(fn) {
+			continue
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		if test := testOf(testHandleOf(pass, sel.X)); test != nil && test.parallel == nil {
+			test.parallel = call
+		}
+	}
+
+	for _, call := range calls {
+		handle, effect := parallelMutation(pass, call)
+		if effect == "" {
+			continue
+		}
+		parallel := testOf(handle).parallelCall()
+		if parallel == nil {
+			continue
+		}
+		fun, ok := formatExpr(pass, call.Fun)
+		if !ok {
+			continue
+		}
+		parallelText, ok := formatExpr(pass, parallel.Fun)
+		if !ok {
+			continue
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos: call.Pos(),
+			End: call.End(),
+			Message: fmt.Sprintf("qtlint: %s in a test run in parallel by %s() %s; no fix: the change belongs in a test that does not run in parallel",
+				fun, parallelText, effect),
+			Related: []analysis.RelatedInformation{{
+				Pos:     parallel.Pos(),
+				End:     parallel.End(),
+				Message: "the test runs in parallel from here",
+			}},
+		})
+	}
+}
+
+// parallelMutation reports whether call changes state the whole process
+// shares, on behalf of the test whose handle it returns, and what it does
+// wrong when that test runs in parallel.
+func parallelMutation(pass *analysis.Pass, call *ast.CallExpr) (types.Object, string) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return nil, ""
+	}
+	if fn.Pkg().Path() == quicktestV2PkgPath {
+		// qt.Patch(t, &v, value).
+		if fn.Name() != "Patch" || len(call.Args) != 3 {
+			return nil, ""
+		}
+		return patchMutation(pass, testHandleOf(pass, call.Args[0]), call.Args[1])
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !isTestHandleMethod(fn) {
+		return nil, ""
+	}
+	handle := testHandleOf(pass, sel.X)
+	switch {
+	case fn.Name() == "Chdir", fn.Name() == "Setenv" && fn.Pkg().Path() == testingPkgPath:
+		return handle, "panics"
+	case fn.Name() == "Setenv", fn.Name() == "Unsetenv":
+		return handle, "races with the tests alongside it on the process environment"
+	case fn.Name() == "Patch" && len(call.Args) == 2:
+		return patchMutation(pass, handle, call.Args[0])
+	}
+	return nil, ""
+}
+
+// patchMutation describes a Patch of dest on behalf of handle, when dest is
+// the address of a package-level variable.
+func patchMutation(pass *analysis.Pass, handle types.Object, dest ast.Expr) (types.Object, string) {
+	addr, ok := stripParens(dest).(*ast.UnaryExpr)
+	if !ok || addr.Op != token.AND {
+		return nil, ""
+	}
+	v := packageLevelVar(pass, addr.X)
+	if v == nil {
+		return nil, ""
+	}
+	return handle, "races with the tests alongside it on package-level variable " + v.Name()
+}
+
+// packageLevelVar returns the package-level variable expr is, or is a field
+// of, or nil.
+func packageLevelVar(pass *analysis.Pass, expr ast.Expr) *types.Var {
+	for {
+		var ident *ast.Ident
+		switch x := stripParens(expr).(type) {
+		case *ast.Ident:
+			ident = x
+		case *ast.SelectorExpr:
+			if selection := pass.TypesInfo.Selections[x]; selection != nil {
+				if selection.Kind() != types.FieldVal || selection.Indirect() {
+					return nil
+				}
+				expr = x.X
+				continue
+			}
+			ident = x.Sel
+		default:
+			return nil
+		}
+		v, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+		if !ok || v.Pkg() == nil || v.Parent() != v.Pkg().Scope() {
+			return nil
+		}
+		return v
+	}
+}
+
+// testHandleOf returns the variable expr names when it is a handle on a test:
+// t, or c, or c.TB reached through a *qt.C.
+func testHandleOf(pass *analysis.Pass, expr ast.Expr) types.Object {
+	expr = stripParens(expr)
+	if sel, ok := expr.(*ast.SelectorExpr); ok && sel.Sel.Name == "TB" && isQuicktestCType(pass.TypesInfo.TypeOf(sel.X)) {
+		expr = stripParens(sel.X)
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	return pass.TypesInfo.Uses[ident]
+}
+
+// isTestHandleMethod reports whether fn is a method of a *qt.C or of a type of
+// the testing package.
+func isTestHandleMethod(fn *types.Func) bool {
+	if fn.Signature().Recv() == nil || fn.Pkg() == nil {
+		return false
+	}
+	path := fn.Pkg().Path()
+	return path == quicktestPkgPath || path == testingPkgPath
+}
@@ -0,0 +1,113 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// checkPatchArgs checks the two arguments of (*qt.C).Patch(dest, value),
+// which takes both as interface{} and sets *dest to value through reflect, so
+// the compiler accepts anything and a mistake is a panic when the test runs:
+// reflect cannot take the element of a dest that is not a pointer, and cannot
+// set it to a value of a type not assignable to it.
+//
+// The value is checked as Patch receives it, boxed in an interface{}: an
+// untyped constant has its default type there, so c.Patch(&timeout, 5) sets
+// a time.Duration to an int and panics. An untyped nil is set as the zero
+// value, as Patch does. An argument whose static type is an interface has a
+// dynamic type the rule cannot know, and is left alone.
+//
+// A dest that is a variable rather than a pointer to one, as in
+// c.Patch(myFunc, stub), gets a fix taking its address when value would then
+// fit. A pointer to an unexported field of another package cannot be written
+// at all, so the type checker has reported it before the rule runs.
+func checkPatchArgs(pass *analysis.Pass, call *ast.CallExpr) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Name() != "Patch" || fn.Pkg() == nil || fn.Pkg().Path() != quicktestPkgPath || fn.Signature().Recv() == nil {
+		return
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 2 {
+		return
+	}
+	recv, ok := formatExpr(pass, sel.X)
+	if !ok {
+		return
+	}
+	dest, value := call.Args[0], call.Args[1]
+	destText, ok := formatExpr(pass, dest)
+	if !ok {
+		return
+	}
+	destType := patchArgType(pass, dest)
+	valueType := patchArgType(pass, value)
+	if destType == nil {
+		return
+	}
+
+	ptr, isPtr := destType.Underlying().(*types.Pointer)
+	if !isPtr {
+		diag := analysis.Diagnostic{
+			Pos: dest.Pos(),
+			End: dest.End(),
+			Message: fmt.Sprintf("qtlint: %s.Patch destination %s of type %s is not a pointer, and Patch panics at run time",
+				recv, destText, patchTypeText(pass, destType)),
+		}
+		tv := pass.TypesInfo.Types[stripParens(dest)]
+		switch {
+		case !tv.Addressable():
+			diag.Message += fmt.Sprintf("; no fix: %s is not a variable whose address can be taken", destText)
+		case valueType != nil && !types.AssignableTo(valueType, destType):
+			diag.Message += fmt.Sprintf("; no fix: the value is not assignable to %s either", patchTypeText(pass, destType))
+		default:
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "Take the address of " + destText,
+				TextEdits: []analysis.TextEdit{{Pos: dest.Pos(), End: dest.Pos(), NewText: []byte("&")}},
+			}}
+		}
+		pass.Report(diag)
+		return
+	}
+
+	if valueType == nil || types.AssignableTo(valueType, ptr.Elem()) {
+		return
+	}
+	valueText, ok := formatExpr(pass, value)
+	if !ok {
+		return
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos: value.Pos(),
+		End: value.End(),
+		Message: fmt.Sprintf("qtlint: %s.Patch value %s of type %s is not assignable to %s, the type %s points to, and Patch panics at run time",
+			recv, valueText, patchTypeText(pass, valueType), patchTypeText(pass, ptr.Elem()), destText),
+	})
+}
+
+// patchArgType returns the dynamic type an argument of Patch has once boxed
+// in an interface{}: the default type of an untyped constant, and the static
+// type of anything else. It returns nil for an untyped nil and for an
+// interface, whose dynamic type is not known.
+func patchArgType(pass *analysis.Pass, expr ast.Expr) types.Type {
+	typ := pass.TypesInfo.TypeOf(expr)
+	if typ == nil {
+		return nil
+	}
+	if basic, ok := typ.(*types.Basic); ok && basic.Kind() == types.UntypedNil {
+		return nil
+	}
+	typ = types.Default(typ)
+	if types.IsInterface(typ) {
+		return nil
+	}
+	return typ
+}
+
+// patchTypeText renders typ for a message, relative to the package.
+func patchTypeText(pass *analysis.Pass, typ types.Type) string {
+	return types.TypeString(typ, types.RelativeTo(pass.Pkg))
+}
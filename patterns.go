@@ -0,0 +1,324 @@
+package qtlint
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// patternCheckers are the checkers whose want is a regular expression.
+var patternCheckers = map[string]bool{
+	"Matches":      true,
+	"ErrorMatches": true,
+	"PanicMatches": true,
+}
+
+// patternArg is a constant pattern given to one of patternCheckers.
+type patternArg struct {
+	expr    ast.Expr
+	pattern string
+	// checker is the pattern checker's name.
+	checker string
+}
+
+// matchPatternArg returns the constant string pattern the assertion call gives
+// a regexp-taking checker, in either API. A checker applied to each element
+// with qt.All or qt.Any, or negated with qt.Not, takes its pattern the same
+// way.
+func matchPatternArg(pass *analysis.Pass, call *ast.CallExpr) (patternArg, bool) {
+	var checker string
+	var want ast.Expr
+	if isQuicktestAssertion(pass, call) {
+		args := call.Args
+		if fun, ok := call.Fun.(*ast.SelectorExpr); ok && isPackageQualified(pass, fun) && len(args) > 0 {
+			args = args[1:]
+		}
+		if len(args) < 3 || call.Ellipsis.IsValid() {
+			return patternArg{}, false
+		}
+		c, ok := parseV1Checker(pass, args[1])
+		if !ok {
+			return patternArg{}, false
+		}
+		for c = c.base(); (c.name == "All" || c.name == "Any") && len(c.extra) == 1; c = c.base() {
+			if c, ok = parseV1Checker(pass, c.extra[0]); !ok {
+				return patternArg{}, false
+			}
+		}
+		checker, want = c.name, args[2]
+	} else if c, ok := getV2Checker(pass, call); ok {
+		c, _ = unwrapV2Not(pass, c)
+		if len(c.call.Args) != 2 {
+			return patternArg{}, false
+		}
+		checker, want = c.name, c.call.Args[1]
+	} else {
+		return patternArg{}, false
+	}
+	if !patternCheckers[checker] {
+		return patternArg{}, false
+	}
+
+	tv, ok := pass.TypesInfo.Types[want]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return patternArg{}, false
+	}
+	return patternArg{expr: want, pattern: constant.StringVal(tv.Value), checker: checker}, true
+}
+
+// checkPatternCompiles reports a constant pattern given to qt.Matches,
+// qt.ErrorMatches or qt.PanicMatches that is not a valid regular expression.
+// Both APIs compile a string pattern as ^(pattern)$ when the assertion runs,
+// and fail it as a bad check when that does not compile, however right got is;
+// the pattern is compiled here the same way.
+func checkPatternCompiles(pass *analysis.Pass, call *ast.CallExpr) {
+	arg, ok := matchPatternArg(pass, call)
+	if !ok {
+		return
+	}
+	_, err := regexp.Compile("^(" + arg.pattern + ")$")
+	if err == nil {
+		return
+	}
+	reason := err.Error()
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) {
+		reason = syntaxErr.Code.String()
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:     arg.expr.Pos(),
+		End:     arg.expr.End(),
+		Message: fmt.Sprintf("qtlint: qt.%s pattern %s does not compile: %s", arg.checker, strconv.Quote(arg.pattern), reason),
+	})
+}
+
+// checkQuoteLiteralPatterns checks for a constant pattern that was evidently
+// meant as literal text, and suggests wrapping it in regexp.QuoteMeta. The
+// pattern checkers match the whole of got, so a pattern made of literal text
+// is a common way to spell "equals", and one with a file name or a call in it
+// is read as a regular expression by accident: the dot in "config.yaml"
+// matches any character, and "Close()" matches "Close".
+//
+// A pattern is taken as literal text when the only regular-expression syntax
+// it uses is . and plain ( ) groups, which read as punctuation in a message.
+// One with a backslash, a class, a repetition, an alternation or an anchor
+// was written as a regular expression and is left alone.
+func (a *analyzer) checkQuoteLiteralPatterns(pass *analysis.Pass, call *ast.CallExpr) {
+	arg, ok := matchPatternArg(pass, call)
+	if !ok || strings.Contains(arg.pattern, `\`) {
+		return
+	}
+	re, err := syntax.Parse(arg.pattern, syntax.Perl)
+	if err != nil {
+		return
+	}
+	var dots, groups bool
+	if !literalLike(re, &dots, &groups) || !dots && !groups {
+		return
+	}
+	var why []string
+	if dots {
+		why = append(why, ". matches any character")
+	}
+	if groups {
+		why = append(why, "( and ) form a group")
+	}
+
+	argText, ok := formatExpr(pass, arg.expr)
+	if !ok {
+		return
+	}
+	diag := analysis.Diagnostic{
+		Pos: arg.expr.Pos(),
+		End: arg.expr.End(),
+		Message: fmt.Sprintf("qtlint: use regexp.QuoteMeta(%s) instead of %s, in which %s",
+			argText, argText, strings.Join(why, " and ")),
+	}
+	if edits, ok := quoteMetaEdits(pass, arg.expr, argText); ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Wrap the pattern in regexp.QuoteMeta",
+			TextEdits: edits,
+		}}
+	}
+	pass.Report(diag)
+}
+
+// literalLike reports whether re is made of literal text, any-character dots
+// and capture groups alone, recording in dots and groups which of the last two
+// it uses.
+func literalLike(re *syntax.Regexp, dots, groups *bool) bool {
+	switch re.Op {
+	case syntax.OpLiteral, syntax.OpEmptyMatch:
+		return re.Flags&syntax.FoldCase == 0
+	case syntax.OpAnyCharNotNL:
+		*dots = true
+		return true
+	case syntax.OpCapture:
+		*groups = true
+	case syntax.OpConcat:
+	default:
+		return false
+	}
+	for _, sub := range re.Sub {
+		if !literalLike(sub, dots, groups) {
+			return false
+		}
+	}
+	return true
+}
+
+// quoteMetaEdits returns the edits wrapping expr, written as argText, in
+// regexp.QuoteMeta.
+func quoteMetaEdits(pass *analysis.Pass, expr ast.Expr, argText string) ([]analysis.TextEdit, bool) {
+	name, imports, ok := regexpQualifier(pass, expr.Pos())
+	if !ok {
+		return nil, false
+	}
+	return append(imports, analysis.TextEdit{
+		Pos:     expr.Pos(),
+		End:     expr.End(),
+		NewText: []byte(name + ".QuoteMeta(" + argText + ")"),
+	}), true
+}
+
+// regexpQualifier returns the name a reference to package regexp at pos is
+// qualified by, and the edit importing regexp when the file does not. The name
+// the file imports regexp under must not be shadowed at pos, and a file that
+// lacks the import must leave the name regexp free for it.
+func regexpQualifier(pass *analysis.Pass, pos token.Pos) (string, []analysis.TextEdit, bool) {
+	file := fileOf(pass, pos)
+	if file == nil {
+		return "", nil, false
+	}
+	name := importedPkgName(pass, file, "regexp")
+	var imports []analysis.TextEdit
+	if name == "" {
+		if importSpecFor(file, "regexp") != nil || importedPkgNameIn(file, "regexp") || len(file.Imports) == 0 {
+			return "", nil, false
+		}
+		name = "regexp"
+		edit, ok := importAfter(pass, file, file.Imports[0], strconv.Quote("regexp"))
+		if !ok {
+			return "", nil, false
+		}
+		imports = append(imports, edit)
+	}
+	scope := pass.TypesInfo.Scopes[file].Innermost(pos)
+	if scope == nil {
+		return "", nil, false
+	}
+	_, obj := scope.LookupParent(name, pos)
+	if pkgName, ok := obj.(*types.PkgName); obj != nil && (!ok || pkgName.Imported().Path() != "regexp") {
+		return "", nil, false
+	}
+	return name, imports, true
+}
+
+// patternForm is how a string an assertion compares with becomes the pattern
+// of a checker that matches the whole of got.
+type patternForm int
+
+const (
+	// quoteWhole matches the string and nothing else.
+	quoteWhole patternForm = iota
+	// quoteWithin matches anything holding the string.
+	quoteWithin
+	// quotePrefix matches anything starting with the string.
+	quotePrefix
+	// quoteSuffix matches anything ending with the string.
+	quoteSuffix
+	// asPattern takes the string as the pattern itself.
+	asPattern
+)
+
+// around returns what goes before and after a pattern matching the string
+// alone for it to match what form asks: .* matching anything on either side.
+// The pattern checkers anchor a pattern as ^(pattern)$, and (?s) lets .*
+// match a string of several lines, as strings.Contains and its siblings do.
+func (form patternForm) around() (before, after string) {
+	switch form {
+	case quoteWithin:
+		return "(?s).*", ".*"
+	case quotePrefix:
+		return "(?s)", ".*"
+	case quoteSuffix:
+		return "(?s).*", ""
+	}
+	return "", ""
+}
+
+// quotedPattern returns the pattern, written as Go source, that matches what
+// form asks of want, and the edits it needs besides replacing want. A
+// constant want is quoted here, into a raw string where it fits in one; any
+// other is quoted with regexp.QuoteMeta when the assertion runs.
+func quotedPattern(pass *analysis.Pass, form patternForm, want ast.Expr) (string, []analysis.TextEdit, bool) {
+	wantText, ok := formatExpr(pass, want)
+	if !ok {
+		return "", nil, false
+	}
+	if form == asPattern {
+		return wantText, nil, true
+	}
+	before, after := form.around()
+	if tv, ok := pass.TypesInfo.Types[want]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		return patternLiteral(before + regexp.QuoteMeta(constant.StringVal(tv.Value)) + after), nil, true
+	}
+	name, imports, ok := regexpQualifier(pass, want.Pos())
+	if !ok {
+		return "", nil, false
+	}
+	pattern := name + ".QuoteMeta(" + wantText + ")"
+	if before != "" {
+		pattern = strconv.Quote(before) + " + " + pattern
+	}
+	if after != "" {
+		pattern += " + " + strconv.Quote(after)
+	}
+	return pattern, imports, true
+}
+
+// patternLiteral renders pattern as a Go string literal, raw where it fits in
+// one.
+func patternLiteral(pattern string) string {
+	if strconv.CanBackquote(pattern) {
+		return "`" + pattern + "`"
+	}
+	return strconv.Quote(pattern)
+}
+
+// fileOf returns the file of pass holding pos.
+func fileOf(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, file := range pass.Files {
+		if file.FileStart <= pos && pos <= file.FileEnd {
+			return file
+		}
+	}
+	return nil
+}
+
+// importAfter returns the edit adding the import spec on the line after
+// after, which must have a line of its own.
+func importAfter(pass *analysis.Pass, file *ast.File, after *ast.ImportSpec, spec string) (analysis.TextEdit, bool) {
+	var node ast.Node = after
+	grouped := true
+	if decl := importDeclOf(file, after); decl != nil && !decl.Lparen.IsValid() {
+		node, grouped = decl, false
+	}
+	_, end, ok := wholeLineSpan(pass, node)
+	if !ok {
+		return analysis.TextEdit{}, false
+	}
+	if !grouped {
+		spec = "import " + spec
+	}
+	return analysis.TextEdit{Pos: end, End: end, NewText: []byte(spec + "\n")}, true
+}
@@ -14,6 +14,11 @@ import (
 // analyzer enforces practices for.
 const quicktestPkgPath = "github.com/frankban/quicktest"
 
+// quicktestV2PkgPath is the import path of quicktest's generics-based
+// successor, whose checkers are calls that own their arguments:
+// qt.Assert(t, qt.Equals(got, want)) rather than c.Assert(got, qt.Equals, want).
+const quicktestV2PkgPath = "github.com/go-quicktest/qt"
+
 // testingPkgPath is the import path of the standard library testing package.
 const testingPkgPath = "testing"
 
@@ -1,10 +1,20 @@
 // Package qtlint implements a static analysis tool that enforces
-// best practices for using the frankban/quicktest testing library.
+// best practices for using the frankban/quicktest testing library and its
+// generic successor, go-quicktest/qt.
 //
 // The analyzer detects and reports suboptimal usage patterns, including:
 //   - qt.Not(qt.IsNil) which should be replaced with qt.IsNotNil
 //   - qt.Not(qt.IsTrue) which should be replaced with qt.IsFalse
 //   - qt.Not(qt.IsFalse) which should be replaced with qt.IsTrue
+//   - qt.Not(qt.IsNotNil) which should be replaced with qt.IsNil
+//   - qt.Not(qt.Not(C)) which should be replaced with C
+//   - x, qt.Equals, true which should be replaced with x, qt.IsTrue, and
+//     x, qt.Equals, false with x, qt.IsFalse
+//   - qt.CmpEquals() without options which should be replaced with
+//     qt.DeepEquals
+//   - x, qt.DeepEquals, y on a bool, a number or a string which should be
+//     replaced with x, qt.Equals, y
+//   - err, qt.ErrorIs, nil which should be replaced with err, qt.IsNil
 //   - len(x), qt.Equals which should be replaced with x, qt.HasLen
 //   - x == y, qt.IsTrue which should be replaced with x, qt.Equals, y
 //   - x == y, qt.IsFalse which should be replaced with x, qt.Not(qt.Equals), y
@@ -20,9 +30,59 @@
 //   - errors.Is(err, target), qt.IsFalse which should be replaced with err, qt.Not(qt.ErrorIs), target
 //   - errors.As(err, &target), qt.IsTrue which should be replaced with err, qt.ErrorAs, &target
 //   - errors.As(err, &target), qt.IsFalse which should be replaced with err, qt.Not(qt.ErrorAs), &target
+//   - reflect.DeepEqual(x, y), qt.IsTrue which should be replaced with x, qt.DeepEquals, y,
+//     and likewise for bytes.Equal, slices.Equal and maps.Equal, and
+//     strings.HasPrefix(s, p), qt.IsTrue with s, qt.Matches and a quoted
+//     pattern, and likewise for strings.HasSuffix, bytes.Contains and
+//     regexp.MustCompile(p).MatchString(s)
 //   - if err != nil { t.Fatal[f](...) } which should be replaced with c.Assert(err, qt.IsNil, qt.Commentf(...))
 //   - if err != nil { t.Error[f](...) } which should be replaced with c.Check(err, qt.IsNil, qt.Commentf(...))
+//   - if got != want { t.Fatal[f](...) } which should be replaced with c.Assert(got, qt.Equals, want, qt.Commentf(...)),
+//     and likewise for the other conditions a checker states
 //   - x, qt.Equals, nil which should be replaced with x, qt.IsNil
+//   - err.Error(), qt.Equals, "boom" which should be replaced with
+//     err, qt.ErrorMatches, `boom`, and likewise for qt.Contains and qt.Matches
+//   - x, qt.Equals, y on slices, maps, funcs or pointers to structs which
+//     should be replaced with x, qt.DeepEquals, y
+//   - if diff := cmp.Diff(want, got); diff != "" { t.Error[f](...) } which
+//     should be replaced with c.Check(got, qt.DeepEquals, want), or with
+//     qt.CmpEquals(opts...) when cmp.Diff is given options
+//   - x, qt.IsNil on an int, x, qt.HasLen on a value with no length, and
+//     other checkers given an operand they cannot check, which are reported
+//     as bugs without a fix
+//   - c.Assert(x, qt.Equals) and c.Assert(err, qt.IsNil, y), whose checker is
+//     given fewer or more arguments than it takes, which are reported without
+//     a fix; arguments spread with args... are reported under
+//     -strict-checker-args
+//   - x, qt.Matches, "bad (regexp", a constant pattern that does not compile,
+//     which is reported without a fix
+//   - defer func() { if recover() == nil { t.Fatal(...) } }() ahead of the
+//     code expected to panic, which should be replaced with
+//     c.Assert(func() { ... }, qt.PanicMatches, pattern)
+//   - for _, v := range items { c.Assert(v, qt.Equals, want) }, which should
+//     be replaced with c.Assert(items, qt.All(qt.Equals), want), and a loop
+//     setting a found flag asserted with qt.IsTrue, with qt.Any
+//   - qt.Commentf("id %d", name), whose format does not fit its arguments,
+//     which is reported as go vet reports fmt.Printf; qt.Commentf(msg) with a
+//     non-constant format and no arguments, which should be replaced with
+//     qt.Commentf("%s", msg); and c.Assert(x, qt.Equals, qt.Commentf(...), y),
+//     whose comment should be moved after the checker's arguments
+//   - go func() { c.Assert(err, qt.IsNil) }(), and likewise a function given
+//     to errgroup.Group.Go or sync.WaitGroup.Go, which should use c.Check, as
+//     t.FailNow must be called from the test goroutine
+//   - c.Defer(f) on a c := qt.New(t) whose Done nothing calls, which panics
+//     with "Done not called after Defer" and should be followed by
+//     defer c.Done()
+//   - c.Patch(myFunc, stub), whose destination is not a pointer, which should
+//     be replaced with c.Patch(&myFunc, stub), and c.Patch(&timeout, 5), whose
+//     value is not assignable to the type the destination points to, which
+//     is reported without a fix
+//   - t.Setenv, t.Chdir, c.Setenv, c.Unsetenv and a c.Patch of a
+//     package-level variable in a test that calls Parallel or runs under one
+//     that does, which panic or race and are reported without a fix
+//   - t.Run("x", func(t2 *testing.T) { c := qt.New(t) }), whose checker is
+//     built from the test around the subtest, which should be replaced with
+//     qt.New(t2)
 //
 // It also carries house-style rules that are off unless their flag is set,
 // because both forms they choose between are correct quicktest:
@@ -30,6 +90,35 @@
 //     should be replaced with c.Assert(...) / c.Check(...) on a *qt.C
 //   - -require-testing-run: c.Run(name, func(c *qt.C)) which should be
 //     replaced with t.Run(name, func(t *testing.T)) plus a per-subtest qt.New
+//   - -quote-literal-patterns: err, qt.ErrorMatches, "open a.txt" which should
+//     be replaced with err, qt.ErrorMatches, regexp.QuoteMeta("open a.txt")
+//   - -prefer-json-equals: json.Unmarshal(body, &got) asserted nil and then
+//     got, qt.DeepEquals, want, which should be replaced with
+//     body, qt.JSONEquals, want, and likewise for comparing json.Marshal output
+//   - -swapped-got-want: c.Assert(42, qt.Equals, n) which should be replaced
+//     with c.Assert(n, qt.Equals, 42)
+//   - -modernize-lifecycle: c.Mkdir(), c.Defer(f) and c.Setenv(k, v), which
+//     should be replaced with the testing.TB methods c.TempDir(), c.Cleanup(f)
+//     and t.Setenv(k, v) where the file's Go version has them
+//   - -unused-checker: c := qt.New(t) in a test that never asserts with c,
+//     runs a subtest on it or hands it to a helper that does, which should be
+//     removed
+//
+// And migration rules, off unless their flag is set, because they report code
+// that is correct as it stands:
+//   - -migrate-to-qt-v2: c.Assert(got, qt.Equals, want) which should be
+//     replaced with qt.Assert(t, qt.Equals(got, want)) from go-quicktest/qt
+//   - -migrate-from-testify: assert.Equal(t, want, got) which should be
+//     replaced with c.Check(got, qt.DeepEquals, want), in a file that
+//     already imports frankban/quicktest
+//   - -migrate-from-gocheck: func (s *S) TestX(c *check.C) which should be
+//     replaced with func TestX(t *testing.T) and c := qt.New(t), with each
+//     gocheck checker replaced by its quicktest namesake
+//
+// The default rules also cover the generic go-quicktest/qt API, where a
+// checker is a call that owns its arguments: qt.Not(qt.IsNil(x)) is reported
+// with qt.IsNotNil(x) as its fix, and if err != nil { t.Fatal(err) } with
+// qt.Assert(t, qt.IsNil(err)) where no *qt.C is in scope.
 //
 // This linter is designed to be used as a custom linter for golangci-lint.
 package qtlint
@@ -79,6 +168,57 @@ type analyzer struct {
 	// requireDataRows enables the opt-in house-style rule that refuses a
 	// table-row function field whose every row holds one assertion.
 	requireDataRows bool
+
+	// migrateToQtV2 enables the opt-in rule that reports every
+	// frankban/quicktest assertion and suggests its go-quicktest/qt form. It
+	// is off by default: it is a migration a project runs once, not a defect.
+	migrateToQtV2 bool
+
+	// migrateFromTestify enables the opt-in rule that reports testify
+	// assertions in files that import frankban/quicktest and suggests the
+	// *qt.C assertion each becomes. It is off by default for the same reason.
+	migrateFromTestify bool
+
+	// migrateFromGocheck enables the opt-in rule that reports gocheck test
+	// methods and suggests the quicktest test function each becomes. It is
+	// off by default for the same reason.
+	migrateFromGocheck bool
+
+	// strictCheckerArgs extends the default checker argument-count rule to
+	// assertions whose arguments are spread from a slice, which it cannot
+	// count. It is off by default: such an assertion may well be right.
+	strictCheckerArgs bool
+
+	// quoteLiteralPatterns enables the opt-in house-style rule that reports a
+	// regexp checker pattern evidently meant as literal text and suggests
+	// wrapping it in regexp.QuoteMeta. It is off by default: the rule guesses
+	// at what the pattern means.
+	quoteLiteralPatterns bool
+
+	// preferJSONEquals enables the opt-in house-style rule that reports JSON
+	// decoded or encoded only to be compared and suggests qt.JSONEquals. It is
+	// off by default: qt.JSONEquals compares JSON documents, which is less
+	// than the comparison it replaces checks.
+	preferJSONEquals bool
+
+	// swappedGotWant enables the opt-in house-style rule that reports an
+	// assertion whose got reads as the expected value and whose want does
+	// not, and suggests swapping them. It is off by default: the rule goes by
+	// what the operands look like, not by what they mean.
+	swappedGotWant bool
+
+	// modernizeLifecycle enables the opt-in house-style rule that reports the
+	// *qt.C helpers testing.TB has superseded, such as Mkdir and Defer, and
+	// suggests the testing.TB method. It is off by default: the helpers still
+	// work, and the Go version a module targets decides whether the
+	// replacement is there to call.
+	modernizeLifecycle bool
+
+	// unusedChecker enables the opt-in house-style rule that reports a *qt.C
+	// a test makes with qt.New and never asserts with, and suggests removing
+	// it. It is off by default: the checker does no harm, and a test may keep
+	// one ready for assertions still to be written.
+	unusedChecker bool
 }
 
 // NewAnalyzer creates a new instance of the qtlint analyzer.
@@ -92,7 +232,7 @@ func NewAnalyzer() *analysis.Analyzer {
 	}
 	aa.Flags.BoolVar(&a.onlyStableFixes, "only-stable-fixes", false,
 		"emit SuggestedFix only for diagnostics whose rewrite is reliable; "+
-			"best-effort fixes (e.g. errnil-fatal with non-literal format or "+
+			"best-effort fixes (e.g. a failure block with non-literal format or "+
 			"multi-arg non-formatted call) are reported without an auto-fix")
 	aa.Flags.BoolVar(&a.requireQtCReceiver, "require-qt-c-receiver", false,
 		"house-style rule, off by default: report qt.Assert(t, ...) and "+
@@ -104,6 +244,33 @@ func NewAnalyzer() *analysis.Analyzer {
 	aa.Flags.BoolVar(&a.requireTestingRun, "require-testing-run", false,
 		"house-style rule, off by default: report c.Run(...) subtests and "+
 			"suggest t.Run(name, func(t *testing.T)) with a per-subtest qt.New")
+	aa.Flags.BoolVar(&a.migrateToQtV2, "migrate-to-qt-v2", false,
+		"migration rule, off by default: report frankban/quicktest assertions "+
+			"and suggest their go-quicktest/qt form, one file at a time")
+	aa.Flags.BoolVar(&a.migrateFromTestify, "migrate-from-testify", false,
+		"migration rule, off by default: report testify assert and require "+
+			"calls in files that import frankban/quicktest and suggest their *qt.C form")
+	aa.Flags.BoolVar(&a.migrateFromGocheck, "migrate-from-gocheck", false,
+		"migration rule, off by default: report gopkg.in/check.v1 test methods "+
+			"and suggest a func TestX(t *testing.T) asserting through qt.New")
+	aa.Flags.BoolVar(&a.strictCheckerArgs, "strict-checker-args", false,
+		"also report assertions whose checker arguments are spread with args... "+
+			"and cannot be counted (opt-in)")
+	aa.Flags.BoolVar(&a.quoteLiteralPatterns, "quote-literal-patterns", false,
+		"house-style rule, off by default: report qt.Matches, qt.ErrorMatches and "+
+			"qt.PanicMatches patterns that read as literal text and suggest regexp.QuoteMeta")
+	aa.Flags.BoolVar(&a.preferJSONEquals, "prefer-json-equals", false,
+		"house-style rule, off by default: report json.Unmarshal or json.Marshal "+
+			"results made only to be compared and suggest qt.JSONEquals")
+	aa.Flags.BoolVar(&a.swappedGotWant, "swapped-got-want", false,
+		"house-style rule, off by default: report qt.Equals and similar assertions "+
+			"whose got reads as the expected value and suggest swapping got and want")
+	aa.Flags.BoolVar(&a.modernizeLifecycle, "modernize-lifecycle", false,
+		"house-style rule, off by default: report *qt.C Mkdir, Defer and Setenv "+
+			"and suggest the testing.TB method the module's Go version provides")
+	aa.Flags.BoolVar(&a.unusedChecker, "unused-checker", false,
+		"house-style rule, off by default: report a *qt.C a test makes with qt.New "+
+			"and never asserts with, and suggest removing it")
 	return aa
 }
 
@@ -113,9 +280,10 @@ var Analyzer = NewAnalyzer()
 
 // Replacement suggestions for qt.Not() patterns.
 var replacements = map[string]string{
-	"IsNil":   "IsNotNil",
-	"IsTrue":  "IsFalse",
-	"IsFalse": "IsTrue",
+	"IsNil":    "IsNotNil",
+	"IsNotNil": "IsNil",
+	"IsTrue":   "IsFalse",
+	"IsFalse":  "IsTrue",
 }
 
 func (a *analyzer) run(pass *analysis.Pass) (any, error) {
@@ -126,6 +294,8 @@ func (a *analyzer) run(pass *analysis.Pass) (any, error) {
 	}
 
 	inSourceOrder(pass, func() {
+		panicChecks := a.checkPanicMatchesPattern(pass, insp)
+
 		// Filter for nodes we want to inspect.
 		nodeFilter := []ast.Node{
 			(*ast.CallExpr)(nil),
@@ -136,10 +306,32 @@ func (a *analyzer) run(pass *analysis.Pass) (any, error) {
 			switch n := n.(type) {
 			case *ast.CallExpr:
 				checkQuicktestCall(pass, n)
+				checkQuicktestV2Call(pass, n)
+				a.checkEqualsDeepPattern(pass, n)
+				a.checkHelperPredicatePattern(pass, n)
+				a.checkCheckerArgCount(pass, n)
+				checkPatternCompiles(pass, n)
+				checkCommentf(pass, n)
+				checkMisplacedComment(pass, n)
+				checkPatchArgs(pass, n)
+				if a.quoteLiteralPatterns {
+					a.checkQuoteLiteralPatterns(pass, n)
+				}
+				if a.swappedGotWant {
+					a.checkSwappedGotWant(pass, n)
+				}
 			case *ast.IfStmt:
-				a.checkErrNilFatalPattern(pass, n)
+				if !panicChecks[n] {
+					a.checkFailureBlockPattern(pass, n)
+				}
+				a.checkCmpDiffPattern(pass, n)
 			}
 		})
+		a.checkAllAnyPattern(pass, insp)
+		a.checkGoroutineAsserts(pass, insp)
+		a.checkDeferWithoutDone(pass)
+		checkParallelMutations(pass)
+		checkSubtestQtNew(pass)
 
 		a.runOptInRules(pass, insp)
 	})
@@ -205,6 +397,24 @@ func (a *analyzer) runOptInRules(pass *analysis.Pass, insp *inspector.Inspector)
 	if a.requireTestingRun {
 		a.checkRequireTestingRun(pass)
 	}
+	if a.migrateToQtV2 {
+		a.checkMigrateToQtV2(pass)
+	}
+	if a.migrateFromTestify {
+		a.checkMigrateFromTestify(pass)
+	}
+	if a.migrateFromGocheck {
+		a.checkMigrateFromGocheck(pass)
+	}
+	if a.preferJSONEquals {
+		a.checkPreferJSONEquals(pass, insp)
+	}
+	if a.modernizeLifecycle {
+		a.checkModernizeLifecycle(pass)
+	}
+	if a.unusedChecker {
+		a.checkUnusedChecker(pass)
+	}
 	if !a.requireQtCReceiver {
 		return
 	}
@@ -242,6 +452,13 @@ func checkQuicktestCall(pass *analysis.Pass, call *ast.CallExpr) {
 	// Check if the checker is qt.Not(...)
 	checkNotPattern(pass, checkerArg)
 
+	// Check for qt.Not(qt.Not(...)) and for qt.CmpEquals() without options.
+	checkDoubleNotPattern(pass, checkerArg)
+	checkCmpEqualsNoOptsPattern(pass, checkerArg)
+
+	// Check for a checker that cannot check the operand it is given.
+	checkImpossibleChecker(pass, call, checkerArg)
+
 	// Check for len(x), qt.Equals pattern
 	checkLenEqualsPattern(pass, call)
 
@@ -259,8 +476,20 @@ func checkQuicktestCall(pass *analysis.Pass, call *ast.CallExpr) {
 	// Check for errors.Is(err, target) or errors.As(err, &target) with qt.IsTrue/qt.IsFalse pattern.
 	checkErrorIsAsPattern(pass, call)
 
+	// Check for err.Error() compared as a string.
+	checkErrorStringPattern(pass, call)
+
 	// Check for x, qt.Equals, nil pattern.
 	checkEqualsNilPattern(pass, call)
+
+	// Check for x, qt.Equals, true and x, qt.Equals, false patterns.
+	checkEqualsBoolPattern(pass, call)
+
+	// Check for qt.DeepEquals on two values of a basic type.
+	checkDeepEqualsBasicPattern(pass, call)
+
+	// Check for err, qt.ErrorIs, nil pattern.
+	checkErrorIsNilPattern(pass, call)
 }
 
 // checkEqualsNilPattern checks if the pattern is x, qt.Equals, nil and suggests
@@ -443,8 +672,75 @@ func isQuicktestAssertion(pass *analysis.Pass, call *ast.CallExpr) bool {
 	return isPackageQualified(pass, sel) || isQuicktestCMethod(pass, sel)
 }
 
+// assertionStmt is an assertion statement written in either API,
+// qt.Assert(t, got, qt.X, want) or qt.Assert(t, qt.X(got, want)), for a rule
+// that reads the statements around it.
+type assertionStmt struct {
+	stmt *ast.ExprStmt
+	// checker is the checker's selector, such as qt.DeepEquals, and got and
+	// want its operands; want is nil for a checker that takes none.
+	checker   *ast.SelectorExpr
+	got, want ast.Expr
+	// bare is set when the assertion carries nothing but the check: no
+	// comment that removing the statement would lose.
+	bare bool
+}
+
+// matchAssertionStmt parses stmt into an assertionStmt. A checker wrapped in
+// qt.Not is not matched.
+func matchAssertionStmt(pass *analysis.Pass, stmt ast.Stmt) (assertionStmt, bool) {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return assertionStmt{}, false
+	}
+	call, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok || call.Ellipsis.IsValid() {
+		return assertionStmt{}, false
+	}
+	if checker, ok := getV2Checker(pass, call); ok {
+		args := checker.call.Args
+		if checker.name == "Not" || len(args) == 0 || len(args) > 2 {
+			return assertionStmt{}, false
+		}
+		m := assertionStmt{stmt: exprStmt, checker: checker.sel, got: args[0], bare: len(call.Args) == 2}
+		if len(args) == 2 {
+			m.want = args[1]
+		}
+		return m, true
+	}
+	if !isQuicktestAssertion(pass, call) {
+		return assertionStmt{}, false
+	}
+	args := call.Args
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && isPackageQualified(pass, sel) && len(args) > 0 {
+		args = args[1:]
+	}
+	if len(args) < 2 {
+		return assertionStmt{}, false
+	}
+	checker, ok := args[1].(*ast.SelectorExpr)
+	if !ok || !isPackageQualified(pass, checker) {
+		return assertionStmt{}, false
+	}
+	wants, ok := v1CheckerWants[checker.Sel.Name]
+	if !ok || len(args) < 2+wants {
+		return assertionStmt{}, false
+	}
+	m := assertionStmt{stmt: exprStmt, checker: checker, got: args[0], bare: len(args) == 2+wants}
+	if wants == 1 {
+		m.want = args[2]
+	}
+	return m, true
+}
+
 // isPackageQualified checks if a selector expression refers to a symbol in the quicktest package.
 func isPackageQualified(pass *analysis.Pass, sel *ast.SelectorExpr) bool {
+	return isQualifiedBy(pass, sel, quicktestPkgPath)
+}
+
+// isQualifiedBy checks if a selector expression refers to a symbol in the
+// package imported from path.
+func isQualifiedBy(pass *analysis.Pass, sel *ast.SelectorExpr, path string) bool {
 	ident, ok := sel.X.(*ast.Ident)
 	if !ok {
 		return false
@@ -460,7 +756,7 @@ func isPackageQualified(pass *analysis.Pass, sel *ast.SelectorExpr) bool {
 		return false
 	}
 
-	return pkg.Imported().Path() == quicktestPkgPath
+	return pkg.Imported().Path() == path
 }
 
 // isQuicktestCMethod checks if a selector is a method on *qt.C.
@@ -1146,6 +1442,101 @@ func checkErrorIsAsPattern(pass *analysis.Pass, call *ast.CallExpr) {
 	})
 }
 
+// errorStringCheckers maps the string checkers an err.Error() result may be
+// given to, in either API, to how the checker's want becomes a pattern for
+// qt.ErrorMatches: quoted, quoted and surrounded by anything, or as it is.
+var errorStringCheckers = map[string]patternForm{
+	"Equals":         quoteWhole,
+	"Contains":       quoteWithin,
+	"StringContains": quoteWithin,
+	"Matches":        asPattern,
+}
+
+// matchErrorString returns the error whose Error method expr calls, when expr
+// is such a call.
+func matchErrorString(pass *analysis.Pass, expr ast.Expr) (ast.Expr, bool) {
+	call, ok := stripParens(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Error" {
+		return nil, false
+	}
+	if selection, ok := pass.TypesInfo.Selections[sel]; !ok || selection.Kind() != types.MethodVal {
+		return nil, false
+	}
+	if !isErrorType(pass, sel.X) {
+		return nil, false
+	}
+	return sel.X, true
+}
+
+// checkErrorStringPattern checks for err.Error() given to qt.Equals,
+// qt.Contains or qt.Matches, or to qt.Not around one, and suggests asserting
+// on err itself with qt.ErrorMatches. Calling Error on a nil error panics, so
+// the assertion crashes the test where qt.ErrorMatches fails it with a message
+// saying the error is nil.
+func checkErrorStringPattern(pass *analysis.Pass, call *ast.CallExpr) {
+	args := call.Args
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && isPackageQualified(pass, sel) && len(args) > 0 {
+		args = args[1:]
+	}
+	if len(args) < 3 || call.Ellipsis.IsValid() || isV1CommentType(pass.TypesInfo.TypeOf(args[2])) {
+		return
+	}
+	gotArg, checkerArg, want := args[0], args[1], args[2]
+	errExpr, ok := matchErrorString(pass, gotArg)
+	if !ok {
+		return
+	}
+	checker, ok := parseV1Checker(pass, checkerArg)
+	if !ok {
+		return
+	}
+	not := checker.inner != nil
+	if not && checker.inner.inner != nil {
+		return
+	}
+	form, ok := errorStringCheckers[checker.base().name]
+	if !ok || checker.base().name == "StringContains" || len(checker.base().extra) > 0 {
+		return
+	}
+	qtPkgIdent, ok := checker.sels[0].X.(*ast.Ident)
+	if !ok {
+		return
+	}
+
+	old, replacement := "qt."+checker.base().name, "qt.ErrorMatches"
+	newCheckerText := qtPkgIdent.Name + ".ErrorMatches"
+	if not {
+		old, replacement = "qt.Not("+old+")", "qt.Not(qt.ErrorMatches)"
+		newCheckerText = qtPkgIdent.Name + ".Not(" + newCheckerText + ")"
+	}
+	diag := analysis.Diagnostic{
+		Pos:     gotArg.Pos(),
+		End:     want.End(),
+		Message: fmt.Sprintf("qtlint: use err, %s instead of err.Error(), %s", replacement, old),
+	}
+	errText, ok := formatExpr(pass, errExpr)
+	if !ok {
+		pass.Report(diag)
+		return
+	}
+	pattern, edits, ok := quotedPattern(pass, form, want)
+	if ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Replace with " + replacement,
+			TextEdits: append(edits,
+				analysis.TextEdit{Pos: gotArg.Pos(), End: gotArg.End(), NewText: []byte(errText)},
+				analysis.TextEdit{Pos: checkerArg.Pos(), End: checkerArg.End(), NewText: []byte(newCheckerText)},
+				analysis.TextEdit{Pos: want.Pos(), End: want.End(), NewText: []byte(pattern)},
+			),
+		}}
+	}
+	pass.Report(diag)
+}
+
 func stripParens(expr ast.Expr) ast.Expr {
 	for {
 		p, ok := expr.(*ast.ParenExpr)
@@ -1189,6 +1580,12 @@ func isErrorType(pass *analysis.Pass, expr ast.Expr) bool {
 }
 
 func findQuicktestPkgAlias(pass *analysis.Pass, start ast.Node) string {
+	return findPkgAlias(pass, start, quicktestPkgPath)
+}
+
+// findPkgAlias returns the name under which the package imported from path is
+// visible from start's scope, or "" when it is not.
+func findPkgAlias(pass *analysis.Pass, start ast.Node, path string) string {
 	scope := pass.TypesInfo.Scopes[start]
 	if scope == nil {
 		return ""
@@ -1200,7 +1597,7 @@ func findQuicktestPkgAlias(pass *analysis.Pass, start ast.Node) string {
 			if !ok {
 				continue
 			}
-			if pkgName.Imported().Path() == quicktestPkgPath {
+			if pkgName.Imported().Path() == path {
 				return name
 			}
 		}
@@ -1248,65 +1645,64 @@ func findQuicktestCVarName(pass *analysis.Pass, start ast.Node) string {
 	return ""
 }
 
-// errNilFatalMatch holds the pattern parsed by matchErrNilFatal.
-type errNilFatalMatch struct {
-	errExpr    ast.Expr
+// failureBlockMatch holds the pattern parsed by matchFailureBlock.
+type failureBlockMatch struct {
+	check      failureCheck
 	call       *ast.CallExpr
 	sel        *ast.SelectorExpr
 	methodName string // "Fatal", "Fatalf", "Error", or "Errorf"
 	qtMethod   string // "Assert" or "Check"
 }
 
-// matchErrNilFatal validates and parses ifStmt into an errNilFatalMatch.
-func matchErrNilFatal(pass *analysis.Pass, ifStmt *ast.IfStmt) (errNilFatalMatch, bool) {
+// matchFailureBlock validates and parses ifStmt into a failureBlockMatch: an
+// if statement without an else whose body only fails the test, guarded by a
+// condition failureCondition can turn into a checker.
+func matchFailureBlock(pass *analysis.Pass, ifStmt *ast.IfStmt) (failureBlockMatch, bool) {
 	if ifStmt == nil || ifStmt.Else != nil {
-		return errNilFatalMatch{}, false
+		return failureBlockMatch{}, false
 	}
 
-	cond := stripParens(ifStmt.Cond)
-	binExpr, ok := cond.(*ast.BinaryExpr)
-	if !ok || binExpr.Op != token.NEQ {
-		return errNilFatalMatch{}, false
+	check, ok := failureCondition(pass, ifStmt.Cond)
+	if !ok {
+		return failureBlockMatch{}, false
 	}
 
-	// Match: <expr> != nil (or nil != <expr>) where <expr> is assignable to error.
-	var errExpr ast.Expr
-	switch {
-	case isNilIdent(binExpr.Y):
-		errExpr = binExpr.X
-	case isNilIdent(binExpr.X):
-		errExpr = binExpr.Y
-	default:
-		return errNilFatalMatch{}, false
-	}
-	if !isErrorType(pass, errExpr) {
-		return errNilFatalMatch{}, false
+	call, sel, qtMethod, ok := matchFailureCall(ifStmt.Body)
+	if !ok {
+		return failureBlockMatch{}, false
 	}
+	return failureBlockMatch{check, call, sel, sel.Sel.Name, qtMethod}, true
+}
 
-	if len(ifStmt.Body.List) != 1 {
-		return errNilFatalMatch{}, false
+// matchFailureCall parses a block holding nothing but a Fatal[f] or Error[f]
+// call, returning the call, its selector and the quicktest method the call
+// maps to: "Assert" for Fatal[f], which stops the test, and "Check" for
+// Error[f], which does not. Whose method the call is, is left to the caller.
+func matchFailureCall(body *ast.BlockStmt) (*ast.CallExpr, *ast.SelectorExpr, string, bool) {
+	if len(body.List) != 1 {
+		return nil, nil, "", false
 	}
 
-	exprStmt, ok := ifStmt.Body.List[0].(*ast.ExprStmt)
+	exprStmt, ok := body.List[0].(*ast.ExprStmt)
 	if !ok {
-		return errNilFatalMatch{}, false
+		return nil, nil, "", false
 	}
 	call, ok := exprStmt.X.(*ast.CallExpr)
 	if !ok {
-		return errNilFatalMatch{}, false
+		return nil, nil, "", false
 	}
 	sel, ok := call.Fun.(*ast.SelectorExpr)
 	if !ok {
-		return errNilFatalMatch{}, false
+		return nil, nil, "", false
 	}
 
 	switch sel.Sel.Name {
 	case "Fatal", "Fatalf":
-		return errNilFatalMatch{errExpr, call, sel, sel.Sel.Name, "Assert"}, true
+		return call, sel, "Assert", true
 	case "Error", "Errorf":
-		return errNilFatalMatch{errExpr, call, sel, sel.Sel.Name, "Check"}, true
+		return call, sel, "Check", true
 	default:
-		return errNilFatalMatch{}, false
+		return nil, nil, "", false
 	}
 }
 
@@ -1353,11 +1749,16 @@ func errMismatch(pass *analysis.Pass, errExpr ast.Expr, call *ast.CallExpr) bool
 	return false
 }
 
-func (a *analyzer) checkErrNilFatalPattern(pass *analysis.Pass, ifStmt *ast.IfStmt) {
-	m, ok := matchErrNilFatal(pass, ifStmt)
+func (a *analyzer) checkFailureBlockPattern(pass *analysis.Pass, ifStmt *ast.IfStmt) {
+	m, ok := matchFailureBlock(pass, ifStmt)
 	if !ok {
 		return
 	}
+	// A cmp.Diff block is a failure block too, and checkCmpDiffPattern knows
+	// what its condition means.
+	if _, ok := matchCmpDiff(pass, ifStmt); ok {
+		return
+	}
 
 	// Verify the method belongs to the testing package so we don't accidentally
 	// flag calls to custom types that happen to have a Fatal/Error method.
@@ -1367,31 +1768,36 @@ func (a *analyzer) checkErrNilFatalPattern(pass *analysis.Pass, ifStmt *ast.IfSt
 	}
 
 	// Don't fire when the call clearly uses a different error variable.
-	if errMismatch(pass, m.errExpr, m.call) {
+	if isErrorType(pass, m.check.got) && errMismatch(pass, m.check.got, m.call) {
 		return
 	}
 
-	// The scope attached to the body is a good starting point for finding visible names.
-	startScopeNode := ast.Node(ifStmt.Body)
-	qtAlias := findQuicktestPkgAlias(pass, startScopeNode)
-	cVar := findQuicktestCVarName(pass, startScopeNode)
-	if qtAlias == "" || cVar == "" {
+	gotText, ok := formatExpr(pass, m.check.got)
+	if !ok {
 		return
 	}
+	var wantText string
+	if m.check.want != nil {
+		if wantText, ok = formatExpr(pass, m.check.want); !ok {
+			return
+		}
+	}
 
-	errText, ok := formatExpr(pass, m.errExpr)
+	receiverText, ok := formatExpr(pass, m.sel.X)
 	if !ok {
 		return
 	}
 
-	receiverText, ok := formatExpr(pass, m.sel.X)
+	// The scope attached to the body is a good starting point for finding visible names.
+	spelling, ok := assertionSpellingAt(pass, ifStmt.Body, selection, m.sel.X, receiverText)
 	if !ok {
 		return
 	}
 
-	shortAssertText := fmt.Sprintf("%s.%s(%s, %s.IsNil)", cVar, m.qtMethod, errText, qtAlias)
+	assertion := m.check.render(spelling, gotText, wantText)
+	shortAssertText := spelling.call(m.qtMethod, assertion)
 	if len(m.call.Args) > 0 {
-		shortAssertText = fmt.Sprintf("%s.%s(%s, %s.IsNil, %s.Commentf(...))", cVar, m.qtMethod, errText, qtAlias, qtAlias)
+		shortAssertText = spelling.call(m.qtMethod, assertion+", "+spelling.qtAlias+".Commentf(...)")
 	}
 
 	diag := analysis.Diagnostic{
@@ -1400,8 +1806,8 @@ func (a *analyzer) checkErrNilFatalPattern(pass *analysis.Pass, ifStmt *ast.IfSt
 		Message: fmt.Sprintf("qtlint: use %s instead of %s.%s(...)", shortAssertText, receiverText, m.methodName),
 	}
 
-	if fix, ok := buildErrNilFatalFix(pass, ifStmt, m, cVar, qtAlias, errText); ok {
-		if fix.stable || !a.onlyStableFixes {
+	if fix, ok := buildFailureBlockFix(pass, ifStmt, m, spelling, assertion, gotText); ok && m.check.fixable(pass, spelling) {
+		if fix.stable && m.check.stable || !a.onlyStableFixes {
 			diag.SuggestedFixes = []analysis.SuggestedFix{{
 				Message: fix.message,
 				TextEdits: []analysis.TextEdit{{
@@ -1416,9 +1822,119 @@ func (a *analyzer) checkErrNilFatalPattern(pass *analysis.Pass, ifStmt *ast.IfSt
 	pass.Report(diag)
 }
 
-// errNilFatalFix describes a single-statement rewrite for the
-// `if err != nil { t.Fatal[f]/t.Error[f](...) }` pattern.
-type errNilFatalFix struct {
+// assertionSpelling renders an assertion in whichever quicktest API the code
+// around it already uses.
+//
+// frankban/quicktest asserts through a *qt.C and passes the checker beside its
+// arguments; go-quicktest/qt asserts through a package-level function given
+// the test handle, and the checker is a call that owns its arguments. A rule
+// that synthesizes an assertion has to write one or the other, and which one
+// is decided by what is in scope rather than by the rule.
+type assertionSpelling struct {
+	// qtAlias is the name the chosen package is visible under.
+	qtAlias string
+	// cVar is the *qt.C the frankban/quicktest form goes through. It is empty
+	// for the go-quicktest/qt form, which names handle instead.
+	cVar   string
+	handle string
+}
+
+// call renders the assertion method applied to args.
+func (s assertionSpelling) call(method, args string) string {
+	if s.cVar != "" {
+		return s.cVar + "." + method + "(" + args + ")"
+	}
+	return s.qtAlias + "." + method + "(" + s.handle + ", " + args + ")"
+}
+
+// checker renders the argument-free checker name applied to got.
+func (s assertionSpelling) checker(name, got string) string {
+	return s.check(name, false, got, "")
+}
+
+// check renders the checker name applied to got and, unless it is empty,
+// want, wrapped in qt.Not when not is set.
+func (s assertionSpelling) check(name string, not bool, got, want string) string {
+	if s.cVar != "" {
+		checker := s.qtAlias + "." + name
+		if not {
+			checker = s.qtAlias + ".Not(" + checker + ")"
+		}
+		if want == "" {
+			return got + ", " + checker
+		}
+		return got + ", " + checker + ", " + want
+	}
+	args := got
+	if want != "" {
+		args += ", " + want
+	}
+	checker := s.qtAlias + "." + name + "(" + args + ")"
+	if not {
+		checker = s.qtAlias + ".Not(" + checker + ")"
+	}
+	return checker
+}
+
+// comparison renders a checker comparing got with want. opts are the
+// checker's own arguments, written after the checker name in the
+// frankban/quicktest form and after got and want in the go-quicktest/qt form;
+// with none, the frankban/quicktest checker is named rather than called.
+func (s assertionSpelling) comparison(name, got, want string, opts []string) string {
+	if s.cVar != "" {
+		checker := s.qtAlias + "." + name
+		if len(opts) > 0 {
+			checker += "(" + strings.Join(opts, ", ") + ")"
+		}
+		return got + ", " + checker + ", " + want
+	}
+	return s.qtAlias + "." + name + "(" + strings.Join(append([]string{got, want}, opts...), ", ") + ")"
+}
+
+// assertionSpellingAt picks the API a synthesized assertion at start uses.
+//
+// A visible *qt.C wins, because it is what the existing rules have always
+// written. Failing that, a visible go-quicktest/qt import is used with the
+// handle the original call went through, provided that handle is something
+// qt.Assert accepts: a method promoted from the testing package says only that
+// the receiver embeds a test, not that it is one.
+func assertionSpellingAt(
+	pass *analysis.Pass,
+	start ast.Node,
+	selection *types.Selection,
+	handle ast.Expr,
+	handleText string,
+) (assertionSpelling, bool) {
+	qtAlias := findQuicktestPkgAlias(pass, start)
+	cVar := findQuicktestCVarName(pass, start)
+	if qtAlias != "" && cVar != "" {
+		return assertionSpelling{qtAlias: qtAlias, cVar: cVar}, true
+	}
+
+	v2Alias := findPkgAlias(pass, start, quicktestV2PkgPath)
+	if v2Alias == "" || !isTestingTB(selection, pass.TypesInfo.TypeOf(handle)) {
+		return assertionSpelling{}, false
+	}
+	return assertionSpelling{qtAlias: v2Alias, handle: handleText}, true
+}
+
+// isTestingTB reports whether typ can be passed where the testing package's TB
+// is expected. The interface is looked up through the package the selected
+// method came from, so no import of testing is needed at the call site.
+func isTestingTB(selection *types.Selection, typ types.Type) bool {
+	if typ == nil || selection.Obj() == nil || selection.Obj().Pkg() == nil {
+		return false
+	}
+	tb := selection.Obj().Pkg().Scope().Lookup("TB")
+	if tb == nil {
+		return false
+	}
+	return types.AssignableTo(typ, tb.Type())
+}
+
+// failureBlockFix describes a single-statement rewrite for the
+// `if cond { t.Fatal[f]/t.Error[f](...) }` pattern.
+type failureBlockFix struct {
 	// text is the replacement source for the entire ifStmt span. It is a
 	// single line of code (no indentation prefix, no trailing newline);
 	// the surrounding whitespace in the file is preserved by the TextEdit.
@@ -1432,30 +1948,33 @@ type errNilFatalFix struct {
 	stable bool
 }
 
-// buildErrNilFatalFix returns a rewrite for the if-err-fatal pattern, or
-// ok=false when we cannot safely produce one (init-stmt scoping or spread args).
-func buildErrNilFatalFix(
+// buildFailureBlockFix returns a rewrite for the failure-block pattern, or
+// ok=false when we cannot safely produce one (init-stmt scoping or spread
+// args). assertion is the rendered checker and its operands, and gotText the
+// operand a lone failure argument may merely repeat.
+func buildFailureBlockFix(
 	pass *analysis.Pass,
 	ifStmt *ast.IfStmt,
-	m errNilFatalMatch,
-	cVar, qtAlias, errText string,
-) (errNilFatalFix, bool) {
+	m failureBlockMatch,
+	spelling assertionSpelling,
+	assertion string,
+	gotText string,
+) (failureBlockFix, bool) {
 	// `if err := f(); err != nil { ... }` would require pulling the init
 	// statement out, which changes scoping (err leaks into the enclosing
 	// block). Refuse the fix and let the diagnostic stand on its own.
 	if ifStmt.Init != nil {
-		return errNilFatalFix{}, false
+		return failureBlockFix{}, false
 	}
 	// `t.Fatal(args...)` (spread): we cannot enumerate the underlying
 	// arguments at lint time, so any rewrite would silently drop them.
 	if m.call.Ellipsis != token.NoPos {
-		return errNilFatalFix{}, false
+		return failureBlockFix{}, false
 	}
 
-	bare := fmt.Sprintf("%s.%s(%s, %s.IsNil)", cVar, m.qtMethod, errText, qtAlias)
+	bare := spelling.call(m.qtMethod, assertion)
 	withComment := func(commentArgs string) string {
-		return fmt.Sprintf("%s.%s(%s, %s.IsNil, %s.Commentf(%s))",
-			cVar, m.qtMethod, errText, qtAlias, qtAlias, commentArgs)
+		return spelling.call(m.qtMethod, assertion+", "+spelling.qtAlias+".Commentf("+commentArgs+")")
 	}
 
 	isFmtVariant := m.methodName == "Fatalf" || m.methodName == "Errorf"
@@ -1463,16 +1982,16 @@ func buildErrNilFatalFix(
 	if isFmtVariant {
 		// Fatalf/Errorf require a format argument; defensively refuse if absent.
 		if len(m.call.Args) == 0 {
-			return errNilFatalFix{}, false
+			return failureBlockFix{}, false
 		}
 		argTexts, ok := formatArgs(pass, m.call.Args)
 		if !ok {
-			return errNilFatalFix{}, false
+			return failureBlockFix{}, false
 		}
 		_, formatIsLiteral := m.call.Args[0].(*ast.BasicLit)
-		return errNilFatalFix{
+		return failureBlockFix{
 			text:    withComment(strings.Join(argTexts, ", ")),
-			message: fmt.Sprintf("Replace with %s.%s(..., qt.Commentf(...))", cVar, m.qtMethod),
+			message: "Replace with " + spelling.call(m.qtMethod, "..., qt.Commentf(...)"),
 			// A literal format string round-trips through fmt.Sprintf inside
 			// Commentf identically. A non-literal could be anything (a const
 			// alias, a function call), so we mark the rewrite unstable.
@@ -1482,25 +2001,26 @@ func buildErrNilFatalFix(
 
 	// Non-formatted Fatal/Error: zero args is a clean rewrite to bare assert.
 	if len(m.call.Args) == 0 {
-		return errNilFatalFix{
+		return failureBlockFix{
 			text:    bare,
-			message: fmt.Sprintf("Replace with %s.%s(%s, %s.IsNil)", cVar, m.qtMethod, errText, qtAlias),
+			message: "Replace with " + bare,
 			stable:  true,
 		}, true
 	}
 
-	// Single arg: when it's the same err identifier, drop it entirely (clean).
+	// Single arg: when it's the got operand again, as err is in t.Fatal(err),
+	// drop it entirely (clean): the checker prints got when it fails.
 	// Otherwise wrap it via qt.Commentf("%v", arg) — semantically close to
 	// t.Fatal's Sprintln output but loses the trailing newline, so unstable.
 	if len(m.call.Args) == 1 {
 		argText, ok := formatExpr(pass, m.call.Args[0])
 		if !ok {
-			return errNilFatalFix{}, false
+			return failureBlockFix{}, false
 		}
-		if argText == errText {
-			return errNilFatalFix{
+		if argText == gotText {
+			return failureBlockFix{
 				text:    bare,
-				message: fmt.Sprintf("Replace with %s.%s(%s, %s.IsNil)", cVar, m.qtMethod, errText, qtAlias),
+				message: "Replace with " + bare,
 				stable:  true,
 			}, true
 		}
@@ -1509,9 +2029,9 @@ func buildErrNilFatalFix(
 		// trailing newline that Sprintln would have produced. The format is
 		// fixed ("%v") so user-supplied "%" characters in arg pass through
 		// verbatim — there is no format-string injection risk here.
-		return errNilFatalFix{
+		return failureBlockFix{
 			text:    withComment(`"%v", ` + argText),
-			message: fmt.Sprintf("Replace with %s.%s(..., qt.Commentf(\"%%v\", ...))", cVar, m.qtMethod),
+			message: "Replace with " + spelling.call(m.qtMethod, `..., qt.Commentf("%v", ...)`),
 			stable:  false,
 		}, true
 	}
@@ -1529,13 +2049,13 @@ func buildErrNilFatalFix(
 	// nearly but not exactly identical to the original t.Fatal output.
 	argTexts, ok := formatArgs(pass, m.call.Args)
 	if !ok {
-		return errNilFatalFix{}, false
+		return failureBlockFix{}, false
 	}
 	placeholders := strings.TrimRight(strings.Repeat("%v ", len(m.call.Args)), " ")
 	commentArgs := strconv.Quote(placeholders) + ", " + strings.Join(argTexts, ", ")
-	return errNilFatalFix{
+	return failureBlockFix{
 		text:    withComment(commentArgs),
-		message: fmt.Sprintf("Replace with %s.%s(..., qt.Commentf(\"%%v ...\", ...))", cVar, m.qtMethod),
+		message: "Replace with " + spelling.call(m.qtMethod, `..., qt.Commentf("%v ...", ...)`),
 		stable:  false,
 	}, true
 }
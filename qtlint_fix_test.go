@@ -19,8 +19,20 @@ func TestFixes(t *testing.T) {
 	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "errorisfix")
 	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "aliaserrorsfix")
 	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "equalsnilfix")
+	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "qtv2fix")
+	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "equalsboolfix")
+	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "doublenotfix")
+	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "notisnotnilfix")
+	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "cmpequalsfix")
+	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "deepequalsbasicfix")
+	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "errorisnilfix")
+	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "commentf")
+	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "goroutineassert")
+	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "deferdone")
+	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "patchargs")
+	analysistest.RunWithSuggestedFixes(t, testdata, qtlint.Analyzer, "subtestqtnew")
 
-	// Default behavior: stable AND unstable errnil-fatal fixes apply.
+	// Default behavior: stable AND unstable failure-block fixes apply.
 	t.Run("errcheckfix default applies all", func(t *testing.T) {
 		analyzer := qtlint.NewAnalyzer()
 		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "errcheckfix")
@@ -35,6 +47,137 @@ func TestFixes(t *testing.T) {
 		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "errcheckonlystable")
 	})
 
+	t.Run("equalsdeepfix", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "equalsdeepfix")
+	})
+
+	// Pointers compared by address may be meant that way, so that fix is
+	// withheld; a non-comparable operand's is not.
+	t.Run("equalsdeep only-stable-fixes", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "only-stable-fixes")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "equalsdeeponlystable")
+	})
+
+	t.Run("quote-literal-patterns", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "quote-literal-patterns")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "quoteliteralpatterns")
+	})
+
+	t.Run("panicmatches", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "panicmatches")
+	})
+
+	// A panic value compared as it is, rather than as text, may be an error
+	// whose text qt.PanicMatches matches, so --only-stable-fixes withholds
+	// that fix.
+	t.Run("panicmatches only-stable-fixes", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "only-stable-fixes")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "panicmatchesonlystable")
+	})
+
+	t.Run("allany", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "allany")
+	})
+
+	// Check fails for every element where qt.All stops at the first, and a
+	// want that calls a function runs once instead of per element, so
+	// --only-stable-fixes withholds both fixes.
+	t.Run("allany only-stable-fixes", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "only-stable-fixes")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "allanyonlystable")
+	})
+
+	t.Run("goroutineassert only-stable-fixes", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "only-stable-fixes")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "goroutineassertonlystable")
+	})
+
+	t.Run("swapped-got-want", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "swapped-got-want")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "swappedgotwant")
+	})
+
+	t.Run("modernize-lifecycle", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "modernize-lifecycle")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "modernizelifecycle")
+	})
+
+	t.Run("unused-checker", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "unused-checker")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "unusedchecker")
+	})
+
+	t.Run("prefer-json-equals", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "prefer-json-equals")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "jsonequals")
+	})
+
+	// A value decoded into a struct has lost the fields the struct does not
+	// name, so --only-stable-fixes withholds that fix.
+	t.Run("prefer-json-equals only-stable-fixes", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "prefer-json-equals")
+		setFlag(t, analyzer, "only-stable-fixes")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "jsonequalsonlystable")
+	})
+
+	t.Run("errorstringfix", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "errorstringfix")
+	})
+
+	t.Run("helperpredicatesfix", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "helperpredicatesfix")
+	})
+
+	// slices.Equal calls a nil slice equal to an empty one, which
+	// qt.DeepEquals does not, so --only-stable-fixes withholds its fix;
+	// reflect.DeepEqual's stays.
+	t.Run("helperpredicates only-stable-fixes", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "only-stable-fixes")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "helperpredicatesonlystable")
+	})
+
+	t.Run("failureblockfix", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "failureblockfix")
+	})
+
+	// A checker that only approximates its condition is best-effort the way a
+	// synthesized qt.Commentf is.
+	t.Run("failureblock only-stable-fixes", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "only-stable-fixes")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "failureblockonlystable")
+	})
+
+	t.Run("cmpdifffix", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "cmpdifffix")
+	})
+
+	// The block whose operands both call something keeps its diagnostic and
+	// loses its fix.
+	t.Run("cmpdiff only-stable-fixes", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "only-stable-fixes")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "cmpdiffonlystable")
+	})
+
 	t.Run("qtcreceiverfix", func(t *testing.T) {
 		analyzer := qtlint.NewAnalyzer()
 		setFlag(t, analyzer, "require-qt-c-receiver")
@@ -108,9 +251,63 @@ func TestFixes(t *testing.T) {
 		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "bothrulesfix")
 	})
 
+	// The whole file moves to go-quicktest/qt, and every assertion's fix is
+	// that move.
+	t.Run("migrateqtv2fix", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "migrate-to-qt-v2")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "migrateqtv2fix")
+	})
+
+	// qt.CodecEquals has no exact counterpart, and the file moves as a unit, so
+	// --only-stable-fixes leaves the whole file where it is.
+	t.Run("migrateqtv2 only-stable-fixes", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "migrate-to-qt-v2")
+		setFlag(t, analyzer, "only-stable-fixes")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "migrateqtv2onlystable")
+	})
+
+	t.Run("migratetestifyfix", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "migrate-from-testify")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "migratetestifyfix")
+	})
+
+	// A withheld fix leaves a use of its testify import behind, so the import
+	// stays, and the stable calls beside it move one at a time.
+	t.Run("migratetestify only-stable-fixes", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "migrate-from-testify")
+		setFlag(t, analyzer, "only-stable-fixes")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "migratetestifyonlystable")
+	})
+
+	t.Run("migrategocheckfix", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "migrate-from-gocheck")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "migrategocheckfix")
+	})
+
+	// Each test moves on its own, so the one stable test still does.
+	t.Run("migrategocheck only-stable-fixes", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "migrate-from-gocheck")
+		setFlag(t, analyzer, "only-stable-fixes")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "migrategocheckonlystable")
+	})
+
 	t.Run("subtestchecker", func(t *testing.T) {
 		analyzer := qtlint.NewAnalyzer()
 		setFlag(t, analyzer, "require-subtest-checker")
 		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "subtestcheckerfix")
 	})
+
+	// The go-quicktest/qt spelling: a subtest asserting through the handle of
+	// the test around it is repaired by naming its own.
+	t.Run("subtestchecker go-quicktest/qt", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "require-subtest-checker")
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "qtv2subtest")
+	})
 }
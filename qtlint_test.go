@@ -61,6 +61,57 @@ func TestAnalyzer(t *testing.T) {
 		analysistest.Run(t, testdata, analyzer, "errcheck")
 	})
 
+	t.Run("qt.Equals on non-comparable and pointer operands", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.Run(t, testdata, analyzer, "equalsdeep")
+	})
+
+	t.Run("checkers that cannot check their operands", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.Run(t, testdata, analyzer, "impossiblechecker")
+	})
+
+	t.Run("checker argument counts", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.Run(t, testdata, analyzer, "checkerargs")
+	})
+
+	t.Run("checker argument counts with strict-checker-args", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "strict-checker-args")
+		analysistest.Run(t, testdata, analyzer, "checkerargsstrict")
+	})
+
+	t.Run("process-wide changes in a parallel test", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.Run(t, testdata, analyzer, "parallelstate")
+	})
+
+	t.Run("regexp patterns that do not compile", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.Run(t, testdata, analyzer, "patterns")
+	})
+
+	t.Run("err.Error() compared as a string", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.Run(t, testdata, analyzer, "errorstring")
+	})
+
+	t.Run("helper predicates under qt.IsTrue", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.Run(t, testdata, analyzer, "helperpredicates")
+	})
+
+	t.Run("failure blocks with t.Fatal/t.Error", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.Run(t, testdata, analyzer, "failureblock")
+	})
+
+	t.Run("cmp.Diff with t.Fatal/t.Error", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.Run(t, testdata, analyzer, "cmpdiff")
+	})
+
 	t.Run("strings.Contains and slices.Contains patterns", func(t *testing.T) {
 		analyzer := qtlint.NewAnalyzer()
 		analysistest.Run(t, testdata, analyzer, "strcontains")
@@ -86,6 +137,39 @@ func TestAnalyzer(t *testing.T) {
 		analysistest.Run(t, testdata, analyzer, "equalsnil")
 	})
 
+	// The same default rules against go-quicktest/qt, whose checkers are calls
+	// that own their arguments.
+	t.Run("go-quicktest/qt patterns", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		analysistest.Run(t, testdata, analyzer, "qtv2")
+	})
+
+	t.Run("data rows with go-quicktest/qt", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "require-data-rows")
+		analysistest.Run(t, testdata, analyzer, "datarowsv2")
+	})
+
+	// Files the migration cannot move as a whole: each keeps its diagnostics
+	// and every one of them says why there is no fix.
+	t.Run("migrate-to-qt-v2 patterns", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "migrate-to-qt-v2")
+		analysistest.Run(t, testdata, analyzer, "migrateqtv2")
+	})
+
+	t.Run("migrate-from-testify patterns", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "migrate-from-testify")
+		analysistest.Run(t, testdata, analyzer, "migratetestify")
+	})
+
+	t.Run("migrate-from-gocheck patterns", func(t *testing.T) {
+		analyzer := qtlint.NewAnalyzer()
+		setFlag(t, analyzer, "migrate-from-gocheck")
+		analysistest.Run(t, testdata, analyzer, "migrategocheck")
+	})
+
 	t.Run("require-qt-c-receiver patterns", func(t *testing.T) {
 		analyzer := qtlint.NewAnalyzer()
 		setFlag(t, analyzer, "require-qt-c-receiver")
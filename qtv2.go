@@ -0,0 +1,476 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// v2Checker is a call to one of go-quicktest/qt's checker constructors, such
+// as qt.Equals(got, want).
+//
+// It is the go-quicktest/qt counterpart of getCheckerArg. In frankban/quicktest
+// the checker is a value and its arguments sit beside it in the assertion, so
+// a rule asks the assertion for the argument at an index; in go-quicktest/qt
+// the checker is a call that owns its arguments, so a rule asks the checker.
+type v2Checker struct {
+	// call is the constructor call and sel its "qt.Equals" selector.
+	call *ast.CallExpr
+	sel  *ast.SelectorExpr
+	// name is the constructor's name and qtAlias the qualifier it is written
+	// with, which is what a rewrite writes back.
+	name    string
+	qtAlias string
+}
+
+// matchV2Checker parses expr into a v2Checker. A constructor given explicit
+// type arguments is not matched: a rewrite would have to decide what the type
+// arguments of the checker it writes are, and inference is what decides them
+// everywhere else.
+func matchV2Checker(pass *analysis.Pass, expr ast.Expr) (v2Checker, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return v2Checker{}, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !isQualifiedBy(pass, sel, quicktestV2PkgPath) {
+		return v2Checker{}, false
+	}
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return v2Checker{}, false
+	}
+	return v2Checker{call: call, sel: sel, name: sel.Sel.Name, qtAlias: pkgIdent.Name}, true
+}
+
+// isV2Assertion checks if a call is to go-quicktest/qt's Assert or Check.
+func isV2Assertion(pass *analysis.Pass, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	if sel.Sel.Name != "Assert" && sel.Sel.Name != "Check" {
+		return false
+	}
+	return isQualifiedBy(pass, sel, quicktestV2PkgPath)
+}
+
+// getV2Checker extracts the checker of a go-quicktest/qt assertion:
+// qt.Assert(t, checker, comments...).
+func getV2Checker(pass *analysis.Pass, call *ast.CallExpr) (v2Checker, bool) {
+	if !isV2Assertion(pass, call) || len(call.Args) < 2 {
+		return v2Checker{}, false
+	}
+	return matchV2Checker(pass, call.Args[1])
+}
+
+// unwrapV2Not returns the checker qt.Not wraps, and whether it wrapped one.
+// A checker that is not a negation is returned as it is.
+func unwrapV2Not(pass *analysis.Pass, checker v2Checker) (v2Checker, bool) {
+	if checker.name != "Not" || len(checker.call.Args) != 1 {
+		return checker, false
+	}
+	inner, ok := matchV2Checker(pass, checker.call.Args[0])
+	if !ok {
+		return checker, false
+	}
+	return inner, true
+}
+
+// checkQuicktestV2Call runs the default rules against a go-quicktest/qt
+// assertion. Each is the counterpart of a frankban/quicktest rule in
+// checkQuicktestCall, reporting the same defect in the spelling this API uses.
+func checkQuicktestV2Call(pass *analysis.Pass, call *ast.CallExpr) {
+	checker, ok := getV2Checker(pass, call)
+	if !ok {
+		return
+	}
+
+	checkV2NotPattern(pass, checker)
+	checkV2ImpossibleChecker(pass, checker)
+	checkV2LenEqualsPattern(pass, checker)
+	if checkV2NilComparisonPattern(pass, checker) {
+		return
+	}
+	checkV2EqualityComparisonPattern(pass, checker)
+	checkV2ContainsPattern(pass, checker)
+	checkV2ErrorIsAsPattern(pass, checker)
+	checkV2ErrorStringPattern(pass, checker)
+	checkV2EqualsNilPattern(pass, checker)
+	checkV2EqualsBoolPattern(pass, checker)
+	checkV2DoubleNotPattern(pass, checker)
+	checkV2CmpEqualsNoOptsPattern(pass, checker)
+	checkV2DeepEqualsBasicPattern(pass, checker)
+	checkV2ErrorIsNilPattern(pass, checker)
+}
+
+// reportV2Rewrite reports a checker that has a more direct spelling and, when
+// newText is not empty, suggests it as the checker's replacement.
+func reportV2Rewrite(pass *analysis.Pass, node ast.Node, message, fixMessage, newText string) {
+	diag := analysis.Diagnostic{
+		Pos:     node.Pos(),
+		End:     node.End(),
+		Message: message,
+	}
+	if newText != "" {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: fixMessage,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     node.Pos(),
+				End:     node.End(),
+				NewText: []byte(newText),
+			}},
+		}}
+	}
+	pass.Report(diag)
+}
+
+// v2Call renders a call to the named go-quicktest/qt checker.
+func v2Call(qtAlias, name string, args ...string) string {
+	return qtAlias + "." + name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// v2Negate renders text wrapped in qt.Not when negate is set.
+func v2Negate(qtAlias, text string, negate bool) string {
+	if !negate {
+		return text
+	}
+	return v2Call(qtAlias, "Not", text)
+}
+
+// checkV2NotPattern checks if the checker is qt.Not(qt.IsNil(x)),
+// qt.Not(qt.IsNotNil(x)), qt.Not(qt.IsTrue(x)) or qt.Not(qt.IsFalse(x)) and
+// suggests the checker that
+// says the same thing directly.
+func checkV2NotPattern(pass *analysis.Pass, checker v2Checker) {
+	inner, negated := unwrapV2Not(pass, checker)
+	if !negated {
+		return
+	}
+	replacement, ok := replacements[inner.name]
+	if !ok || len(inner.call.Args) != 1 {
+		return
+	}
+	argText, ok := formatExpr(pass, inner.call.Args[0])
+	if !ok {
+		return
+	}
+
+	reportV2Rewrite(pass, checker.call,
+		fmt.Sprintf("qtlint: use qt.%s(x) instead of qt.Not(qt.%s(x))", replacement, inner.name),
+		fmt.Sprintf("Replace with qt.%s", replacement),
+		v2Call(inner.qtAlias, replacement, argText))
+}
+
+// checkV2LenEqualsPattern checks if the checker is qt.Equals(len(x), n) or
+// qt.Not(qt.Equals(len(x), n)) and suggests qt.HasLen(x, n) or its negation.
+func checkV2LenEqualsPattern(pass *analysis.Pass, checker v2Checker) {
+	inner, negated := unwrapV2Not(pass, checker)
+	if inner.name != "Equals" || len(inner.call.Args) != 2 {
+		return
+	}
+	lenArg, ok := extractBuiltinLenArg(pass, inner.call.Args[0])
+	if !ok {
+		return
+	}
+	lenText, ok := formatExpr(pass, lenArg)
+	if !ok {
+		return
+	}
+	nText, ok := formatExpr(pass, inner.call.Args[1])
+	if !ok {
+		return
+	}
+
+	message := "qtlint: use qt.HasLen(x, n) instead of qt.Equals(len(x), n)"
+	fixMessage := "Replace with qt.HasLen"
+	if negated {
+		message = "qtlint: use qt.Not(qt.HasLen(x, n)) instead of qt.Not(qt.Equals(len(x), n))"
+		fixMessage = "Replace with qt.Not(qt.HasLen)"
+	}
+	reportV2Rewrite(pass, checker.call, message, fixMessage,
+		v2Negate(checker.qtAlias, v2Call(inner.qtAlias, "HasLen", lenText, nText), negated))
+}
+
+// matchV2BoolChecker returns the sole argument of a qt.IsTrue or qt.IsFalse
+// checker, and whether the checker was qt.IsTrue.
+func matchV2BoolChecker(checker v2Checker) (ast.Expr, bool, bool) {
+	if checker.name != "IsTrue" && checker.name != "IsFalse" {
+		return nil, false, false
+	}
+	if len(checker.call.Args) != 1 {
+		return nil, false, false
+	}
+	return checker.call.Args[0], checker.name == "IsTrue", true
+}
+
+// checkV2NilComparisonPattern checks if the checker is qt.IsTrue(x == nil) or
+// one of its three siblings and suggests qt.IsNil(x) or qt.IsNotNil(x).
+// Returns true if the pattern was matched (to skip further checks).
+func checkV2NilComparisonPattern(pass *analysis.Pass, checker v2Checker) bool {
+	arg, isTrue, ok := matchV2BoolChecker(checker)
+	if !ok {
+		return false
+	}
+	binExpr, ok := arg.(*ast.BinaryExpr)
+	if !ok || (binExpr.Op != token.EQL && binExpr.Op != token.NEQ) {
+		return false
+	}
+
+	var nonNilExpr ast.Expr
+	switch {
+	case isNilIdent(binExpr.Y):
+		nonNilExpr = binExpr.X
+	case isNilIdent(binExpr.X):
+		nonNilExpr = binExpr.Y
+	default:
+		return false
+	}
+	text, ok := formatExpr(pass, nonNilExpr)
+	if !ok {
+		return false
+	}
+
+	replacement := "IsNotNil"
+	if (binExpr.Op == token.EQL) == isTrue {
+		replacement = "IsNil"
+	}
+
+	reportV2Rewrite(pass, checker.call,
+		fmt.Sprintf("qtlint: use qt.%s(x) instead of qt.%s(x %s nil)", replacement, checker.name, binExpr.Op),
+		fmt.Sprintf("Replace with qt.%s", replacement),
+		v2Call(checker.qtAlias, replacement, text))
+	return true
+}
+
+// checkV2EqualityComparisonPattern checks if the checker is qt.IsTrue(x == y)
+// or one of its three siblings and suggests qt.Equals(x, y) or its negation.
+//
+// qt.Equals takes both operands as one type parameter, so the rewrite compiles
+// only where the comparison's operands have one type. A comparison between an
+// interface and a concrete type is legal Go and fails inference as a call, and
+// it is reported without a fix.
+func checkV2EqualityComparisonPattern(pass *analysis.Pass, checker v2Checker) {
+	arg, isTrue, ok := matchV2BoolChecker(checker)
+	if !ok {
+		return
+	}
+	binExpr, ok := arg.(*ast.BinaryExpr)
+	if !ok || (binExpr.Op != token.EQL && binExpr.Op != token.NEQ) {
+		return
+	}
+	lhs, ok := formatExpr(pass, binExpr.X)
+	if !ok {
+		return
+	}
+	rhs, ok := formatExpr(pass, binExpr.Y)
+	if !ok {
+		return
+	}
+
+	negate := (binExpr.Op == token.EQL) != isTrue
+	message := fmt.Sprintf("qtlint: use qt.Equals(x, y) instead of qt.%s(x %s y)", checker.name, binExpr.Op)
+	fixMessage := "Replace with qt.Equals"
+	if negate {
+		message = fmt.Sprintf("qtlint: use qt.Not(qt.Equals(x, y)) instead of qt.%s(x %s y)", checker.name, binExpr.Op)
+		fixMessage = "Replace with qt.Not(qt.Equals)"
+	}
+
+	newText := v2Negate(checker.qtAlias, v2Call(checker.qtAlias, "Equals", lhs, rhs), negate)
+	if !sameOperandTypes(pass, binExpr.X, binExpr.Y) {
+		message += "; no fix: the operands have different types, which qt.Equals cannot infer one type parameter from"
+		newText = ""
+	}
+	reportV2Rewrite(pass, checker.call, message, fixMessage, newText)
+}
+
+// sameOperandTypes reports whether x and y have identical types. An untyped
+// constant operand has already been given the other side's type by the
+// comparison, so 3 in n == 3 reads as an int.
+func sameOperandTypes(pass *analysis.Pass, x, y ast.Expr) bool {
+	xt, yt := pass.TypesInfo.TypeOf(x), pass.TypesInfo.TypeOf(y)
+	return xt != nil && yt != nil && types.Identical(xt, yt)
+}
+
+// v2ContainsCheckers maps the package whose Contains a qt.IsTrue wraps to the
+// go-quicktest/qt checker that says the same thing. The API has no single
+// Contains: strings and slices each have their own.
+var v2ContainsCheckers = map[string]string{
+	"strings": "StringContains",
+	"slices":  "SliceContains",
+}
+
+// checkV2ContainsPattern checks if the checker is
+// qt.IsTrue(strings.Contains(x, y)) or qt.IsTrue(slices.Contains(x, y)), or
+// the qt.IsFalse of either, and suggests qt.StringContains or qt.SliceContains
+// (or their negation).
+func checkV2ContainsPattern(pass *analysis.Pass, checker v2Checker) {
+	arg, isTrue, ok := matchV2BoolChecker(checker)
+	if !ok {
+		return
+	}
+	fnCall, ok := arg.(*ast.CallExpr)
+	if !ok || len(fnCall.Args) != 2 {
+		return
+	}
+	fnSel, ok := fnCall.Fun.(*ast.SelectorExpr)
+	if !ok || fnSel.Sel.Name != "Contains" {
+		return
+	}
+	pkgIdent, pkgPath, ok := qualifyingPackage(pass, fnSel)
+	if !ok {
+		return
+	}
+	replacement, ok := v2ContainsCheckers[pkgPath]
+	if !ok {
+		return
+	}
+	argTexts, ok := formatArgs(pass, fnCall.Args)
+	if !ok {
+		return
+	}
+
+	message := fmt.Sprintf("qtlint: use qt.%s(x, y) instead of qt.IsTrue(%s.Contains(x, y))", replacement, pkgIdent.Name)
+	fixMessage := fmt.Sprintf("Replace with qt.%s", replacement)
+	if !isTrue {
+		message = fmt.Sprintf("qtlint: use qt.Not(qt.%s(x, y)) instead of qt.IsFalse(%s.Contains(x, y))",
+			replacement, pkgIdent.Name)
+		fixMessage = fmt.Sprintf("Replace with qt.Not(qt.%s)", replacement)
+	}
+	reportV2Rewrite(pass, checker.call, message, fixMessage,
+		v2Negate(checker.qtAlias, v2Call(checker.qtAlias, replacement, argTexts...), !isTrue))
+}
+
+// checkV2ErrorIsAsPattern checks if the checker is
+// qt.IsTrue(errors.Is(err, target)) or qt.IsTrue(errors.As(err, &target)), or
+// the qt.IsFalse of either, and suggests qt.ErrorIs / qt.ErrorAs (or their
+// negation).
+//
+// qt.ErrorAs takes its target as a *T where errors.As takes any, so a target
+// that is not a pointer is reported without a fix.
+func checkV2ErrorIsAsPattern(pass *analysis.Pass, checker v2Checker) {
+	arg, isTrue, ok := matchV2BoolChecker(checker)
+	if !ok {
+		return
+	}
+	fnCall, ok := arg.(*ast.CallExpr)
+	if !ok || len(fnCall.Args) != 2 {
+		return
+	}
+	fnSel, ok := fnCall.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	var replacement string
+	switch fnSel.Sel.Name {
+	case "Is":
+		replacement = "ErrorIs"
+	case "As":
+		replacement = "ErrorAs"
+	default:
+		return
+	}
+	pkgIdent, pkgPath, ok := qualifyingPackage(pass, fnSel)
+	if !ok || pkgPath != "errors" {
+		return
+	}
+	argTexts, ok := formatArgs(pass, fnCall.Args)
+	if !ok {
+		return
+	}
+
+	message := fmt.Sprintf("qtlint: use qt.%s(err, target) instead of qt.IsTrue(%s.%s(err, target))",
+		replacement, pkgIdent.Name, fnSel.Sel.Name)
+	fixMessage := fmt.Sprintf("Replace with qt.%s", replacement)
+	if !isTrue {
+		message = fmt.Sprintf("qtlint: use qt.Not(qt.%s(err, target)) instead of qt.IsFalse(%s.%s(err, target))",
+			replacement, pkgIdent.Name, fnSel.Sel.Name)
+		fixMessage = fmt.Sprintf("Replace with qt.Not(qt.%s)", replacement)
+	}
+
+	newText := v2Negate(checker.qtAlias, v2Call(checker.qtAlias, replacement, argTexts...), !isTrue)
+	if replacement == "ErrorAs" {
+		if _, isPtr := types.Unalias(pass.TypesInfo.TypeOf(fnCall.Args[1])).(*types.Pointer); !isPtr {
+			message += "; no fix: qt.ErrorAs takes its target as a pointer"
+			newText = ""
+		}
+	}
+	reportV2Rewrite(pass, checker.call, message, fixMessage, newText)
+}
+
+// checkV2ErrorStringPattern is the go-quicktest/qt counterpart of
+// checkErrorStringPattern: qt.Equals(err.Error(), want),
+// qt.StringContains(err.Error(), sub) and qt.Matches(err.Error(), re) become
+// qt.ErrorMatches(err, pattern).
+func checkV2ErrorStringPattern(pass *analysis.Pass, checker v2Checker) {
+	inner, not := unwrapV2Not(pass, checker)
+	form, ok := errorStringCheckers[inner.name]
+	if !ok || inner.name == "Contains" || len(inner.call.Args) != 2 {
+		return
+	}
+	errExpr, ok := matchErrorString(pass, inner.call.Args[0])
+	if !ok {
+		return
+	}
+
+	old, replacement := "qt."+inner.name+"(err.Error(), want)", "qt.ErrorMatches(err, pattern)"
+	if not {
+		old, replacement = "qt.Not("+old+")", "qt.Not("+replacement+")"
+	}
+	diag := analysis.Diagnostic{
+		Pos:     inner.call.Pos(),
+		End:     inner.call.End(),
+		Message: fmt.Sprintf("qtlint: use %s instead of %s", replacement, old),
+	}
+	errText, ok := formatExpr(pass, errExpr)
+	if !ok {
+		pass.Report(diag)
+		return
+	}
+	if pattern, edits, ok := quotedPattern(pass, form, inner.call.Args[1]); ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Replace with qt.ErrorMatches",
+			TextEdits: append(edits, analysis.TextEdit{
+				Pos:     inner.call.Pos(),
+				End:     inner.call.End(),
+				NewText: []byte(v2Call(inner.qtAlias, "ErrorMatches", errText, pattern)),
+			}),
+		}}
+	}
+	pass.Report(diag)
+}
+
+// checkV2EqualsNilPattern checks if the checker is qt.Equals(x, nil) and
+// suggests qt.IsNil(x), for the reason checkEqualsNilPattern gives: a typed nil
+// never equals the untyped nil.
+func checkV2EqualsNilPattern(pass *analysis.Pass, checker v2Checker) {
+	if checker.name != "Equals" || len(checker.call.Args) != 2 || !isNilIdent(checker.call.Args[1]) {
+		return
+	}
+	text, ok := formatExpr(pass, checker.call.Args[0])
+	if !ok {
+		return
+	}
+	reportV2Rewrite(pass, checker.call,
+		"qtlint: use qt.IsNil(x) instead of qt.Equals(x, nil)",
+		"Replace with qt.IsNil",
+		v2Call(checker.qtAlias, "IsNil", text))
+}
+
+// qualifyingPackage resolves the package a selector like strings.Contains is
+// qualified by, through the type checker so that an aliased import is matched.
+func qualifyingPackage(pass *analysis.Pass, sel *ast.SelectorExpr) (*ast.Ident, string, bool) {
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil, "", false
+	}
+	pkgName, ok := pass.TypesInfo.Uses[pkgIdent].(*types.PkgName)
+	if !ok {
+		return nil, "", false
+	}
+	return pkgIdent, pkgName.Imported().Path(), true
+}
@@ -53,9 +53,10 @@ type assertionField struct {
 // grows silently: every row is locally reasonable, and only the count shows it.
 func (*analyzer) checkRequireDataRows(pass *analysis.Pass) {
 	for _, file := range pass.Files {
+		v2 := importedPkgName(pass, file, quicktestV2PkgPath) != ""
 		for _, root := range outermostFuncs(file) {
 			for _, spec := range structTypesIn(root) {
-				for _, field := range assertionFieldsOf(pass, spec) {
+				for _, field := range assertionFieldsOf(pass, spec, v2) {
 					collectRowBodies(pass, root, spec, field)
 					reportDataRowField(pass, field)
 				}
@@ -77,8 +78,9 @@ func structTypesIn(root ast.Node) []*ast.StructType {
 }
 
 // assertionFieldsOf returns the fields of spec whose type is a function taking
-// a test handle.
-func assertionFieldsOf(pass *analysis.Pass, spec *ast.StructType) []*assertionField {
+// a test handle. v2 says the file imports go-quicktest/qt; see
+// isTestHandleType.
+func assertionFieldsOf(pass *analysis.Pass, spec *ast.StructType, v2 bool) []*assertionField {
 	if spec.Fields == nil {
 		return nil
 	}
@@ -89,7 +91,7 @@ func assertionFieldsOf(pass *analysis.Pass, spec *ast.StructType) []*assertionFi
 		if !ok || len(field.Names) != 1 || sig.Params == nil {
 			continue
 		}
-		param, index, ok := handleParam(pass, sig)
+		param, index, ok := handleParam(pass, sig, v2)
 		if !ok {
 			continue
 		}
@@ -104,7 +106,7 @@ func assertionFieldsOf(pass *analysis.Pass, spec *ast.StructType) []*assertionFi
 }
 
 // handleParam finds the sole *qt.C or testing.TB parameter of a signature.
-func handleParam(pass *analysis.Pass, sig *ast.FuncType) (*ast.Field, int, bool) {
+func handleParam(pass *analysis.Pass, sig *ast.FuncType, v2 bool) (*ast.Field, int, bool) {
 	index := 0
 	for _, field := range sig.Params.List {
 		width := len(field.Names)
@@ -112,7 +114,7 @@ func handleParam(pass *analysis.Pass, sig *ast.FuncType) (*ast.Field, int, bool)
 			width = 1
 		}
 		typ := pass.TypesInfo.TypeOf(field.Type)
-		if isTestHandleType(typ) {
+		if isTestHandleType(typ, v2) {
 			if width != 1 {
 				return nil, 0, false
 			}
@@ -195,7 +197,8 @@ func reportDataRowField(pass *analysis.Pass, field *assertionField) {
 	})
 }
 
-// isTestHandleType reports whether typ is a *qt.C.
+// isTestHandleType reports whether typ is a *qt.C, or, in a file importing
+// go-quicktest/qt, a test handle.
 //
 // Only the checker. This tool is about quicktest, and a row carrying a
 // *testing.T or a testing.TB is a table in a suite that may not use quicktest
@@ -207,11 +210,38 @@ func reportDataRowField(pass *analysis.Pass, field *assertionField) {
 // The field's NAME is not consulted. A row carrying the means to assert is the
 // defect whatever it is called, and matching a name like "assert" would find
 // the tidy cases and miss the rest.
-func isTestHandleType(typ types.Type) bool {
+//
+// go-quicktest/qt has no checker type: its assertions take the test handle
+// itself, so a row carrying the means to assert carries a *testing.T or a
+// testing.TB. The anchor moves with it, from the parameter's type to the file's
+// import — a file that imports go-quicktest/qt is one whose table rows assert
+// through it.
+func isTestHandleType(typ types.Type, v2 bool) bool {
 	if typ == nil {
 		return false
 	}
-	return isQuicktestCType(typ)
+	return isQuicktestCType(typ) || (v2 && isTestingHandle(typ))
+}
+
+// isTestingHandle reports whether typ is *testing.T, *testing.B, *testing.F or
+// testing.TB itself.
+func isTestingHandle(typ types.Type) bool {
+	if ptr, ok := types.Unalias(typ).(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	if obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != testingPkgPath {
+		return false
+	}
+	switch obj.Name() {
+	case "T", "B", "F", "TB":
+		return true
+	}
+	return false
 }
 
 // collectFieldBody records elt when it assigns a closure to the field.
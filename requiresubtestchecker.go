@@ -77,10 +77,27 @@ func (*analyzer) checkRequireSubtestChecker(pass *analysis.Pass) {
 		qtAlias := importedPkgName(pass, file, quicktestPkgPath)
 		for _, root := range outermostFuncs(file) {
 			planBorrowedCheckers(pass, root, qtAlias)
+			planBorrowedHandles(pass, root)
 		}
 	}
 }
 
+// subtestClosuresIn returns every subtest closure within root.
+func subtestClosuresIn(pass *analysis.Pass, root ast.Node) []subtestClosure {
+	var closures []subtestClosure
+	ast.Inspect(root, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if closure, ok := matchSubtestClosure(pass, call); ok {
+			closures = append(closures, closure)
+		}
+		return true
+	})
+	return closures
+}
+
 // borrowSite is one subtest closure that reads a checker from outside itself.
 type borrowSite struct {
 	closure subtestClosure
@@ -98,17 +115,7 @@ type borrowSite struct {
 // closures were its only readers, the declaration has to go with them or the
 // function stops compiling. Answering that per closure cannot see the others.
 func planBorrowedCheckers(pass *analysis.Pass, root ast.Node, qtAlias string) {
-	var closures []subtestClosure
-	ast.Inspect(root, func(n ast.Node) bool {
-		call, ok := n.(*ast.CallExpr)
-		if !ok {
-			return true
-		}
-		if closure, ok := matchSubtestClosure(pass, call); ok {
-			closures = append(closures, closure)
-		}
-		return true
-	})
+	closures := subtestClosuresIn(pass, root)
 	if len(closures) == 0 {
 		return
 	}
@@ -346,3 +353,110 @@ func declRemovalEdits(pass *analysis.Pass, root ast.Node, obj types.Object) ([]a
 	}
 	return []analysis.TextEdit{{Pos: start, End: end}}, ""
 }
+
+// handleBorrow is one subtest closure whose go-quicktest/qt assertions name a
+// test handle from outside it.
+type handleBorrow struct {
+	closure subtestClosure
+	args    []*ast.Ident
+}
+
+// planBorrowedHandles is the go-quicktest/qt counterpart of
+// planBorrowedCheckers.
+//
+// That API has no *qt.C: every assertion names the test it reports against as
+// its first argument. So the same defect is spelled qt.Assert(t, …) inside a
+// subtest whose own handle is t2, and the repair is to name the subtest's
+// handle instead. No declaration goes with it, because the handle borrowed is a
+// parameter of the test around the subtest and stays in use there.
+//
+// Attribution is innermost-wins for the reason borrowSites gives.
+func planBorrowedHandles(pass *analysis.Pass, root ast.Node) {
+	closures := subtestClosuresIn(pass, root)
+	if len(closures) == 0 {
+		return
+	}
+
+	byLit := make(map[*ast.FuncLit]*handleBorrow)
+	var borrows []*handleBorrow
+	ast.Inspect(root, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || !isV2Assertion(pass, call) || len(call.Args) == 0 {
+			return true
+		}
+		ident, ok := stripParens(call.Args[0]).(*ast.Ident)
+		if !ok {
+			return true
+		}
+		obj, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+		if !ok || !isTestingTPtr(obj.Type()) {
+			return true
+		}
+		lit := innermostClosureAt(closures, call.Pos())
+		if lit == nil || declaredWithin(lit, obj) {
+			return true
+		}
+		b, ok := byLit[lit]
+		if !ok {
+			for _, closure := range closures {
+				if closure.lit == lit {
+					b = &handleBorrow{closure: closure}
+				}
+			}
+			byLit[lit] = b
+			borrows = append(borrows, b)
+		}
+		b.args = append(b.args, ident)
+		return true
+	})
+
+	for _, b := range borrows {
+		reportBorrowedHandle(pass, b)
+	}
+}
+
+// reportBorrowedHandle reports one closure, with a fix that names the
+// closure's own handle in every assertion that borrowed one.
+func reportBorrowedHandle(pass *analysis.Pass, b *handleBorrow) {
+	reason := ""
+	if b.closure.handle == "" {
+		reason = "; no fix: the closure's *testing.T is blank, so there is no handle to assert through"
+	}
+	var edits []analysis.TextEdit
+	for _, arg := range b.args {
+		if reason != "" {
+			break
+		}
+		// The handle's name has to mean the closure's parameter where it is
+		// written; a nested block may have declared it again.
+		if !namesParam(pass, b.closure, arg.Pos()) {
+			reason = "; no fix: the closure's handle is hidden where the assertion would name it"
+			break
+		}
+		edits = append(edits, analysis.TextEdit{Pos: arg.Pos(), End: arg.End(), NewText: []byte(b.closure.handle)})
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     b.closure.lit.Type.Pos(),
+		End:     b.closure.lit.Type.End(),
+		Message: "qtlint: this subtest asserts through the *testing.T of the test around it, so a failure names that test instead" + reason,
+	}
+	if reason == "" {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Assert through the subtest's own *testing.T",
+			TextEdits: edits,
+		}}
+	}
+	pass.Report(diag)
+}
+
+// namesParam reports whether the closure's handle name resolves to its
+// parameter at pos.
+func namesParam(pass *analysis.Pass, closure subtestClosure, pos token.Pos) bool {
+	scope := pass.Pkg.Scope().Innermost(pos)
+	if scope == nil || len(closure.param.Names) != 1 {
+		return false
+	}
+	_, obj := scope.LookupParent(closure.handle, pos)
+	return obj != nil && obj == pass.TypesInfo.Defs[closure.param.Names[0]]
+}
@@ -654,6 +654,13 @@ type cReach struct {
 	// *qt.C, so Defer is unreachable there and testing.TB's own methods are
 	// what remains.
 	handedOn bool
+	// asserts is set when the checker's Assert can be reached, directly or as
+	// the t of qt.Assert, which stops the test with t.FailNow.
+	asserts bool
+	// checks is set when the checker does what a *qt.C is made for: asserts
+	// with Assert or Check, directly or as the t of the package-level form, or
+	// runs a subtest with Run.
+	checks bool
 
 	// escape names the first use the analysis could not follow, and method the
 	// first spelled-out method that decided the reach. Exactly one is usually
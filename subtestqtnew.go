@@ -0,0 +1,89 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkSubtestQtNew checks for a qt.New inside a subtest closure that builds
+// its checker from the *testing.T of a test around the subtest, as in
+// t.Run("x", func(t2 *testing.T) { c := qt.New(t) }). The checker reports
+// against the test it was built from, so a failure names the parent, and
+// Assert stops the parent from the subtest's goroutine, where t.FailNow must
+// not be called.
+//
+// The closure is the innermost t.Run, b.Run or f.Fuzz closure around the
+// qt.New, as matchSubtestClosure reads it, and a *testing.T declared anywhere
+// within it is taken to be meant. -require-subtest-checker reports the sibling
+// shape, a subtest asserting through a *qt.C built outside it.
+//
+// The fix names the closure's own handle in place of the outer one.
+func checkSubtestQtNew(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		for _, root := range outermostFuncs(file) {
+			closures := subtestClosuresIn(pass, root)
+			if len(closures) == 0 {
+				continue
+			}
+			ast.Inspect(root, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				arg, ok := qtNewArg(pass, call)
+				if !ok {
+					return true
+				}
+				ident, ok := stripParens(arg).(*ast.Ident)
+				if !ok {
+					return true
+				}
+				obj, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+				if !ok || !isTestingTPtr(obj.Type()) {
+					return true
+				}
+				lit := innermostClosureAt(closures, call.Pos())
+				if lit == nil || declaredWithin(lit, obj) {
+					return true
+				}
+				for _, closure := range closures {
+					if closure.lit == lit {
+						reportSubtestQtNew(pass, closure, call, ident)
+					}
+				}
+				return true
+			})
+		}
+	}
+}
+
+// reportSubtestQtNew reports qt.New(outer), built within closure from a
+// handle declared outside it.
+func reportSubtestQtNew(pass *analysis.Pass, closure subtestClosure, call *ast.CallExpr, outer *ast.Ident) {
+	newText, ok := formatExpr(pass, call)
+	if !ok {
+		return
+	}
+	diag := analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("qtlint: %s in a subtest builds a checker from the test around it, so a failure names that test and Assert stops it",
+			newText),
+	}
+	switch {
+	case closure.handle == "":
+		diag.Message += "; no fix: the closure's *testing.T is blank, so there is no handle to build a checker from"
+	case !namesParam(pass, closure, outer.Pos()):
+		diag.Message += "; no fix: the closure's handle is hidden where qt.New would name it"
+	default:
+		diag.Message += fmt.Sprintf("; use the subtest's own %s", closure.handle)
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Build the checker from the subtest's own *testing.T",
+			TextEdits: []analysis.TextEdit{{Pos: outer.Pos(), End: outer.End(), NewText: []byte(closure.handle)}},
+		}}
+	}
+	pass.Report(diag)
+}
@@ -0,0 +1,114 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// orderedCheckers are the checkers whose failure message labels got and want,
+// and which compare the two the same way whichever is which, so that
+// swapping them changes the message and nothing else.
+var orderedCheckers = map[string]bool{
+	"Equals":     true,
+	"DeepEquals": true,
+	"CmpEquals":  true,
+}
+
+// wantNames are the names a variable holding the expected value goes by.
+var wantNames = []string{"want", "expected"}
+
+// looksExpected reports whether expr reads as the expected value of an
+// assertion: a constant, a composite literal or its address, or a variable
+// or field named want or expected, or wantX or expectedX.
+func looksExpected(pass *analysis.Pass, expr ast.Expr) bool {
+	expr = stripParens(expr)
+	if tv, ok := pass.TypesInfo.Types[expr]; ok && tv.Value != nil {
+		return true
+	}
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		return true
+	case *ast.UnaryExpr:
+		_, ok := stripParens(e.X).(*ast.CompositeLit)
+		return ok
+	case *ast.Ident:
+		return isWantName(e.Name)
+	case *ast.SelectorExpr:
+		return isWantName(e.Sel.Name)
+	}
+	return false
+}
+
+// isWantName reports whether name is one of wantNames, or one of them
+// followed by an upper-case letter.
+func isWantName(name string) bool {
+	for _, want := range wantNames {
+		rest, ok := strings.CutPrefix(name, want)
+		if !ok {
+			continue
+		}
+		if r, _ := utf8.DecodeRuneInString(rest); rest == "" || unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSwappedGotWant checks for an assertion comparing an expected value,
+// given as got, with the value under test, given as want, and suggests
+// swapping them. The failure message of qt.Equals and its siblings labels
+// the two, so c.Assert(42, qt.Equals, n) reports 42 as what the code
+// produced. A checker wrapped in qt.Not is left alone: its failure message
+// says only that the two were equal, and which was which does not matter.
+func (a *analyzer) checkSwappedGotWant(pass *analysis.Pass, call *ast.CallExpr) {
+	var checkerName string
+	var got, want ast.Expr
+	if isQuicktestAssertion(pass, call) {
+		args := v1AssertionArgs(pass, call)
+		if len(args) < 3 || call.Ellipsis.IsValid() {
+			return
+		}
+		c, ok := parseV1Checker(pass, args[1])
+		if !ok || c.inner != nil {
+			return
+		}
+		checkerName, got, want = c.name, args[0], args[2]
+	} else if checker, ok := getV2Checker(pass, call); ok {
+		if len(checker.call.Args) < 2 {
+			return
+		}
+		checkerName, got, want = checker.name, checker.call.Args[0], checker.call.Args[1]
+	} else {
+		return
+	}
+	if !orderedCheckers[checkerName] || !looksExpected(pass, got) || looksExpected(pass, want) {
+		return
+	}
+	gotText, ok := formatExpr(pass, got)
+	if !ok {
+		return
+	}
+	wantText, ok := formatExpr(pass, want)
+	if !ok {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos: got.Pos(),
+		End: got.End(),
+		Message: fmt.Sprintf("qtlint: swap got and want: %s reads as the expected value, but qt.%s reports it as got",
+			gotText, checkerName),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Swap got and want",
+			TextEdits: []analysis.TextEdit{
+				{Pos: got.Pos(), End: got.End(), NewText: []byte(wantText)},
+				{Pos: want.Pos(), End: want.End(), NewText: []byte(gotText)},
+			},
+		}},
+	})
+}
@@ -0,0 +1,120 @@
+package allany
+
+import (
+	"reflect"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type item struct {
+	Name  string
+	Valid bool
+}
+
+func TestAll(t *testing.T) {
+	c := qt.New(t)
+	names := []string{"a", "a"}
+	for _, name := range names { // want `qtlint: use qt.All\(qt.Equals\) instead of a loop asserting on each element; qt.All reports which element failed`
+		c.Assert(name, qt.Equals, "a")
+	}
+
+	counts := map[string]int{"a": 1}
+	for _, n := range counts { // want `qtlint: use qt.All\(qt.Not\(qt.Equals\)\) instead of a loop asserting on each element`
+		c.Assert(n, qt.Not(qt.Equals), 0, qt.Commentf("counts"))
+	}
+
+	var errs [2]error
+	for _, err := range errs { // want `qtlint: use qt.All\(qt.IsNil\) instead of a loop asserting on each element`
+		qt.Assert(t, err, qt.IsNil)
+	}
+}
+
+// The loop checks a field of each element, which qt.All cannot reach.
+func TestField(t *testing.T) {
+	c := qt.New(t)
+	items := []item{{Valid: true}}
+	for _, it := range items { // want `qtlint: use qt.All\(qt.IsTrue\) instead of a loop asserting on each element; qt.All reports which element failed; no fix: the loop checks it.Valid rather than the element itself`
+		c.Assert(it.Valid, qt.IsTrue)
+	}
+}
+
+func TestAny(t *testing.T) {
+	c := qt.New(t)
+	names := []string{"a", "b"}
+	found := false
+	for _, name := range names { // want `qtlint: use qt.Any\(qt.Equals\) instead of a loop setting found`
+		if name == "b" {
+			found = true
+			break
+		}
+	}
+	c.Assert(found, qt.IsTrue)
+
+	var sawNil bool
+	for _, err := range []error{nil} { // want `qtlint: use qt.Any\(qt.IsNil\) instead of a loop setting sawNil`
+		if err == nil {
+			sawNil = true
+		}
+	}
+	c.Assert(sawNil, qt.IsTrue, qt.Commentf("no nil error"))
+
+	// id == 1 compares two int64s, where qt.Equals would compare an int64
+	// with an int, so there is no fix.
+	ids := []int64{1}
+	hasOne := false
+	for _, id := range ids { // want `qtlint: use qt.Any\(qt.Equals\) instead of a loop setting hasOne`
+		if id == 1 {
+			hasOne = true
+		}
+	}
+	c.Assert(hasOne, qt.IsTrue)
+}
+
+// Loops the rule leaves alone.
+func TestNotMatched(t *testing.T) {
+	c := qt.New(t)
+	names := []string{"a"}
+
+	// The index is used.
+	for i, name := range names {
+		c.Assert(name, qt.Equals, names[i])
+	}
+
+	// The want depends on the element.
+	for _, name := range names {
+		c.Assert(name, qt.Equals, name+"")
+	}
+
+	// More than one statement.
+	for _, name := range names {
+		c.Assert(name, qt.Not(qt.Equals), "")
+		c.Assert(name, qt.Equals, "a")
+	}
+
+	// The flag is used after the assertion.
+	found := false
+	for _, name := range names {
+		if name == "a" {
+			found = true
+		}
+	}
+	c.Assert(found, qt.IsTrue)
+	_ = found
+
+	// The condition does not map to a checker.
+	long := false
+	for _, name := range names {
+		if len(name) > 3 {
+			long = true
+		}
+	}
+	c.Assert(long, qt.IsTrue)
+
+	// A string is not a container qt.All takes.
+	for _, r := range "abc" {
+		c.Assert(r, qt.Not(qt.Equals), 'x')
+	}
+
+	_ = reflect.DeepEqual
+}
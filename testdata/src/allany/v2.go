@@ -0,0 +1,37 @@
+package allany
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestAllV2(t *testing.T) {
+	items := []item{{Valid: true}}
+	for _, it := range items { // want `qtlint: use qt.SliceAll instead of a loop asserting on each element; qt.SliceAll reports which element failed`
+		qt.Assert(t, qt.IsTrue(it.Valid))
+	}
+
+	byName := map[string]*url.URL{"a": {}}
+	for _, u := range byName { // want `qtlint: use qt.MapAll instead of a loop asserting on each element`
+		qt.Assert(t, qt.IsNotNil(u))
+	}
+
+	var fixed [2]string
+	for _, s := range fixed { // want `qtlint: use qt.SliceAll instead of a loop asserting on each element; qt.SliceAll reports which element failed; no fix: qt.SliceAll takes a slice, not an array`
+		qt.Assert(t, qt.Equals(s, ""))
+	}
+}
+
+func TestAnyV2(t *testing.T) {
+	items := []item{{Name: "a"}}
+	found := false
+	for _, it := range items { // want `qtlint: use qt.SliceAny instead of a loop setting found`
+		if it.Name == "a" {
+			found = true
+			break
+		}
+	}
+	qt.Assert(t, qt.IsTrue(found))
+}
@@ -0,0 +1,35 @@
+package allanyonlystable
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestAssert(t *testing.T) {
+	c := qt.New(t)
+	names := []string{"a"}
+	for _, name := range names { // want `qtlint: use qt.All\(qt.Equals\) instead of a loop asserting on each element`
+		c.Assert(name, qt.Equals, "a")
+	}
+}
+
+// Check fails once for every element, qt.All for the first only, so the fix
+// is withheld.
+func TestCheck(t *testing.T) {
+	c := qt.New(t)
+	names := []string{"a"}
+	for _, name := range names { // want `qtlint: use qt.All\(qt.Equals\) instead of a loop asserting on each element`
+		c.Check(name, qt.Equals, "a")
+	}
+}
+
+// The want is evaluated once after the fix, not once per element.
+func TestCall(t *testing.T) {
+	c := qt.New(t)
+	names := []string{"a"}
+	for _, name := range names { // want `qtlint: use qt.All\(qt.Equals\) instead of a loop asserting on each element`
+		c.Assert(name, qt.Equals, strings.ToLower("A"))
+	}
+}
@@ -0,0 +1,47 @@
+package checkerargs
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestMissingWant(t *testing.T) {
+	c := qt.New(t)
+
+	got := 1
+	c.Assert(got, qt.Equals)                            // want `qtlint: qt.Equals is missing its want argument`
+	c.Check(got, qt.Not(qt.Equals), qt.Commentf("got")) // want `qtlint: qt.Not\(qt.Equals\) is missing its want argument`
+	qt.Assert(t, []int{got}, qt.All(qt.Equals))         // want `qtlint: qt.All\(...\) is missing its want argument`
+	c.Assert(got, qt.Equals, 1)
+	c.Assert(got, qt.Equals, 1, qt.Commentf("got"), qt.Commentf("again"))
+}
+
+func TestTooMany(t *testing.T) {
+	c := qt.New(t)
+
+	var err error
+	c.Assert(err, qt.IsNil, "reading config")             // want `qtlint: qt.IsNil takes no argument after got, but is given 1; only qt.Comment values may follow`
+	c.Assert(err, qt.Not(qt.IsNotNil), 1, 2)              // want `qtlint: qt.Not\(qt.IsNotNil\) takes no argument after got, but is given 2; only qt.Comment values may follow` `qtlint: use qt.IsNil instead of qt.Not\(qt.IsNotNil\)`
+	qt.Check(t, "a", qt.CmpEquals(), "a", "b")            // want `qtlint: qt.CmpEquals takes one argument after got, but is given 2; only qt.Comment values may follow` `qtlint: use qt.DeepEquals instead of qt.CmpEquals\(\)`
+	c.Assert(err, qt.IsNil, qt.Commentf("first"), "then") // want `qtlint: qt.IsNil takes no argument after got, but is given 2; only qt.Comment values may follow`
+	c.Assert([]error{err}, qt.Any(qt.IsNil), nil)         // want `qtlint: qt.Any\(...\) takes no argument after got, but is given 1; only qt.Comment values may follow`
+	c.Assert(err, qt.IsNil, qt.Commentf("reading config"))
+	c.Assert([]error{err}, qt.All(qt.IsNil))
+}
+
+// Spread arguments cannot be counted, and are left to -strict-checker-args.
+func TestSpread(t *testing.T) {
+	c := qt.New(t)
+
+	args := []any{1}
+	c.Assert(1, qt.Equals, args...)
+}
+
+// A checker qtlint does not know is not counted.
+func TestCustomChecker(t *testing.T) {
+	c := qt.New(t)
+
+	var custom qt.Checker
+	c.Assert(1, custom)
+}
@@ -0,0 +1,16 @@
+package checkerargsstrict
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSpread(t *testing.T) {
+	c := qt.New(t)
+
+	args := []any{1}
+	c.Assert(1, qt.Equals, args...)                // want `qtlint: cannot count the arguments spread to qt.Equals, which takes one argument after got`
+	qt.Check(t, nil, qt.Not(qt.IsNotNil), args...) // want `qtlint: cannot count the arguments spread to qt.Not\(qt.IsNotNil\), which takes no argument after got` `qtlint: use qt.IsNil instead of qt.Not\(qt.IsNotNil\)`
+	c.Assert(1, qt.Equals, 1)
+}
@@ -0,0 +1,105 @@
+package cmpdiff
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp"
+)
+
+type point struct{ X, Y int }
+
+func load() point { return point{} }
+
+func TestDeepEquals(t *testing.T) {
+	c := qt.New(t)
+
+	want, got := point{1, 2}, load()
+	if diff := cmp.Diff(want, got); diff != "" { // want `qtlint: use c.Check\(got, qt.DeepEquals, want\) instead of cmp.Diff with t.Errorf\(...\)`
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, got); diff != "" { // want `qtlint: use c.Assert\(got, qt.DeepEquals, want\) instead of cmp.Diff with t.Fatal\(...\)`
+		t.Fatal(diff)
+	}
+	c.Assert(got, qt.DeepEquals, want)
+}
+
+func TestOptions(t *testing.T) {
+	c := qt.New(t)
+
+	opts := []cmp.Option{cmp.AllowUnexported(point{})}
+	if diff := cmp.Diff(point{}, load(), opts...); diff != "" { // want `qtlint: use c.Check\(load\(\), qt.CmpEquals\(...\), point{}\) instead of cmp.Diff with t.Error\(...\)`
+		t.Error(diff)
+	}
+	c.Assert(opts, qt.HasLen, 1)
+}
+
+// The row's name has nowhere to go in the assertion, so there is no fix; the
+// block is still reported.
+func TestTable(t *testing.T) {
+	c := qt.New(t)
+
+	for _, tc := range []struct {
+		name string
+		want point
+	}{{"zero", point{}}} {
+		if diff := cmp.Diff(tc.want, load()); diff != "" { // want `qtlint: use c.Check\(load\(\), qt.DeepEquals, tc.want\) instead of cmp.Diff with t.Errorf\(...\)`
+			t.Errorf("%s: mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+	c.Assert(1, qt.Equals, 1)
+}
+
+// Not reported as a cmp.Diff block: diff outlives the if statement. What is
+// left is an ordinary comparison.
+func TestDiffDeclaredBefore(t *testing.T) {
+	c := qt.New(t)
+
+	diff := cmp.Diff(point{}, load())
+	if diff != "" { // want `qtlint: use c.Check\(diff, qt.Equals, "", qt.Commentf\(...\)\) instead of t.Error\(...\)`
+		t.Error(diff)
+	}
+	c.Assert(diff, qt.Equals, "")
+}
+
+// Not reported as cmp.Diff blocks: the first does more than fail the test,
+// the second fails when there is no difference, and the third has an else.
+func TestMoreThanFailure(t *testing.T) {
+	c := qt.New(t)
+
+	if diff := cmp.Diff(point{}, load()); diff != "" {
+		t.Log("retrying")
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(point{}, load()); diff == "" { // want `qtlint: use c.Check\(diff, qt.Not\(qt.Equals\), "", qt.Commentf\(...\)\) instead of t.Error\(...\)`
+		t.Error("no difference")
+	}
+	if diff := cmp.Diff(point{}, load()); diff != "" {
+		t.Error(diff)
+	} else {
+		t.Log("same")
+	}
+	c.Assert(1, qt.Equals, 1)
+}
+
+type reporter struct{}
+
+func (reporter) Errorf(format string, args ...any) {}
+
+// Not reported: Errorf is not the testing package's.
+func TestOtherErrorf(t *testing.T) {
+	c := qt.New(t)
+
+	var r reporter
+	if diff := cmp.Diff(point{}, load()); diff != "" {
+		r.Errorf("mismatch: %s", diff)
+	}
+	c.Assert(1, qt.Equals, 1)
+}
+
+// Not reported: no *qt.C is in scope to assert through.
+func TestNoChecker(t *testing.T) {
+	if diff := cmp.Diff(point{}, load()); diff != "" {
+		t.Errorf("mismatch: %s", diff)
+	}
+}
@@ -0,0 +1,22 @@
+package cmpdiff
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestV2(t *testing.T) {
+	if diff := cmp.Diff(point{}, load()); diff != "" { // want `qtlint: use qt.Check\(t, qt.DeepEquals\(load\(\), point{}\)\) instead of cmp.Diff with t.Errorf\(...\)`
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	// cmp.Diff compares an int with an int64 and finds them different;
+	// qt.DeepEquals would not compile. The block is reported without a fix.
+	var n int64 = 1
+	if diff := cmp.Diff(1, n); diff != "" { // want `qtlint: use qt.Check\(t, qt.DeepEquals\(n, 1\)\) instead of cmp.Diff with t.Error\(...\)`
+		t.Error(diff)
+	}
+	qt.Assert(t, qt.Equals(n, 1))
+}
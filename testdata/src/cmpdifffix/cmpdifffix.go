@@ -0,0 +1,45 @@
+package cmpdifffix
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp"
+)
+
+type point struct{ X, Y int }
+
+func load() point { return point{} }
+
+func TestDeepEquals(t *testing.T) {
+	c := qt.New(t)
+
+	want := point{1, 2}
+	if diff := cmp.Diff(want, load()); diff != "" { // want `qtlint: use c.Check\(load\(\), qt.DeepEquals, want\) instead of cmp.Diff with t.Errorf\(...\)`
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, load()); "" != diff { // want `qtlint: use c.Assert\(load\(\), qt.DeepEquals, want\) instead of cmp.Diff with t.Fatalf\(...\)`
+		t.Fatalf("load() mismatch (-want +got):\n%s", diff)
+	}
+	c.Assert(1, qt.Equals, 1)
+}
+
+func TestCmpEquals(t *testing.T) {
+	c := qt.New(t)
+
+	if diff := cmp.Diff(point{}, load(), cmp.AllowUnexported(point{})); diff != "" { // want `qtlint: use c.Check\(load\(\), qt.CmpEquals\(...\), point{}\) instead of cmp.Diff with t.Error\(...\)`
+		t.Error("mismatch:", diff)
+	}
+	c.Assert(1, qt.Equals, 1)
+}
+
+// Unstable: c.Check evaluates load() before point{1, load().Y} where
+// cmp.Diff evaluated them the other way round.
+func TestEvaluationOrder(t *testing.T) {
+	c := qt.New(t)
+
+	if diff := cmp.Diff(point{1, load().Y}, load()); diff != "" { // want `qtlint: use c.Check\(load\(\), qt.DeepEquals, point{1, load\(\).Y}\) instead of cmp.Diff with t.Error\(...\)`
+		t.Error(diff)
+	}
+	c.Assert(1, qt.Equals, 1)
+}
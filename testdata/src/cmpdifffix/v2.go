@@ -0,0 +1,18 @@
+package cmpdifffix
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestV2(t *testing.T) {
+	if diff := cmp.Diff(point{}, load()); diff != "" { // want `qtlint: use qt.Assert\(t, qt.DeepEquals\(load\(\), point{}\)\) instead of cmp.Diff with t.Fatal\(...\)`
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff(point{}, load(), cmp.AllowUnexported(point{})); diff != "" { // want `qtlint: use qt.Check\(t, qt.CmpEquals\(load\(\), point{}, ...\)\) instead of cmp.Diff with t.Errorf\(...\)`
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	qt.Assert(t, qt.IsTrue(true))
+}
@@ -0,0 +1,32 @@
+package cmpdiffonlystable
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp"
+)
+
+type point struct{ X, Y int }
+
+func load() point { return point{} }
+
+func TestStable(t *testing.T) {
+	c := qt.New(t)
+
+	if diff := cmp.Diff(point{}, load()); diff != "" { // want `qtlint: use c.Check\(load\(\), qt.DeepEquals, point{}\) instead of cmp.Diff with t.Errorf\(...\)`
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	c.Assert(1, qt.Equals, 1)
+}
+
+// Unstable: both operands call load, and the rewrite would call them in the
+// other order. The diagnostic stays; the fix is withheld.
+func TestEvaluationOrder(t *testing.T) {
+	c := qt.New(t)
+
+	if diff := cmp.Diff(point{1, load().Y}, load()); diff != "" { // want `qtlint: use c.Check\(load\(\), qt.DeepEquals, point{1, load\(\).Y}\) instead of cmp.Diff with t.Error\(...\)`
+		t.Error(diff)
+	}
+	c.Assert(1, qt.Equals, 1)
+}
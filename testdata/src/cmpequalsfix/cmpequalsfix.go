@@ -0,0 +1,25 @@
+package cmpequalsfix
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCmpEqualsNoOpts(t *testing.T) {
+	c := qt.New(t)
+	got := []int{1}
+
+	c.Assert(got, qt.CmpEquals(), []int{1})           // want `qtlint: use qt.DeepEquals instead of qt.CmpEquals\(\)`
+	qt.Check(t, got, qt.Not(qt.CmpEquals()), []int{}) // want `qtlint: use qt.DeepEquals instead of qt.CmpEquals\(\)`
+}
+
+// Negative test cases: patterns that should NOT trigger the rule
+func TestCmpEqualsNotMatched(t *testing.T) {
+	c := qt.New(t)
+	got := []int{1}
+	var opts []any
+
+	c.Assert(got, qt.CmpEquals(nil), []int{1})
+	c.Assert(got, qt.CmpEquals(opts...), []int{1})
+}
@@ -0,0 +1,15 @@
+package cmpequalsfix
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestCmpEqualsNoOptsV2(t *testing.T) {
+	got := []int{1}
+
+	qt.Assert(t, qt.CmpEquals(got, []int{1}))        // want `qtlint: use qt.DeepEquals\(x, y\) instead of qt.CmpEquals\(x, y\)`
+	qt.Assert(t, qt.Not(qt.CmpEquals(got, []int{}))) // want `qtlint: use qt.DeepEquals\(x, y\) instead of qt.CmpEquals\(x, y\)`
+	qt.Assert(t, qt.CmpEquals(got, []int{1}, nil))
+}
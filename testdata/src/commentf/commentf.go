@@ -0,0 +1,86 @@
+package commentf
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	quick "github.com/frankban/quicktest"
+)
+
+type id int
+
+type point struct{ X, Y int }
+
+type name string
+
+func (n name) String() string { return string(n) }
+
+// Verbs that do not fit their arguments.
+func TestVerbs(t *testing.T) {
+	c := qt.New(t)
+	n, s := 3, "x"
+	err := errors.New("boom")
+
+	c.Assert(n, qt.Equals, 3, qt.Commentf("id %d", s))         // want `qtlint: qt.Commentf format %d has arg s of wrong type string`
+	c.Assert(n, qt.Equals, 3, qt.Commentf("ids %d and %d", n)) // want `qtlint: qt.Commentf format %d reads arg #2, but call has 1 arg`
+	c.Assert(n, qt.Equals, 3, qt.Commentf("id %d", n, s))      // want `qtlint: qt.Commentf call needs 1 arg but has 2 args`
+	c.Assert(n, qt.Equals, 3, qt.Commentf("id", n))            // want `qtlint: qt.Commentf call has arguments but no formatting directives`
+	c.Assert(n, qt.Equals, 3, qt.Commentf("id %z", n))         // want `qtlint: qt.Commentf format %z has unknown verb z`
+	c.Assert(err, qt.IsNotNil, qt.Commentf("err %w", err))     // want `qtlint: qt.Commentf does not support error-wrapping directive %w`
+	c.Assert(n, qt.Equals, 3, qt.Commentf("%t", n))            // want `qtlint: qt.Commentf format %t has arg n of wrong type int`
+	c.Assert(n, qt.Equals, 3, qt.Commentf("%*d", s, n))        // want `qtlint: qt.Commentf format %\* uses non-int s as argument of \*`
+	c.Assert(n, qt.Equals, 3, qt.Commentf("%s", []point{}))    // want `qtlint: qt.Commentf format %s has arg \[\]point\{\} of wrong type \[\]point`
+	c.Assert(n, qt.Equals, 3, qt.Commentf("at 100%"))          // want `qtlint: qt.Commentf format % is missing its verb at the end of the string`
+	quick.Assert(t, n, quick.Equals, 3, quick.Commentf("%d"))  // want `qtlint: qt.Commentf format %d reads arg #1, but call has 0 args`
+}
+
+// Formats that fit their arguments.
+func TestGoodFormats(t *testing.T) {
+	c := qt.New(t)
+	n, s := 3, "x"
+	err := errors.New("boom")
+	var p *point
+	var anything any = s
+
+	c.Assert(n, qt.Equals, 3, qt.Commentf("plain text"))
+	c.Assert(n, qt.Equals, 3, qt.Commentf("100%% done"))
+	c.Assert(n, qt.Equals, 3, qt.Commentf("id %d of %s", n, s))
+	c.Assert(n, qt.Equals, 3, qt.Commentf("id %d", id(n)))
+	c.Assert(n, qt.Equals, 3, qt.Commentf("%s %v %q", err, name("a"), s))
+	c.Assert(n, qt.Equals, 3, qt.Commentf("%s", time.Second))
+	c.Assert(n, qt.Equals, 3, qt.Commentf("%d %p %v", p, p, p))
+	c.Assert(n, qt.Equals, 3, qt.Commentf("%d", &point{}))
+	c.Assert(n, qt.Equals, 3, qt.Commentf("%x %s", []byte("a"), []byte("b")))
+	c.Assert(n, qt.Equals, 3, qt.Commentf("%-8.*f|%+v|%#v", 2, 1.5, point{}, p))
+	c.Assert(n, qt.Equals, 3, qt.Commentf("%[2]d %[1]s", s, n))
+	c.Assert(n, qt.Equals, 3, qt.Commentf("%s", anything))
+	c.Assert(n, qt.Equals, 3, qt.Commentf("%T", n))
+	args := []any{n}
+	c.Assert(n, qt.Equals, 3, qt.Commentf("id %d and %d", args...))
+	c.Assert(n, qt.Equals, 3, quick.Commentf("id %d", n))
+}
+
+// A format that is not a constant, given no arguments.
+func TestNonConstantFormat(t *testing.T) {
+	c := qt.New(t)
+	msg := fmt.Sprint("load ", 100, "%")
+
+	c.Assert(msg, qt.Not(qt.Equals), "", qt.Commentf(msg)) // want `qtlint: qt.Commentf has a non-constant format and no args; use qt.Commentf\("%s", msg\) to print it as a plain comment`
+	c.Assert(msg, qt.Not(qt.Equals), "", qt.Commentf(msg, 1))
+	c.Assert(msg, qt.Not(qt.Equals), "", qt.Commentf("%s", msg))
+}
+
+// A comment given before the checker's own arguments.
+func TestMisplacedComment(t *testing.T) {
+	c := qt.New(t)
+	n, want := 3, 3
+	comment := qt.Commentf("n")
+
+	c.Assert(n, qt.Equals, qt.Commentf("n"), want)                      // want `qtlint: comment comes before the arguments of qt.Equals; quicktest takes comments only off the end, so the checker is given it as an argument`
+	qt.Check(t, n, qt.Not(qt.Equals), comment, 4, qt.Commentf("again")) // want `qtlint: comment comes before the arguments of qt.Not\(qt.Equals\); quicktest takes comments only off the end, so the checker is given it as an argument`
+	c.Assert(n, qt.Equals, want, qt.Commentf("n"), qt.Commentf("again"))
+	c.Assert(nil, qt.IsNil, qt.Commentf("first"), "then") // want `qtlint: qt.IsNil takes no argument after got, but is given 2; only qt.Comment values may follow`
+}
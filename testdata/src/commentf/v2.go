@@ -0,0 +1,15 @@
+package commentf
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestV2(t *testing.T) {
+	n, s := 3, "x"
+
+	qt.Assert(t, qt.Equals(n, 3), qt.Commentf("id %d", s)) // want `qtlint: qt.Commentf format %d has arg s of wrong type string`
+	qt.Check(t, qt.Equals(n, 3), qt.Commentf(s))           // want `qtlint: qt.Commentf has a non-constant format and no args; use qt.Commentf\("%s", s\) to print it as a plain comment`
+	qt.Check(t, qt.Equals(n, 3), qt.Commentf("id %d", n))
+}
@@ -0,0 +1,51 @@
+package datarowsv2
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+var errBoom = errors.New("boom")
+
+// go-quicktest/qt asserts through the test handle itself, so a row carrying
+// the means to assert carries a *testing.T.
+func TestRowCarriesAHandle(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr func(t *testing.T, err error) // want "qtlint: a table row carries data, not a checker; give the row the value that varies, or split the table into the tests its rows are asserting differently"
+	}{
+		{
+			name:    "no error",
+			wantErr: func(t *testing.T, err error) { qt.Assert(t, qt.IsNil(err)) },
+		},
+		{
+			name:    "an error",
+			wantErr: func(t *testing.T, err error) { qt.Assert(t, qt.ErrorIs(err, errBoom)) },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.wantErr(t, nil)
+		})
+	}
+}
+
+// A row carrying something other than a handle is data.
+func TestRowCarriesData(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr error
+	}{
+		{name: "no error"},
+		{name: "an error", wantErr: errBoom},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			qt.Assert(t, qt.ErrorIs(test.wantErr, test.wantErr))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package deepequalsbasicfix
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type celsius float64
+
+func (c celsius) Equal(other celsius) bool { return c-other < 0.5 && other-c < 0.5 }
+
+func TestDeepEqualsBasic(t *testing.T) {
+	c := qt.New(t)
+	name := "a"
+	n := 1
+
+	c.Assert(name, qt.DeepEquals, "a")                      // want `qtlint: use qt.Equals instead of qt.DeepEquals on string, which == compares`
+	qt.Assert(t, n, qt.Not(qt.DeepEquals), 2)               // want `qtlint: use qt.Equals instead of qt.DeepEquals on int, which == compares`
+	c.Check(n > 0, qt.DeepEquals, true, qt.Commentf("pos")) // want `qtlint: use qt.Equals instead of qt.DeepEquals on bool, which == compares`
+}
+
+// Negative test cases: patterns that should NOT trigger the rule
+func TestDeepEqualsNotMatched(t *testing.T) {
+	c := qt.New(t)
+	n := 1
+
+	// go-cmp calls the Equal method of a named type.
+	c.Assert(celsius(20), qt.DeepEquals, celsius(20.2))
+
+	// Not a basic type.
+	c.Assert([]int{1}, qt.DeepEquals, []int{1})
+	var p *int
+	c.Assert(p, qt.DeepEquals, p)
+
+	// The two sides box to different types.
+	var f float32
+	c.Assert(n, qt.DeepEquals, int64(3))
+	c.Assert(f, qt.DeepEquals, 1.5)
+}
@@ -0,0 +1,15 @@
+package deepequalsbasicfix
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestDeepEqualsBasicV2(t *testing.T) {
+	name := "a"
+
+	qt.Assert(t, qt.DeepEquals(name, "a"))            // want `qtlint: use qt.Equals\(x, y\) instead of qt.DeepEquals\(x, y\) on string, which == compares`
+	qt.Assert(t, qt.Not(qt.DeepEquals(len(name), 2))) // want `qtlint: use qt.Equals\(x, y\) instead of qt.DeepEquals\(x, y\) on int, which == compares`
+	qt.Assert(t, qt.DeepEquals(celsius(20), 20.2))
+}
@@ -0,0 +1,75 @@
+package deferdone
+
+import (
+	"os"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func tempFile(c *qt.C) string {
+	f, err := os.CreateTemp("", "x")
+	c.Assert(err, qt.IsNil)
+	return f.Name()
+}
+
+func cleanupWith(c *qt.C) {
+	c.Done()
+}
+
+// Defer on a *qt.C from qt.New with nothing calling Done.
+func TestDeferWithoutDone(t *testing.T) {
+	c := qt.New(t) // the checker
+	name := tempFile(c)
+	c.Defer(func() { os.Remove(name) }) // want `qtlint: c.Defer without c.Done panics with "Done not called after Defer": c comes from qt.New rather than c.Run; add defer c.Done\(\)`
+	c.Defer(func() {})
+}
+
+func TestDeferInClosure(t *testing.T) {
+	var qc = qt.New(t)
+	func() {
+		qc.Defer(func() {}) // want `qtlint: qc.Defer without qc.Done panics with "Done not called after Defer": qc comes from qt.New rather than c.Run; add defer qc.Done\(\)`
+	}()
+}
+
+// Done is called, one way or another.
+func TestDeferDone(t *testing.T) {
+	c := qt.New(t)
+	defer c.Done()
+	c.Defer(func() {})
+}
+
+func TestDeferCleanupDone(t *testing.T) {
+	c := qt.New(t)
+	t.Cleanup(c.Done)
+	c.Defer(func() {})
+}
+
+func TestDeferDoneAtEnd(t *testing.T) {
+	c := qt.New(t)
+	c.Defer(func() {})
+	c.Done()
+}
+
+// A *qt.C from c.Run has Done called for it.
+func TestDeferInRun(t *testing.T) {
+	c := qt.New(t)
+	defer c.Done()
+	c.Run("sub", func(c *qt.C) {
+		c.Defer(func() {})
+	})
+}
+
+// A *qt.C handed somewhere the rule cannot see what happens to it.
+func TestDeferHandedOn(t *testing.T) {
+	c := qt.New(t)
+	c.Defer(func() {})
+	cleanupWith(c)
+}
+
+func TestDeferStored(t *testing.T) {
+	c := qt.New(t)
+	c.Defer(func() {})
+	keep := []*qt.C{c}
+	_ = keep
+}
@@ -0,0 +1,24 @@
+package doublenotfix
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestDoubleNot(t *testing.T) {
+	c := qt.New(t)
+	x := 1
+
+	c.Assert(x, qt.Not(qt.Not(qt.Equals)), 1)                 // want `qtlint: use qt.Equals instead of qt.Not\(qt.Not\(qt.Equals\)\)`
+	qt.Assert(t, x, qt.Not(qt.Not(qt.IsNotNil)))              // want `qtlint: use qt.IsNotNil instead of qt.Not\(qt.Not\(qt.IsNotNil\)\)`
+	c.Check([]int{x}, qt.Not(qt.Not(qt.CmpEquals(nil))), nil) // want `qtlint: use qt.CmpEquals\(...\) instead of qt.Not\(qt.Not\(qt.CmpEquals\(...\)\)\)`
+}
+
+// Negative test cases: patterns that should NOT trigger the rule
+func TestDoubleNotNotMatched(t *testing.T) {
+	c := qt.New(t)
+	x := 1
+
+	c.Assert(x, qt.Not(qt.Equals), 2)
+}
@@ -0,0 +1,14 @@
+package doublenotfix
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestDoubleNotV2(t *testing.T) {
+	x := 1
+
+	qt.Assert(t, qt.Not(qt.Not(qt.Equals(x, 1)))) // want `qtlint: use qt.Equals\(...\) instead of qt.Not\(qt.Not\(qt.Equals\(...\)\)\)`
+	qt.Assert(t, qt.Not(qt.Equals(x, 2)))
+}
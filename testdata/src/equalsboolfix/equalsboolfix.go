@@ -0,0 +1,39 @@
+package equalsboolfix
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type flag bool
+
+func TestEqualsBool(t *testing.T) {
+	c := qt.New(t)
+	ok := true
+
+	c.Assert(ok, qt.Equals, true)     // want "qtlint: use qt.IsTrue instead of qt.Equals, true"
+	qt.Assert(t, ok, qt.Equals, true) // want "qtlint: use qt.IsTrue instead of qt.Equals, true"
+	c.Check(ok, qt.Equals, false)     // want "qtlint: use qt.IsFalse instead of qt.Equals, false"
+
+	c.Assert(len("a") > 0, qt.Equals, true, qt.Commentf("non-empty")) // want "qtlint: use qt.IsTrue instead of qt.Equals, true"
+}
+
+// Negative test cases: patterns that should NOT trigger the rule
+func TestEqualsBoolNotMatched(t *testing.T) {
+	c := qt.New(t)
+	ok := true
+	want := true
+
+	// The want is not a literal.
+	c.Assert(ok, qt.Equals, want)
+
+	// A named bool type boxes as itself and never equals true, which is the
+	// impossible-checker rule's to report.
+	var f flag
+	c.Assert(f, qt.Equals, true) // want `qtlint: qt.Equals always fails on equalsboolfix.flag and bool, which are never equal`
+
+	// An interface holding a bool.
+	var v any = true
+	c.Assert(v, qt.Equals, true)
+}
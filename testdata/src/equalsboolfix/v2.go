@@ -0,0 +1,20 @@
+package equalsboolfix
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestEqualsBoolV2(t *testing.T) {
+	ok := true
+	var f flag
+
+	qt.Assert(t, qt.Equals(ok, true)) // want `qtlint: use qt.IsTrue\(x\) instead of qt.Equals\(x, true\)`
+	qt.Check(t, qt.Equals(ok, false)) // want `qtlint: use qt.IsFalse\(x\) instead of qt.Equals\(x, false\)`
+	qt.Assert(t, qt.Equals(f, true))  // want `qtlint: use qt.IsTrue\(x\) instead of qt.Equals\(x, true\)`
+
+	// An interface holding a bool is not one qt.IsTrue takes.
+	var v any = true
+	qt.Assert(t, qt.Equals(v, true))
+}
@@ -0,0 +1,45 @@
+package equalsdeep
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type point struct{ X, Y int }
+
+type bag struct {
+	items []string
+}
+
+func TestNonComparable(t *testing.T) {
+	c := qt.New(t)
+
+	got, want := []int{1}, []int{1}
+	c.Assert(got, qt.Equals, want)                          // want `qtlint: use qt.DeepEquals instead of qt.Equals on \[\]int, which == cannot compare`
+	c.Check(map[string]int{}, qt.Not(qt.Equals), nilMap())  // want `qtlint: use qt.Not\(qt.DeepEquals\) instead of qt.Not\(qt.Equals\) on map\[string\]int, which == cannot compare`
+	qt.Assert(t, bag{}, qt.Equals, bag{}, qt.Commentf("x")) // want `qtlint: use qt.DeepEquals instead of qt.Equals on equalsdeep.bag, which == cannot compare`
+}
+
+func nilMap() map[string]int { return nil }
+
+func TestPointers(t *testing.T) {
+	c := qt.New(t)
+
+	p, q := &point{1, 2}, &point{1, 2}
+	c.Assert(p, qt.Equals, q) // want `qtlint: use qt.DeepEquals instead of qt.Equals on \*equalsdeep.point, which qt.Equals compares by address`
+}
+
+// Not reported: == compares these the way the test means, or the operand's
+// dynamic type is not known here.
+func TestComparable(t *testing.T) {
+	c := qt.New(t)
+
+	var iface any = []int{1}
+	n := 3
+	c.Assert(point{1, 2}, qt.Equals, point{1, 2})
+	c.Assert(&n, qt.Equals, &n)
+	c.Assert(iface, qt.Equals, iface)
+	c.Assert([2]int{}, qt.Equals, [2]int{})
+	c.Assert([]int(nil), qt.Equals, nil) // want `qtlint: use qt.IsNil instead of qt.Equals, nil`
+}
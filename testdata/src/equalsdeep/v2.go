@@ -0,0 +1,22 @@
+package equalsdeep
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+// go-quicktest/qt's Equals takes any operands, and recovers the panic == makes
+// on a slice, a map or a func as a failure, as frankban/quicktest's does.
+func TestV2(t *testing.T) {
+	got, want := []int{1}, []int{1}
+	qt.Assert(t, qt.Equals(got, want))                                 // want `qtlint: use qt.DeepEquals instead of qt.Equals on \[\]int, which == cannot compare`
+	qt.Check(t, qt.Not(qt.Equals(map[string]int{}, map[string]int{}))) // want `qtlint: use qt.Not\(qt.DeepEquals\) instead of qt.Not\(qt.Equals\) on map\[string\]int, which == cannot compare`
+	f := func() {}
+	qt.Assert(t, qt.Equals(f, f)) // want `qtlint: use qt.DeepEquals instead of qt.Equals on func\(\), which == cannot compare`
+
+	p, q := &point{1, 2}, &point{1, 2}
+	qt.Assert(t, qt.Equals(p, q))        // want `qtlint: use qt.DeepEquals instead of qt.Equals on \*equalsdeep.point, which qt.Equals compares by address`
+	qt.Check(t, qt.Not(qt.Equals(p, q))) // want `qtlint: use qt.Not\(qt.DeepEquals\) instead of qt.Not\(qt.Equals\) on \*equalsdeep.point, which qt.Equals compares by address`
+	qt.Assert(t, qt.Equals(*p, *q))
+}
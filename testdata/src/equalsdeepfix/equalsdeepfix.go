@@ -0,0 +1,18 @@
+package equalsdeepfix
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type point struct{ X, Y int }
+
+func TestFix(t *testing.T) {
+	c := qt.New(t)
+
+	got, want := []string{"a"}, []string{"a"}
+	c.Assert(got, qt.Equals, want)                  // want `qtlint: use qt.DeepEquals instead of qt.Equals on \[\]string, which == cannot compare`
+	c.Check(got, qt.Not(qt.Equals), got[1:])        // want `qtlint: use qt.Not\(qt.DeepEquals\) instead of qt.Not\(qt.Equals\) on \[\]string, which == cannot compare`
+	c.Assert(&point{1, 2}, qt.Equals, &point{1, 2}) // want `qtlint: use qt.DeepEquals instead of qt.Equals on \*equalsdeepfix.point, which qt.Equals compares by address`
+}
@@ -0,0 +1,11 @@
+package equalsdeepfix
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestV2(t *testing.T) {
+	qt.Assert(t, qt.Equals(&point{1, 2}, &point{1, 2})) // want `qtlint: use qt.DeepEquals instead of qt.Equals on \*equalsdeepfix.point, which qt.Equals compares by address`
+}
@@ -0,0 +1,21 @@
+package equalsdeeponlystable
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type point struct{ X, Y int }
+
+func TestOnlyStable(t *testing.T) {
+	c := qt.New(t)
+
+	// Stable: the slices could never have compared equal.
+	c.Assert([]int{1}, qt.Equals, []int{1}) // want `qtlint: use qt.DeepEquals instead of qt.Equals on \[\]int, which == cannot compare`
+
+	// Best-effort: the test may mean that p and q are one point.
+	p := &point{1, 2}
+	q := p
+	c.Assert(p, qt.Equals, q) // want `qtlint: use qt.DeepEquals instead of qt.Equals on \*equalsdeeponlystable.point, which qt.Equals compares by address`
+}
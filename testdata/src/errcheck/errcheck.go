@@ -133,22 +133,22 @@ func TestNoFlagCustomLogger(t *testing.T) {
 	c.Assert(1, qt.Equals, 1)
 }
 
-func TestNoFlagEqualNil(t *testing.T) {
+func TestEqualNil(t *testing.T) {
 	c := qt.New(t)
 
 	err := returnsErr()
-	if err == nil { // no want: wrong operator (== instead of !=)
+	if err == nil { // want `qtlint: use c.Assert\(err, qt.IsNotNil, qt.Commentf\(...\)\) instead of t.Fatal\(...\)`
 		t.Fatal(err)
 	}
 
 	c.Assert(1, qt.Equals, 1)
 }
 
-func TestNoFlagNonErrorType(t *testing.T) {
+func TestNonErrorType(t *testing.T) {
 	c := qt.New(t)
 
 	var p *int
-	if p != nil { // no want: p is not of type error
+	if p != nil { // want `qtlint: use c.Assert\(p, qt.IsNil, qt.Commentf\(...\)\) instead of t.Fatal\(...\)`
 		t.Fatal(p)
 	}
 
@@ -91,13 +91,14 @@ func TestErrorIsDirect(t *testing.T) {
 	qt.Assert(t, err, qt.ErrorAs, &ce)
 }
 
-// Using errors.Is/errors.As with checkers other than qt.IsTrue/qt.IsFalse is not flagged.
+// Using errors.Is/errors.As with checkers other than qt.IsTrue/qt.IsFalse is not
+// flagged by this rule; the checkers themselves have more direct spellings.
 func TestErrorsIsWithOtherCheckers(t *testing.T) {
 	c := qt.New(t)
 	err := makeErr()
 
-	qt.Assert(t, errors.Is(err, ErrSentinel), qt.Equals, true)
-	c.Assert(errors.Is(err, ErrSentinel), qt.DeepEquals, true)
+	qt.Assert(t, errors.Is(err, ErrSentinel), qt.Equals, true) // want `qtlint: use qt.IsTrue instead of qt.Equals, true`
+	c.Assert(errors.Is(err, ErrSentinel), qt.DeepEquals, true) // want `qtlint: use qt.Equals instead of qt.DeepEquals on bool, which == compares`
 }
 
 // User-defined functions named Is/As must not be flagged.
@@ -0,0 +1,28 @@
+package errorisnilfix
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type myError struct{}
+
+func (*myError) Error() string { return "my error" }
+
+func TestErrorIsNil(t *testing.T) {
+	c := qt.New(t)
+	var err error
+
+	c.Assert(err, qt.ErrorIs, nil)                       // want "qtlint: use qt.IsNil instead of qt.ErrorIs, nil"
+	qt.Check(t, err, qt.ErrorIs, nil, qt.Commentf("ok")) // want "qtlint: use qt.IsNil instead of qt.ErrorIs, nil"
+}
+
+// Negative test cases: patterns that should NOT trigger the rule
+func TestErrorIsNilNotMatched(t *testing.T) {
+	c := qt.New(t)
+
+	// A nil *myError is nil to qt.IsNil, but not once it is an error.
+	var p *myError
+	c.Assert(p, qt.ErrorIs, nil)
+}
@@ -0,0 +1,15 @@
+package errorisnilfix
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestErrorIsNilV2(t *testing.T) {
+	var err error
+	var p *myError
+
+	qt.Assert(t, qt.ErrorIs(err, nil)) // want `qtlint: use qt.IsNil\(err\) instead of qt.ErrorIs\(err, nil\)`
+	qt.Assert(t, qt.ErrorIs(p, nil))
+}
@@ -0,0 +1,32 @@
+package errorstring
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type myErr struct{}
+
+func (*myErr) Error() string { return "mine" }
+
+type message struct{}
+
+func (message) Error(n int) string { return "" }
+
+func TestErrorString(t *testing.T) {
+	c := qt.New(t)
+
+	err := errors.New("boom")
+	c.Assert(err.Error(), qt.Equals, "boom")                          // want `qtlint: use err, qt.ErrorMatches instead of err.Error\(\), qt.Equals`
+	c.Check(err.Error(), qt.Contains, "oo")                           // want `qtlint: use err, qt.ErrorMatches instead of err.Error\(\), qt.Contains`
+	qt.Assert(t, err.Error(), qt.Matches, "b.*")                      // want `qtlint: use err, qt.ErrorMatches instead of err.Error\(\), qt.Matches`
+	c.Assert(err.Error(), qt.Not(qt.Equals), "bang")                  // want `qtlint: use err, qt.Not\(qt.ErrorMatches\) instead of err.Error\(\), qt.Not\(qt.Equals\)`
+	c.Assert((&myErr{}).Error(), qt.Equals, "mine", qt.Commentf("x")) // want `qtlint: use err, qt.ErrorMatches instead of err.Error\(\), qt.Equals`
+
+	// Not an error's message.
+	c.Assert(message{}.Error(1), qt.Equals, "")
+	c.Assert(err.Error(), qt.HasLen, 4)
+	c.Assert(err, qt.ErrorMatches, "boom")
+}
@@ -0,0 +1,16 @@
+package errorstring
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestV2(t *testing.T) {
+	err := errors.New("boom")
+	qt.Assert(t, qt.Equals(err.Error(), "boom"))             // want `qtlint: use qt.ErrorMatches\(err, pattern\) instead of qt.Equals\(err.Error\(\), want\)`
+	qt.Check(t, qt.Not(qt.StringContains(err.Error(), "x"))) // want `qtlint: use qt.Not\(qt.ErrorMatches\(err, pattern\)\) instead of qt.Not\(qt.StringContains\(err.Error\(\), want\)\)`
+	qt.Assert(t, qt.Matches(err.Error(), "b.*"))             // want `qtlint: use qt.ErrorMatches\(err, pattern\) instead of qt.Matches\(err.Error\(\), want\)`
+	qt.Assert(t, qt.ErrorMatches(err, "boom"))
+}
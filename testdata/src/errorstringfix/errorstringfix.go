@@ -0,0 +1,28 @@
+package errorstringfix
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestConstants(t *testing.T) {
+	c := qt.New(t)
+
+	err := errors.New("open config.yaml: no such file")
+	c.Assert(err.Error(), qt.Equals, "open config.yaml: no such file")   // want `qtlint: use err, qt.ErrorMatches instead of err.Error\(\), qt.Equals`
+	c.Assert(err.Error(), qt.Contains, "config.yaml")                    // want `qtlint: use err, qt.ErrorMatches instead of err.Error\(\), qt.Contains`
+	c.Assert(err.Error(), qt.Not(qt.Contains), "`quoted`")               // want `qtlint: use err, qt.Not\(qt.ErrorMatches\) instead of err.Error\(\), qt.Not\(qt.Contains\)`
+	c.Assert(err.Error(), qt.Matches, `open .*`, qt.Commentf("opening")) // want `qtlint: use err, qt.ErrorMatches instead of err.Error\(\), qt.Matches`
+	c.Check(errors.Join(err, err).Error(), qt.Equals, "a\nb")            // want `qtlint: use err, qt.ErrorMatches instead of err.Error\(\), qt.Equals`
+}
+
+func TestVariables(t *testing.T) {
+	c := qt.New(t)
+
+	err := errors.New("boom")
+	want := "boom"
+	c.Assert(err.Error(), qt.Equals, want)       // want `qtlint: use err, qt.ErrorMatches instead of err.Error\(\), qt.Equals`
+	c.Assert(err.Error(), qt.Contains, want[1:]) // want `qtlint: use err, qt.ErrorMatches instead of err.Error\(\), qt.Contains`
+}
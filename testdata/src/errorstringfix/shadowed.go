@@ -0,0 +1,17 @@
+package errorstringfix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+// The name regexp is taken where the pattern would use it, so only the
+// constant want is fixed.
+func TestShadowed(t *testing.T) {
+	regexp := "boom"
+	err := errors.New(regexp)
+	qt.Assert(t, qt.Equals(err.Error(), regexp))       // want `qtlint: use qt.ErrorMatches\(err, pattern\) instead of qt.Equals\(err.Error\(\), want\)`
+	qt.Assert(t, qt.StringContains(err.Error(), "o.")) // want `qtlint: use qt.ErrorMatches\(err, pattern\) instead of qt.StringContains\(err.Error\(\), want\)`
+}
@@ -0,0 +1,106 @@
+package failureblock
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type kind int
+
+const (
+	kindA kind = iota
+	kindB
+)
+
+func classify() kind { return kindA }
+
+func TestComparisons(t *testing.T) {
+	c := qt.New(t)
+
+	var got, want string
+	if got != want { // want `qtlint: use c.Assert\(got, qt.Equals, want, qt.Commentf\(...\)\) instead of t.Fatalf\(...\)`
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got == want { // want `qtlint: use c.Check\(got, qt.Not\(qt.Equals\), want, qt.Commentf\(...\)\) instead of t.Error\(...\)`
+		t.Error("expected a difference")
+	}
+	if k := classify(); k != kindB { // want `qtlint: use c.Assert\(k, qt.Equals, kindB, qt.Commentf\(...\)\) instead of t.Fatal\(...\)`
+		t.Fatal("wrong kind")
+	}
+	c.Assert(got, qt.Equals, want)
+}
+
+// An untyped constant compared with an int64 is an int64 there, and an int
+// once boxed for qt.Equals: the assertion would always fail, so it is
+// reported without a fix.
+func TestUntypedConstant(t *testing.T) {
+	c := qt.New(t)
+
+	var n int64
+	if n != 3 { // want `qtlint: use c.Assert\(n, qt.Equals, 3, qt.Commentf\(...\)\) instead of t.Fatalf\(...\)`
+		t.Fatalf("n = %d", n)
+	}
+	c.Assert(n, qt.Equals, int64(3))
+}
+
+func TestPredicates(t *testing.T) {
+	c := qt.New(t)
+
+	var ok bool
+	var xs []int
+	err := errors.New("boom")
+	if !ok { // want `qtlint: use c.Assert\(ok, qt.IsTrue, qt.Commentf\(...\)\) instead of t.Fatal\(...\)`
+		t.Fatal("not ok")
+	}
+	if ok { // want `qtlint: use c.Check\(ok, qt.IsFalse\) instead of t.Error\(...\)`
+		t.Error()
+	}
+	if len(xs) != 3 { // want `qtlint: use c.Assert\(xs, qt.HasLen, 3, qt.Commentf\(...\)\) instead of t.Fatalf\(...\)`
+		t.Fatalf("len(xs) = %d", len(xs))
+	}
+	if !reflect.DeepEqual(xs, []int{1, 2, 3}) { // want `qtlint: use c.Assert\(xs, qt.DeepEquals, \[\]int{1, 2, 3}, qt.Commentf\(...\)\) instead of t.Fatal\(...\)`
+		t.Fatal(xs)
+	}
+	if !errors.Is(err, errBoom) { // want `qtlint: use c.Assert\(err, qt.ErrorIs, errBoom, qt.Commentf\(...\)\) instead of t.Fatal\(...\)`
+		t.Fatal(err)
+	}
+	if strings.Contains(err.Error(), "secret") { // want `qtlint: use c.Check\(err.Error\(\), qt.Not\(qt.Contains\), "secret", qt.Commentf\(...\)\) instead of t.Errorf\(...\)`
+		t.Errorf("leaked: %v", err)
+	}
+	c.Assert(ok, qt.IsTrue)
+}
+
+var errBoom = errors.New("boom")
+
+// Not reported: no checker says what these conditions say, and a condition
+// that is not one of them is not rewritten into qt.IsFalse. Pointers to a
+// struct are compared by address, which qt.Equals is reported for.
+func TestOtherConditions(t *testing.T) {
+	c := qt.New(t)
+
+	var n int
+	var a, b bool
+	if n > 3 {
+		t.Fatal("too many")
+	}
+	if a && b {
+		t.Fatal("both")
+	}
+	if !(n > 3) {
+		t.Fatal("too few")
+	}
+	type config struct{ name string }
+	p1, p2 := &config{}, &config{}
+	if p1 != p2 {
+		t.Fatal("different configs")
+	}
+	if n != 3 {
+		t.Log("retrying")
+		t.Fatal("still wrong")
+	}
+	c.Assert(n, qt.Equals, 0)
+}
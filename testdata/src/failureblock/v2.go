@@ -0,0 +1,26 @@
+package failureblock
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestV2(t *testing.T) {
+	var got, want []string
+	if !reflect.DeepEqual(got, want) { // want `qtlint: use qt.Assert\(t, qt.DeepEquals\(got, want\), qt.Commentf\(...\)\) instead of t.Fatalf\(...\)`
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if len(got) == 0 { // want `qtlint: use qt.Check\(t, qt.Not\(qt.HasLen\(got, 0\)\)\) instead of t.Error\(...\)`
+		t.Error()
+	}
+
+	// reflect.DeepEqual takes any two values; qt.DeepEquals takes one type.
+	// The block is reported without a fix.
+	var raw any
+	if !reflect.DeepEqual(raw, want) { // want `qtlint: use qt.Assert\(t, qt.DeepEquals\(raw, want\)\) instead of t.Fatal\(...\)`
+		t.Fatal()
+	}
+	qt.Assert(t, qt.IsNil(raw))
+}
@@ -0,0 +1,85 @@
+package failureblockfix
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type point struct{ X, Y int }
+
+var errBoom = errors.New("boom")
+
+func TestComparisons(t *testing.T) {
+	c := qt.New(t)
+
+	got, want := "a", "b"
+	if got != want { // want `qtlint: use c\.Assert\(got, qt\.Equals, want, qt\.Commentf\(\.\.\.\)\) instead of t\.Fatalf\(\.\.\.\)`
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	var n int
+	if n == 3 { // want `qtlint: use c\.Check\(n, qt\.Not\(qt\.Equals\), 3, qt\.Commentf\(\.\.\.\)\) instead of t\.Error\(\.\.\.\)`
+		t.Error("n is 3")
+	}
+	// A constant expression built on a typed constant is typed, and boxes as
+	// its own type.
+	var d time.Duration
+	if d != 2*time.Second { // want `qtlint: use c\.Assert\(d, qt\.Equals, 2 \* time\.Second, qt\.Commentf\(\.\.\.\)\) instead of t\.Fatalf\(\.\.\.\)`
+		t.Fatalf("d = %v", d)
+	}
+	c.Assert(got, qt.Not(qt.Equals), want)
+}
+
+func TestNil(t *testing.T) {
+	c := qt.New(t)
+
+	var p *point
+	err := errors.New("boom")
+	if err == nil { // want `qtlint: use c\.Assert\(err, qt\.IsNotNil, qt\.Commentf\(\.\.\.\)\) instead of t\.Fatal\(\.\.\.\)`
+		t.Fatal("expected an error")
+	}
+	if p == nil { // want `qtlint: use c\.Assert\(p, qt\.IsNotNil, qt\.Commentf\(\.\.\.\)\) instead of t\.Fatal\(\.\.\.\)`
+		t.Fatal(p)
+	}
+	c.Assert(err, qt.IsNotNil)
+}
+
+func TestPredicates(t *testing.T) {
+	c := qt.New(t)
+
+	ok := true
+	xs := []int{1, 2, 3}
+	err := errBoom
+	if !ok { // want `qtlint: use c\.Assert\(ok, qt\.IsTrue, qt\.Commentf\(\.\.\.\)\) instead of t\.Fatal\(\.\.\.\)`
+		t.Fatal("not ok")
+	}
+	if len(xs) != 3 { // want `qtlint: use c\.Check\(xs, qt\.HasLen, 3, qt\.Commentf\(\.\.\.\)\) instead of t\.Errorf\(\.\.\.\)`
+		t.Errorf("len(xs) = %d", len(xs))
+	}
+	if !reflect.DeepEqual(point{1, 2}, point{X: 1, Y: 2}) { // want `qtlint: use c\.Assert\(point\{1, 2\}, qt\.DeepEquals, point\{X: 1, Y: 2\}, qt\.Commentf\(\.\.\.\)\) instead of t\.Fatal\(\.\.\.\)`
+		t.Fatal("points differ")
+	}
+	if !errors.Is(err, errBoom) { // want `qtlint: use c\.Assert\(err, qt\.ErrorIs, errBoom, qt\.Commentf\(\.\.\.\)\) instead of t\.Fatal\(\.\.\.\)`
+		t.Fatal(err)
+	}
+	if !slices.Contains(xs, 2) { // want `qtlint: use c\.Check\(xs, qt\.Contains, 2\) instead of t\.Error\(\.\.\.\)`
+		t.Error()
+	}
+	c.Assert(ok, qt.IsTrue)
+}
+
+// Without a fix: boxed as an interface the 3 would be an int, not the int64
+// the comparison made of it.
+func TestUntypedConstant(t *testing.T) {
+	c := qt.New(t)
+
+	var n int64
+	if n != 3 { // want `qtlint: use c\.Assert\(n, qt\.Equals, 3, qt\.Commentf\(\.\.\.\)\) instead of t\.Fatal\(\.\.\.\)`
+		t.Fatal("wrong")
+	}
+	c.Assert(n, qt.Equals, int64(0))
+}
@@ -0,0 +1,20 @@
+package failureblockfix
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestV2(t *testing.T) {
+	var n int64
+	if n != 3 { // want `qtlint: use qt\.Assert\(t, qt\.Equals\(n, 3\), qt\.Commentf\(\.\.\.\)\) instead of t\.Fatalf\(\.\.\.\)`
+		t.Fatalf("n = %d", n)
+	}
+	s := "hello"
+	if !strings.Contains(s, "ell") { // want `qtlint: use qt\.Check\(t, qt\.StringContains\(s, "ell"\), qt\.Commentf\(\.\.\.\)\) instead of t\.Error\(\.\.\.\)`
+		t.Error(s)
+	}
+	qt.Assert(t, qt.IsTrue(true))
+}
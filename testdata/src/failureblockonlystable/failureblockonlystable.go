@@ -0,0 +1,44 @@
+package failureblockonlystable
+
+import (
+	"reflect"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type secret struct{ n int }
+
+// Unstable: qt.DeepEquals compares with go-cmp, which fails on the unexported
+// field reflect.DeepEqual compares.
+func TestUnexported(t *testing.T) {
+	c := qt.New(t)
+
+	if !reflect.DeepEqual(secret{1}, secret{1}) { // want `qtlint: use c\.Assert\(secret\{1\}, qt\.DeepEquals, secret\{1\}, qt\.Commentf\(\.\.\.\)\) instead of t\.Fatal\(\.\.\.\)`
+		t.Fatal("secrets differ")
+	}
+	c.Assert(1, qt.Equals, 1)
+}
+
+// Unstable: qt.IsNil calls an interface holding a nil pointer nil, where
+// v == nil does not.
+func TestInterfaceNil(t *testing.T) {
+	c := qt.New(t)
+
+	var v any
+	if v != nil { // want `qtlint: use c\.Assert\(v, qt\.IsNil, qt\.Commentf\(\.\.\.\)\) instead of t\.Fatal\(\.\.\.\)`
+		t.Fatal(v)
+	}
+	c.Assert(1, qt.Equals, 1)
+}
+
+// Stable: a literal format and a plain bool.
+func TestStable(t *testing.T) {
+	c := qt.New(t)
+
+	var ok bool
+	if ok { // want `qtlint: use c\.Assert\(ok, qt\.IsFalse, qt\.Commentf\(\.\.\.\)\) instead of t\.Fatalf\(\.\.\.\)`
+		t.Fatalf("ok = %v", ok)
+	}
+	c.Assert(1, qt.Equals, 1)
+}
@@ -105,18 +105,52 @@ type Checker interface{}
 
 // Checkers
 var (
-	IsNil      Checker
-	IsNotNil   Checker
-	IsTrue     Checker
-	IsFalse    Checker
-	Equals     Checker
-	DeepEquals Checker
-	HasLen     Checker
-	Contains   Checker
-	ErrorIs    Checker
-	ErrorAs    Checker
+	IsNil         Checker
+	IsNotNil      Checker
+	IsTrue        Checker
+	IsFalse       Checker
+	Equals        Checker
+	DeepEquals    Checker
+	ContentEquals Checker
+	HasLen        Checker
+	Contains      Checker
+	Matches       Checker
+	ErrorMatches  Checker
+	PanicMatches  Checker
+	Implements    Checker
+	Satisfies     Checker
+	JSONEquals    Checker
+	ErrorIs       Checker
+	ErrorAs       Checker
 )
 
+// CmpEquals returns a Checker checking equality of two arbitrary values
+// according to the provided compare options.
+func CmpEquals(opts ...any) Checker {
+	return nil
+}
+
+// CodecEquals returns a checker that checks for codec value equivalence.
+func CodecEquals(
+	marshal func(interface{}) ([]byte, error),
+	unmarshal func([]byte, interface{}) error,
+	opts ...any,
+) Checker {
+	return nil
+}
+
+// All returns a Checker that uses the given checker to check elements of
+// slice or array or the values of a map.
+func All(c Checker) Checker {
+	return nil
+}
+
+// Any returns a Checker that uses the given checker to check elements of a
+// slice or array or the values from a map.
+func Any(c Checker) Checker {
+	return nil
+}
+
 // Not returns a Checker negating the given Checker.
 func Not(checker Checker) Checker {
 	return nil
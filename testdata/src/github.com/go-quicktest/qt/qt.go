@@ -0,0 +1,148 @@
+// Package qt is a stub for testing purposes.
+// This is not the real go-quicktest/qt package.
+//
+// The generic API differs from frankban/quicktest in the one way every rule
+// has to know about: a checker is a call that owns its arguments, so
+// qt.Assert(t, qt.Equals(got, want)) carries got and want inside the checker
+// rather than beside it. Signatures are copied from go-quicktest/qt v1.101.0,
+// with the cmp.Option parameters reduced to any so that the stub needs no
+// dependency of its own.
+package qt
+
+import (
+	"regexp"
+	"testing"
+)
+
+// Checker is implemented by types used as part of Check/Assert invocations.
+type Checker interface{}
+
+// Comment represents additional information on a check or an assertion which
+// is displayed when the check or assertion fails.
+type Comment struct{}
+
+// Commentf returns a test comment whose output is formatted according to the
+// given format specifier and args.
+func Commentf(format string, args ...any) Comment {
+	return Comment{}
+}
+
+// Assert checks that the provided argument passes the given check and calls
+// t.Fatal otherwise.
+func Assert(t testing.TB, checker Checker, comments ...Comment) {}
+
+// Check checks that the provided argument passes the given check and calls
+// t.Error otherwise.
+func Check(t testing.TB, checker Checker, comments ...Comment) bool {
+	return true
+}
+
+// Patch sets a variable to a temporary value for the duration of the test.
+func Patch[T any](tb testing.TB, dest *T, value T) {}
+
+// Equals returns a Checker checking equality of two comparable values.
+func Equals[T any](got, want T) Checker { return nil }
+
+// DeepEquals returns a Checker checking equality of two values using
+// cmp.DeepEqual.
+func DeepEquals[T any](got, want T) Checker { return nil }
+
+// CmpEquals is like DeepEquals but allows custom compare options.
+func CmpEquals[T any](got, want T, opts ...any) Checker { return nil }
+
+// ErrorMatches returns a Checker checking that the provided value is an error
+// whose message matches the provided regular expression pattern.
+func ErrorMatches[StringOrRegexp string | *regexp.Regexp](got error, want StringOrRegexp) Checker {
+	return nil
+}
+
+// PanicMatches returns a Checker checking that the provided function panics
+// with a message matching the provided regular expression pattern.
+func PanicMatches[StringOrRegexp string | *regexp.Regexp](f func(), want StringOrRegexp) Checker {
+	return nil
+}
+
+// Matches returns a Checker checking that the provided string matches the
+// provided regular expression pattern.
+func Matches[StringOrRegexp string | *regexp.Regexp](got string, want StringOrRegexp) Checker {
+	return nil
+}
+
+// CodecEquals returns a Checker that checks for codec value equivalence.
+func CodecEquals[T any](
+	got T,
+	want any,
+	marshal func(any) ([]byte, error),
+	unmarshal func([]byte, any) error,
+	opts ...any,
+) Checker {
+	return nil
+}
+
+// IsNil returns a Checker checking that the provided value is equal to nil.
+func IsNil(got any) Checker { return nil }
+
+// IsNotNil returns a Checker checking that the provided value is not nil.
+func IsNotNil(got any) Checker { return nil }
+
+// HasLen returns a Checker checking that the provided value has the given
+// length.
+func HasLen(got any, n int) Checker { return nil }
+
+// Implements returns a Checker checking that the provided value implements
+// the interface specified by the type parameter.
+func Implements[I any](got any) Checker { return nil }
+
+// Satisfies returns a Checker checking that the provided value, when used as
+// argument of the provided predicate function, causes the function to return
+// true.
+func Satisfies[T any](got T, f func(T) bool) Checker { return nil }
+
+// IsTrue returns a Checker checking that the provided value is true.
+func IsTrue[T ~bool](got T) Checker { return nil }
+
+// IsFalse returns a Checker checking that the provided value is false.
+func IsFalse[T ~bool](got T) Checker { return nil }
+
+// Not returns a Checker negating the given Checker.
+func Not(c Checker) Checker { return nil }
+
+// StringContains returns a Checker checking that the given string contains
+// the given substring.
+func StringContains[T ~string](got, substr T) Checker { return nil }
+
+// SliceContains returns a Checker that succeeds if the given slice contains
+// the given element, by comparing for equality.
+func SliceContains[T any](container []T, elem T) Checker { return nil }
+
+// MapContains returns a Checker that succeeds if the given value is contained
+// in the values of the given map, by comparing for equality.
+func MapContains[K comparable, V any](container map[K]V, elem V) Checker { return nil }
+
+// SliceAny returns a Checker that uses the given checker to check elements of
+// a slice. It succeeds if f(v) passes the check for any v in the slice.
+func SliceAny[T any](container []T, f func(elem T) Checker) Checker { return nil }
+
+// SliceAll returns a Checker that uses the given checker to check elements of
+// a slice. It succeeds if all elements pass the check.
+func SliceAll[T any](container []T, f func(elem T) Checker) Checker { return nil }
+
+// MapAny returns a Checker that uses checkers returned by f to check values
+// of a map. It succeeds if f(v) passes the check for any value v in the map.
+func MapAny[K comparable, V any](container map[K]V, f func(elem V) Checker) Checker { return nil }
+
+// MapAll returns a Checker that uses checkers returned by f to check values
+// of a map. It succeeds if f(v) passes the check for all values v in the map.
+func MapAll[K comparable, V any](container map[K]V, f func(elem V) Checker) Checker { return nil }
+
+// JSONEquals returns a Checker that checks whether a string or byte slice is
+// JSON-equivalent to a Go value.
+func JSONEquals[T []byte | string](got T, want any) Checker { return nil }
+
+// ErrorAs returns a Checker checking that the error is or wraps a specific
+// error type.
+func ErrorAs[T any](got error, want *T) Checker { return nil }
+
+// ErrorIs returns a Checker that checks that the error is or wraps a specific
+// error value.
+func ErrorIs(got, want error) Checker { return nil }
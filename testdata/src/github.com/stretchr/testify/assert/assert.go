@@ -0,0 +1,265 @@
+// Package assert is a stub for testing purposes.
+// This is not the real testify package.
+//
+// Signatures are copied from testify v1.9.0, reduced to the functions the
+// fixtures call. Every assertion takes a TestingT first, which is what tells
+// an assertion apart from the package's helpers.
+package assert
+
+// TestingT is an interface wrapper around *testing.T.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// PanicTestFunc defines a func that should be passed to Panics.
+type PanicTestFunc func()
+
+// Assertions provides assertion methods around the TestingT interface.
+type Assertions struct {
+	t TestingT
+}
+
+// New makes a new Assertions object for the specified TestingT.
+func New(t TestingT) *Assertions {
+	return &Assertions{t: t}
+}
+
+// Equal asserts that two objects are equal.
+func (a *Assertions) Equal(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// ObjectsAreEqual determines if two objects are considered equal.
+func ObjectsAreEqual(expected, actual interface{}) bool {
+	return true
+}
+
+// NoError is a stub of testify's assert.NoError.
+func NoError(t TestingT, err error, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// NoErrorf is a stub of testify's assert.NoErrorf.
+func NoErrorf(t TestingT, err error, msg string, args ...interface{}) bool {
+	return true
+}
+
+// Error is a stub of testify's assert.Error.
+func Error(t TestingT, err error, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// Errorf is a stub of testify's assert.Errorf.
+func Errorf(t TestingT, err error, msg string, args ...interface{}) bool {
+	return true
+}
+
+// Nil is a stub of testify's assert.Nil.
+func Nil(t TestingT, object interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// Nilf is a stub of testify's assert.Nilf.
+func Nilf(t TestingT, object interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+
+// NotNil is a stub of testify's assert.NotNil.
+func NotNil(t TestingT, object interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// NotNilf is a stub of testify's assert.NotNilf.
+func NotNilf(t TestingT, object interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+
+// True is a stub of testify's assert.True.
+func True(t TestingT, value bool, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// Truef is a stub of testify's assert.Truef.
+func Truef(t TestingT, value bool, msg string, args ...interface{}) bool {
+	return true
+}
+
+// False is a stub of testify's assert.False.
+func False(t TestingT, value bool, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// Falsef is a stub of testify's assert.Falsef.
+func Falsef(t TestingT, value bool, msg string, args ...interface{}) bool {
+	return true
+}
+
+// Equal is a stub of testify's assert.Equal.
+func Equal(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// Equalf is a stub of testify's assert.Equalf.
+func Equalf(t TestingT, expected, actual interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+
+// NotEqual is a stub of testify's assert.NotEqual.
+func NotEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// NotEqualf is a stub of testify's assert.NotEqualf.
+func NotEqualf(t TestingT, expected, actual interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+
+// Len is a stub of testify's assert.Len.
+func Len(t TestingT, object interface{}, length int, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// Lenf is a stub of testify's assert.Lenf.
+func Lenf(t TestingT, object interface{}, length int, msg string, args ...interface{}) bool {
+	return true
+}
+
+// Empty is a stub of testify's assert.Empty.
+func Empty(t TestingT, object interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// Emptyf is a stub of testify's assert.Emptyf.
+func Emptyf(t TestingT, object interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+
+// NotEmpty is a stub of testify's assert.NotEmpty.
+func NotEmpty(t TestingT, object interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// NotEmptyf is a stub of testify's assert.NotEmptyf.
+func NotEmptyf(t TestingT, object interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+
+// Contains is a stub of testify's assert.Contains.
+func Contains(t TestingT, s, contains interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// Containsf is a stub of testify's assert.Containsf.
+func Containsf(t TestingT, s, contains interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+
+// NotContains is a stub of testify's assert.NotContains.
+func NotContains(t TestingT, s, contains interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// NotContainsf is a stub of testify's assert.NotContainsf.
+func NotContainsf(t TestingT, s, contains interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+
+// ErrorIs is a stub of testify's assert.ErrorIs.
+func ErrorIs(t TestingT, err, target error, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// ErrorIsf is a stub of testify's assert.ErrorIsf.
+func ErrorIsf(t TestingT, err, target error, msg string, args ...interface{}) bool {
+	return true
+}
+
+// NotErrorIs is a stub of testify's assert.NotErrorIs.
+func NotErrorIs(t TestingT, err, target error, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// NotErrorIsf is a stub of testify's assert.NotErrorIsf.
+func NotErrorIsf(t TestingT, err, target error, msg string, args ...interface{}) bool {
+	return true
+}
+
+// ErrorAs is a stub of testify's assert.ErrorAs.
+func ErrorAs(t TestingT, err error, target interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// ErrorAsf is a stub of testify's assert.ErrorAsf.
+func ErrorAsf(t TestingT, err error, target interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+
+// EqualError is a stub of testify's assert.EqualError.
+func EqualError(t TestingT, theError error, errString string, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// EqualErrorf is a stub of testify's assert.EqualErrorf.
+func EqualErrorf(t TestingT, theError error, errString string, msg string, args ...interface{}) bool {
+	return true
+}
+
+// ErrorContains is a stub of testify's assert.ErrorContains.
+func ErrorContains(t TestingT, theError error, contains string, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// ErrorContainsf is a stub of testify's assert.ErrorContainsf.
+func ErrorContainsf(t TestingT, theError error, contains string, msg string, args ...interface{}) bool {
+	return true
+}
+
+// Greater is a stub of testify's assert.Greater.
+func Greater(t TestingT, e1, e2 interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// Greaterf is a stub of testify's assert.Greaterf.
+func Greaterf(t TestingT, e1, e2 interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+
+// JSONEq is a stub of testify's assert.JSONEq.
+func JSONEq(t TestingT, expected, actual string, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// JSONEqf is a stub of testify's assert.JSONEqf.
+func JSONEqf(t TestingT, expected, actual string, msg string, args ...interface{}) bool {
+	return true
+}
+
+// Panics is a stub of testify's assert.Panics.
+func Panics(t TestingT, f PanicTestFunc, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// Panicsf is a stub of testify's assert.Panicsf.
+func Panicsf(t TestingT, f PanicTestFunc, msg string, args ...interface{}) bool {
+	return true
+}
+
+// Regexp is a stub of testify's assert.Regexp.
+func Regexp(t TestingT, rx interface{}, str interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// Regexpf is a stub of testify's assert.Regexpf.
+func Regexpf(t TestingT, rx interface{}, str interface{}, msg string, args ...interface{}) bool {
+	return true
+}
+
+// Zero is a stub of testify's assert.Zero.
+func Zero(t TestingT, i interface{}, msgAndArgs ...interface{}) bool {
+	return true
+}
+
+// Zerof is a stub of testify's assert.Zerof.
+func Zerof(t TestingT, i interface{}, msg string, args ...interface{}) bool {
+	return true
+}
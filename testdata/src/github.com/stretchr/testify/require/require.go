@@ -0,0 +1,155 @@
+// Package require is a stub for testing purposes.
+// This is not the real testify package.
+//
+// Signatures are copied from testify v1.9.0, reduced to the functions the
+// fixtures call. The require variants return nothing: they stop the test.
+package require
+
+import "github.com/stretchr/testify/assert"
+
+// TestingT is an interface wrapper around *testing.T.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	FailNow()
+}
+
+// PanicTestFunc defines a func that should be passed to Panics.
+type PanicTestFunc = assert.PanicTestFunc
+
+// NoError is a stub of testify's require.NoError.
+func NoError(t TestingT, err error, msgAndArgs ...interface{}) {}
+
+// NoErrorf is a stub of testify's require.NoErrorf.
+func NoErrorf(t TestingT, err error, msg string, args ...interface{}) {}
+
+// Error is a stub of testify's require.Error.
+func Error(t TestingT, err error, msgAndArgs ...interface{}) {}
+
+// Errorf is a stub of testify's require.Errorf.
+func Errorf(t TestingT, err error, msg string, args ...interface{}) {}
+
+// Nil is a stub of testify's require.Nil.
+func Nil(t TestingT, object interface{}, msgAndArgs ...interface{}) {}
+
+// Nilf is a stub of testify's require.Nilf.
+func Nilf(t TestingT, object interface{}, msg string, args ...interface{}) {}
+
+// NotNil is a stub of testify's require.NotNil.
+func NotNil(t TestingT, object interface{}, msgAndArgs ...interface{}) {}
+
+// NotNilf is a stub of testify's require.NotNilf.
+func NotNilf(t TestingT, object interface{}, msg string, args ...interface{}) {}
+
+// True is a stub of testify's require.True.
+func True(t TestingT, value bool, msgAndArgs ...interface{}) {}
+
+// Truef is a stub of testify's require.Truef.
+func Truef(t TestingT, value bool, msg string, args ...interface{}) {}
+
+// False is a stub of testify's require.False.
+func False(t TestingT, value bool, msgAndArgs ...interface{}) {}
+
+// Falsef is a stub of testify's require.Falsef.
+func Falsef(t TestingT, value bool, msg string, args ...interface{}) {}
+
+// Equal is a stub of testify's require.Equal.
+func Equal(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {}
+
+// Equalf is a stub of testify's require.Equalf.
+func Equalf(t TestingT, expected, actual interface{}, msg string, args ...interface{}) {}
+
+// NotEqual is a stub of testify's require.NotEqual.
+func NotEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {}
+
+// NotEqualf is a stub of testify's require.NotEqualf.
+func NotEqualf(t TestingT, expected, actual interface{}, msg string, args ...interface{}) {}
+
+// Len is a stub of testify's require.Len.
+func Len(t TestingT, object interface{}, length int, msgAndArgs ...interface{}) {}
+
+// Lenf is a stub of testify's require.Lenf.
+func Lenf(t TestingT, object interface{}, length int, msg string, args ...interface{}) {}
+
+// Empty is a stub of testify's require.Empty.
+func Empty(t TestingT, object interface{}, msgAndArgs ...interface{}) {}
+
+// Emptyf is a stub of testify's require.Emptyf.
+func Emptyf(t TestingT, object interface{}, msg string, args ...interface{}) {}
+
+// NotEmpty is a stub of testify's require.NotEmpty.
+func NotEmpty(t TestingT, object interface{}, msgAndArgs ...interface{}) {}
+
+// NotEmptyf is a stub of testify's require.NotEmptyf.
+func NotEmptyf(t TestingT, object interface{}, msg string, args ...interface{}) {}
+
+// Contains is a stub of testify's require.Contains.
+func Contains(t TestingT, s, contains interface{}, msgAndArgs ...interface{}) {}
+
+// Containsf is a stub of testify's require.Containsf.
+func Containsf(t TestingT, s, contains interface{}, msg string, args ...interface{}) {}
+
+// NotContains is a stub of testify's require.NotContains.
+func NotContains(t TestingT, s, contains interface{}, msgAndArgs ...interface{}) {}
+
+// NotContainsf is a stub of testify's require.NotContainsf.
+func NotContainsf(t TestingT, s, contains interface{}, msg string, args ...interface{}) {}
+
+// ErrorIs is a stub of testify's require.ErrorIs.
+func ErrorIs(t TestingT, err, target error, msgAndArgs ...interface{}) {}
+
+// ErrorIsf is a stub of testify's require.ErrorIsf.
+func ErrorIsf(t TestingT, err, target error, msg string, args ...interface{}) {}
+
+// NotErrorIs is a stub of testify's require.NotErrorIs.
+func NotErrorIs(t TestingT, err, target error, msgAndArgs ...interface{}) {}
+
+// NotErrorIsf is a stub of testify's require.NotErrorIsf.
+func NotErrorIsf(t TestingT, err, target error, msg string, args ...interface{}) {}
+
+// ErrorAs is a stub of testify's require.ErrorAs.
+func ErrorAs(t TestingT, err error, target interface{}, msgAndArgs ...interface{}) {}
+
+// ErrorAsf is a stub of testify's require.ErrorAsf.
+func ErrorAsf(t TestingT, err error, target interface{}, msg string, args ...interface{}) {}
+
+// EqualError is a stub of testify's require.EqualError.
+func EqualError(t TestingT, theError error, errString string, msgAndArgs ...interface{}) {}
+
+// EqualErrorf is a stub of testify's require.EqualErrorf.
+func EqualErrorf(t TestingT, theError error, errString string, msg string, args ...interface{}) {}
+
+// ErrorContains is a stub of testify's require.ErrorContains.
+func ErrorContains(t TestingT, theError error, contains string, msgAndArgs ...interface{}) {}
+
+// ErrorContainsf is a stub of testify's require.ErrorContainsf.
+func ErrorContainsf(t TestingT, theError error, contains string, msg string, args ...interface{}) {}
+
+// Greater is a stub of testify's require.Greater.
+func Greater(t TestingT, e1, e2 interface{}, msgAndArgs ...interface{}) {}
+
+// Greaterf is a stub of testify's require.Greaterf.
+func Greaterf(t TestingT, e1, e2 interface{}, msg string, args ...interface{}) {}
+
+// JSONEq is a stub of testify's require.JSONEq.
+func JSONEq(t TestingT, expected, actual string, msgAndArgs ...interface{}) {}
+
+// JSONEqf is a stub of testify's require.JSONEqf.
+func JSONEqf(t TestingT, expected, actual string, msg string, args ...interface{}) {}
+
+// Panics is a stub of testify's require.Panics.
+func Panics(t TestingT, f PanicTestFunc, msgAndArgs ...interface{}) {}
+
+// Panicsf is a stub of testify's require.Panicsf.
+func Panicsf(t TestingT, f PanicTestFunc, msg string, args ...interface{}) {}
+
+// Regexp is a stub of testify's require.Regexp.
+func Regexp(t TestingT, rx interface{}, str interface{}, msgAndArgs ...interface{}) {}
+
+// Regexpf is a stub of testify's require.Regexpf.
+func Regexpf(t TestingT, rx interface{}, str interface{}, msg string, args ...interface{}) {}
+
+// Zero is a stub of testify's require.Zero.
+func Zero(t TestingT, i interface{}, msgAndArgs ...interface{}) {}
+
+// Zerof is a stub of testify's require.Zerof.
+func Zerof(t TestingT, i interface{}, msg string, args ...interface{}) {}
@@ -0,0 +1,25 @@
+// Package errgroup is a stub for testing purposes.
+// This is not the real golang.org/x/sync/errgroup package.
+package errgroup
+
+import "context"
+
+// A Group is a collection of goroutines working on subtasks that are part of
+// the same overall task.
+type Group struct{}
+
+// WithContext returns a new Group and an associated Context derived from ctx.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	return &Group{}, ctx
+}
+
+// Go calls the given function in a new goroutine.
+func (g *Group) Go(f func() error) {}
+
+// TryGo calls the given function in a new goroutine only if the number of
+// active goroutines in the group is currently below the configured limit.
+func (g *Group) TryGo(f func() error) bool { return true }
+
+// Wait blocks until all function calls from the Go method have returned, then
+// returns the first non-nil error (if any) from them.
+func (g *Group) Wait() error { return nil }
@@ -0,0 +1,105 @@
+// Package check is a stub for testing purposes.
+// This is not the real gocheck package.
+//
+// Signatures are copied from gopkg.in/check.v1, reduced to what the fixtures
+// use: the *C methods a test calls, the checkers, and the suite machinery.
+package check
+
+import "testing"
+
+// C is the gocheck test context.
+type C struct{}
+
+// Assert runs the given check and stops the test in case of failure.
+func (c *C) Assert(obtained interface{}, checker Checker, args ...interface{}) {}
+
+// Check runs the given check and continues the test in case of failure.
+func (c *C) Check(obtained interface{}, checker Checker, args ...interface{}) bool {
+	return true
+}
+
+// Error logs an error and marks the test as failed.
+func (c *C) Error(args ...interface{}) {}
+
+// Errorf logs a formatted error and marks the test as failed.
+func (c *C) Errorf(format string, args ...interface{}) {}
+
+// Fail marks the test as failed.
+func (c *C) Fail() {}
+
+// FailNow marks the test as failed and stops it.
+func (c *C) FailNow() {}
+
+// Failed reports whether the test has failed.
+func (c *C) Failed() bool { return false }
+
+// Fatal logs an error and stops the test.
+func (c *C) Fatal(args ...interface{}) {}
+
+// Fatalf logs a formatted error and stops the test.
+func (c *C) Fatalf(format string, args ...interface{}) {}
+
+// Log logs its arguments.
+func (c *C) Log(args ...interface{}) {}
+
+// Logf logs a formatted message.
+func (c *C) Logf(format string, args ...interface{}) {}
+
+// MkDir makes a temporary directory removed when the suite finishes.
+func (c *C) MkDir() string { return "" }
+
+// Skip skips the test.
+func (c *C) Skip(reason string) {}
+
+// Skipf skips the test with a formatted reason.
+func (c *C) Skipf(format string, args ...interface{}) {}
+
+// Succeed marks the test as having succeeded.
+func (c *C) Succeed() {}
+
+// TestName returns the name of the suite method being run.
+func (c *C) TestName() string { return "" }
+
+// Checker is implemented by the checkers an assertion takes.
+type Checker interface {
+	Info() *CheckerInfo
+	Check(params []interface{}, names []string) (result bool, error string)
+}
+
+// CheckerInfo describes a checker.
+type CheckerInfo struct {
+	Name   string
+	Params []string
+}
+
+// The checkers gocheck provides.
+var (
+	Equals       Checker
+	DeepEquals   Checker
+	IsNil        Checker
+	NotNil       Checker
+	HasLen       Checker
+	ErrorMatches Checker
+	Matches      Checker
+	PanicMatches Checker
+	Panics       Checker
+	FitsTypeOf   Checker
+	Implements   Checker
+)
+
+// Not returns a checker inverting the result of checker.
+func Not(checker Checker) Checker { return nil }
+
+// CommentInterface is implemented by assertion comments.
+type CommentInterface interface {
+	CheckCommentString() string
+}
+
+// Commentf returns a comment with the given formatted message.
+func Commentf(format string, args ...interface{}) CommentInterface { return nil }
+
+// Suite registers the given value as a test suite.
+func Suite(suite interface{}) interface{} { return suite }
+
+// TestingT runs all registered suites from a go test.
+func TestingT(testingT *testing.T) {}
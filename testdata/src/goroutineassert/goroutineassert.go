@@ -0,0 +1,126 @@
+package goroutineassert
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"golang.org/x/sync/errgroup"
+)
+
+func work() error { return errors.New("boom") }
+
+// An Assert in a go statement's function literal.
+func TestGoStatement(t *testing.T) {
+	c := qt.New(t)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Assert(work(), qt.IsNil) // want `qtlint: c.Assert in a goroutine stops the test with t.FailNow, which must be called from the test goroutine; use c.Check`
+	}()
+	<-done
+
+	go func() {
+		err := work()
+		c.Assert(err, qt.IsNil) // want `qtlint: c.Assert in a goroutine stops the test with t.FailNow, which must be called from the test goroutine; use c.Check`
+		done <- struct{}{}
+	}()
+	<-done
+
+	go func() {
+		qt.Assert(t, work(), qt.IsNil) // want `qtlint: qt.Assert in a goroutine stops the test with t.FailNow, which must be called from the test goroutine; use qt.Check`
+	}()
+
+	go func() {
+		if !c.Assert(work(), qt.IsNil) { // want `qtlint: c.Assert in a goroutine stops the test with t.FailNow, which must be called from the test goroutine; use c.Check; no fix: the result of Assert is used`
+			return
+		}
+	}()
+
+	go c.Assert(work(), qt.IsNil)     // want `qtlint: c.Assert in a goroutine stops the test with t.FailNow, which must be called from the test goroutine; use c.Check; no fix: nothing waits for a call started by go, and Check after the test has ended panics`
+	go qt.Assert(t, work(), qt.IsNil) // want `qtlint: qt.Assert in a goroutine stops the test with t.FailNow, which must be called from the test goroutine; use qt.Check; no fix: nothing waits for a call started by go, and Check after the test has ended panics`
+}
+
+// An Assert in a function run by errgroup.Group.Go or sync.WaitGroup.Go.
+func TestGroups(t *testing.T) {
+	c := qt.New(t)
+	g, _ := errgroup.WithContext(context.Background())
+	g.Go(func() error {
+		c.Assert(work(), qt.IsNil) // want `qtlint: c.Assert in a goroutine stops the test with t.FailNow, which must be called from the test goroutine; use c.Check`
+		return nil
+	})
+	c.Assert(g.Wait(), qt.IsNil)
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		c.Assert(work(), qt.IsNil) // want `qtlint: c.Assert in a goroutine stops the test with t.FailNow, which must be called from the test goroutine; use c.Check`
+	})
+	wg.Wait()
+}
+
+func checkWork(c *qt.C) {
+	c.Assert(work(), qt.IsNil)
+}
+
+func checkWorkVia(c *qt.C) {
+	checkWork(c)
+}
+
+func checkWorkPackage(c *qt.C) {
+	qt.Assert(c, work(), qt.IsNil)
+}
+
+func checkWorkGently(c *qt.C) {
+	c.Check(work(), qt.IsNil)
+}
+
+// A *qt.C handed to a helper that asserts with it.
+func TestHelpers(t *testing.T) {
+	c := qt.New(t)
+	var wg sync.WaitGroup
+	wg.Add(4)
+	go checkWork(c) // want `qtlint: checkWork calls c.Assert in a goroutine, and t.FailNow must be called from the test goroutine; no fix: the helper may also be called from the test goroutine`
+	go func() {
+		defer wg.Done()
+		checkWorkVia(c) // want `qtlint: checkWorkVia calls c.Assert in a goroutine, and t.FailNow must be called from the test goroutine; no fix: the helper may also be called from the test goroutine`
+	}()
+	go func() {
+		defer wg.Done()
+		checkWorkPackage(c) // want `qtlint: checkWorkPackage calls c.Assert in a goroutine, and t.FailNow must be called from the test goroutine; no fix: the helper may also be called from the test goroutine`
+	}()
+	go func() {
+		defer wg.Done()
+		checkWorkGently(c)
+	}()
+	go checkWorkGently(c)
+	wg.Wait()
+
+	// On the test goroutine, the helpers are fine.
+	checkWork(c)
+}
+
+// Asserts that are not made from a goroutine the test started.
+func TestNotInGoroutine(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(work(), qt.IsNotNil)
+
+	go func() {
+		c.Check(work(), qt.IsNotNil)
+	}()
+
+	go func() {
+		c.Run("sub", func(c *qt.C) {
+			c.Assert(work(), qt.IsNotNil)
+		})
+		t.Run("sub", func(t *testing.T) {
+			qt.Assert(t, work(), qt.IsNotNil)
+		})
+	}()
+
+	f := func() {
+		c.Assert(work(), qt.IsNotNil)
+	}
+	f()
+}
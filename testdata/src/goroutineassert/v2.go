@@ -0,0 +1,16 @@
+package goroutineassert
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestV2(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		qt.Assert(t, qt.IsNil(work())) // want `qtlint: qt.Assert in a goroutine stops the test with t.FailNow, which must be called from the test goroutine; use qt.Check`
+	}()
+	<-done
+}
@@ -0,0 +1,30 @@
+package goroutineassertonlystable
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func work() (int, error) { return 0, errors.New("boom") }
+
+// Stable: the Assert is the last thing the goroutine does, so Check stops it
+// in the same place.
+func TestLast(t *testing.T) {
+	c := qt.New(t)
+	go func() {
+		_, err := work()
+		c.Assert(err, qt.IsNil) // want `qtlint: c.Assert in a goroutine stops the test with t.FailNow, which must be called from the test goroutine; use c.Check`
+	}()
+}
+
+// Not stable: with Check, the goroutine goes on to use n after a failure.
+func TestNotLast(t *testing.T) {
+	c := qt.New(t)
+	go func() {
+		n, err := work()
+		c.Assert(err, qt.IsNil) // want `qtlint: c.Assert in a goroutine stops the test with t.FailNow, which must be called from the test goroutine; use c.Check`
+		c.Check(n, qt.Equals, 1)
+	}()
+}
@@ -69,9 +69,10 @@ func TestLenWithOtherCheckers(t *testing.T) {
 	c := qt.New(t)
 	x := []int{1, 2, 3}
 
-	// These should not trigger the rule
-	qt.Assert(t, len(x), qt.DeepEquals, 3)
-	c.Assert(len(x), qt.Not(qt.DeepEquals), 0)
+	// These should not trigger the rule, though qt.DeepEquals on an int has
+	// a more direct spelling
+	qt.Assert(t, len(x), qt.DeepEquals, 3)     // want `qtlint: use qt.Equals instead of qt.DeepEquals on int, which == compares`
+	c.Assert(len(x), qt.Not(qt.DeepEquals), 0) // want `qtlint: use qt.Equals instead of qt.DeepEquals on int, which == compares`
 }
 
 // Test case: non-len expressions with qt.Equals
@@ -0,0 +1,41 @@
+package helperpredicates
+
+import (
+	"bytes"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	myreflect "reflect"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type name string
+
+func TestHelpers(t *testing.T) {
+	c := qt.New(t)
+
+	a, b := []int{1}, []int{1}
+	x, y := []byte("ab"), []byte("a")
+	m := map[string]int{}
+	s := "hello world"
+	c.Assert(reflect.DeepEqual(a, b), qt.IsTrue)                    // want `qtlint: use qt.DeepEquals instead of reflect.DeepEqual\(x, y\), qt.IsTrue`
+	qt.Assert(t, myreflect.DeepEqual(a, b), qt.IsFalse)             // want `qtlint: use qt.Not\(qt.DeepEquals\) instead of myreflect.DeepEqual\(x, y\), qt.IsFalse`
+	c.Assert(bytes.Equal(x, y), qt.IsFalse)                         // want `qtlint: use qt.Not\(qt.DeepEquals\) instead of bytes.Equal\(x, y\), qt.IsFalse`
+	c.Assert(slices.Equal(a, b), qt.IsTrue)                         // want `qtlint: use qt.DeepEquals instead of slices.Equal\(x, y\), qt.IsTrue`
+	c.Assert(maps.Equal(m, m), qt.IsTrue, qt.Commentf("maps"))      // want `qtlint: use qt.DeepEquals instead of maps.Equal\(x, y\), qt.IsTrue`
+	c.Assert(bytes.Contains(x, y), qt.IsTrue)                       // want `qtlint: use qt.Contains instead of bytes.Contains\(x, y\), qt.IsTrue`
+	c.Assert(strings.HasPrefix(s, "hello"), qt.IsTrue)              // want `qtlint: use qt.Matches instead of strings.HasPrefix\(x, y\), qt.IsTrue`
+	c.Assert(strings.HasSuffix(s, "world"), qt.IsFalse)             // want `qtlint: use qt.Not\(qt.Matches\) instead of strings.HasSuffix\(x, y\), qt.IsFalse`
+	c.Assert(regexp.MustCompile("w.rld").MatchString(s), qt.IsTrue) // want `qtlint: use qt.Matches instead of regexp.MustCompile\(p\).MatchString\(x\), qt.IsTrue`
+	c.Assert(strings.HasPrefix(string(name("a")), "a"), qt.IsTrue)  // want `qtlint: use qt.Matches instead of strings.HasPrefix\(x, y\), qt.IsTrue`
+
+	// Not a helper a checker stands in for.
+	c.Assert(strings.EqualFold(s, "x"), qt.IsFalse)
+	c.Assert(regexp.MustCompile(s).MatchString(s), qt.IsTrue)
+	c.Assert(bytes.Equal(x, y), qt.Equals, true) // want `qtlint: use qt.IsTrue instead of qt.Equals, true`
+}
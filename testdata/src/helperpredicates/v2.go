@@ -0,0 +1,18 @@
+package helperpredicates
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestV2(t *testing.T) {
+	a, b := []int{1}, []int{1}
+	x := []byte("ab")
+	qt.Assert(t, qt.IsTrue(reflect.DeepEqual(a, b)))        // want `qtlint: use qt.DeepEquals\(x, y\) instead of qt.IsTrue\(reflect.DeepEqual\(x, y\)\)`
+	qt.Check(t, qt.IsFalse(bytes.Contains(x, []byte("c")))) // want `qtlint: use qt.Not\(qt.StringContains\(x, y\)\) instead of qt.IsFalse\(bytes.Contains\(x, y\)\)`
+	qt.Assert(t, qt.IsTrue(strings.HasSuffix("ab", "b")))   // want `qtlint: use qt.Matches\(x, y\) instead of qt.IsTrue\(strings.HasSuffix\(x, y\)\)`
+}
@@ -0,0 +1,17 @@
+package helperpredicatesfix
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestV2(t *testing.T) {
+	p, q := point{1, 2}, &point{1, 2}
+	var v any = p
+	qt.Assert(t, qt.IsTrue(reflect.DeepEqual(p, *q)))      // want `qtlint: use qt.DeepEquals\(x, y\) instead of qt.IsTrue\(reflect.DeepEqual\(x, y\)\)`
+	qt.Assert(t, qt.IsTrue(reflect.DeepEqual(v, p)))       // want `qtlint: use qt.DeepEquals\(x, y\) instead of qt.IsTrue\(reflect.DeepEqual\(x, y\)\)`
+	qt.Assert(t, qt.IsFalse(strings.HasPrefix("ab", "b"))) // want `qtlint: use qt.Not\(qt.Matches\(x, y\)\) instead of qt.IsFalse\(strings.HasPrefix\(x, y\)\)`
+}
@@ -0,0 +1,39 @@
+package helperpredicatesonlystable
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type point struct{ X, Y int }
+
+type name string
+
+func TestDeepEquals(t *testing.T) {
+	c := qt.New(t)
+
+	p, q := point{1, 2}, point{1, 2}
+	a := []int{1}
+	c.Assert(reflect.DeepEqual(p, q), qt.IsTrue)        // want `qtlint: use qt.DeepEquals instead of reflect.DeepEqual\(x, y\), qt.IsTrue`
+	c.Assert(slices.Equal(a, []int{}), qt.IsFalse)      // want `qtlint: use qt.Not\(qt.DeepEquals\) instead of slices.Equal\(x, y\), qt.IsFalse`
+	c.Assert(bytes.Equal([]byte("a"), nil), qt.IsFalse) // want `qtlint: use qt.Not\(qt.DeepEquals\) instead of bytes.Equal\(x, y\), qt.IsFalse`
+}
+
+func TestStrings(t *testing.T) {
+	c := qt.New(t)
+
+	s, prefix := "open config.yaml", "open "
+	c.Assert(bytes.Contains([]byte(s), []byte("yaml")), qt.IsTrue)  // want `qtlint: use qt.Contains instead of bytes.Contains\(x, y\), qt.IsTrue`
+	c.Assert(strings.HasPrefix(s, prefix), qt.IsTrue)               // want `qtlint: use qt.Matches instead of strings.HasPrefix\(x, y\), qt.IsTrue`
+	c.Assert(strings.HasSuffix(s, ".yaml"), qt.IsTrue)              // want `qtlint: use qt.Matches instead of strings.HasSuffix\(x, y\), qt.IsTrue`
+	c.Assert(regexp.MustCompile(`^open`).MatchString(s), qt.IsTrue) // want `qtlint: use qt.Matches instead of regexp.MustCompile\(p\).MatchString\(x\), qt.IsTrue`
+	c.Assert(strings.HasPrefix(string(name(s)), "o"), qt.IsTrue)    // want `qtlint: use qt.Matches instead of strings.HasPrefix\(x, y\), qt.IsTrue`
+	var n name = "a.b"
+	c.Assert(strings.HasSuffix(string(n), ".b"), qt.IsTrue) // want `qtlint: use qt.Matches instead of strings.HasSuffix\(x, y\), qt.IsTrue`
+}
@@ -0,0 +1,109 @@
+package impossiblechecker
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type point struct{ X, Y int }
+
+type myErr struct{}
+
+func (*myErr) Error() string { return "my error" }
+
+type action func()
+
+type name string
+
+func (n name) String() string { return string(n) }
+
+func TestNil(t *testing.T) {
+	c := qt.New(t)
+
+	n, p := 3, point{}
+	c.Assert(n, qt.IsNil)              // want `qtlint: qt.IsNil always fails on int, which cannot be nil`
+	c.Check(p, qt.IsNotNil)            // want `qtlint: qt.IsNotNil always passes on impossiblechecker.point, which cannot be nil`
+	qt.Assert(t, "", qt.Not(qt.IsNil)) // want `qtlint: qt.Not\(qt.IsNil\) always passes on string, which cannot be nil` `qtlint: use qt.IsNotNil instead of qt.Not\(qt.IsNil\)`
+
+	var err error
+	var ptr *point
+	var s []int
+	c.Assert(err, qt.IsNil)
+	c.Assert(ptr, qt.IsNotNil)
+	c.Assert(s, qt.IsNil)
+	c.Assert(nil, qt.IsNil)
+}
+
+func TestHasLen(t *testing.T) {
+	c := qt.New(t)
+
+	n, arr := 3, [2]int{}
+	c.Assert(n, qt.HasLen, 1)               // want `qtlint: qt.HasLen always fails on int, which has no length`
+	c.Assert(&arr, qt.HasLen, 2)            // want `qtlint: qt.HasLen always fails on \*\[2\]int, which has no length`
+	c.Assert(point{}, qt.Not(qt.HasLen), 0) // want `qtlint: qt.Not\(qt.HasLen\) always fails on impossiblechecker.point, which has no length`
+
+	var v any = "x"
+	c.Assert(arr, qt.HasLen, 2)
+	c.Assert("abc", qt.HasLen, 3)
+	c.Assert(map[string]int{}, qt.HasLen, 0)
+	c.Assert(v, qt.HasLen, 1)
+}
+
+func TestErrors(t *testing.T) {
+	c := qt.New(t)
+
+	code := 1
+	c.Assert(code, qt.ErrorIs, io.EOF)             // want `qtlint: qt.ErrorIs always fails on int, which is not an error`
+	c.Assert("failed", qt.Not(qt.ErrorIs), io.EOF) // want `qtlint: qt.Not\(qt.ErrorIs\) always fails on string, which is not an error`
+	c.Assert(myErr{}, qt.ErrorAs, new(*myErr))     // want `qtlint: qt.ErrorAs always fails on impossiblechecker.myErr, which is not an error`
+
+	err := fmt.Errorf("wrap: %w", os.ErrNotExist)
+	var target *myErr
+	var pathErr *os.PathError
+	c.Assert(err, qt.ErrorAs, target) // want `qtlint: qt.ErrorAs panics on target \*impossiblechecker.myErr, which points to neither an interface nor an error`
+	c.Assert(err, qt.ErrorAs, nil)    // want `qtlint: qt.ErrorAs panics on a nil target`
+	c.Assert(err, qt.ErrorAs, code)   // want `qtlint: qt.ErrorAs panics on target int, which is not a pointer`
+
+	var reader io.Reader
+	c.Assert(err, qt.ErrorIs, os.ErrNotExist)
+	c.Assert(&myErr{}, qt.ErrorIs, os.ErrNotExist)
+	c.Assert(err, qt.ErrorAs, &target)
+	c.Assert(err, qt.ErrorAs, &pathErr)
+	c.Assert(err, qt.ErrorAs, &reader)
+	c.Assert(errors.Unwrap(err), qt.Not(qt.ErrorIs), io.EOF)
+}
+
+func TestPanicMatches(t *testing.T) {
+	c := qt.New(t)
+
+	f := func() error { panic("boom") }
+	var act action = func() { panic("boom") }
+	c.Assert(f, qt.PanicMatches, "boom")   // want `qtlint: qt.PanicMatches always fails on func\(\) error, which is not a func\(\)`
+	c.Assert(act, qt.PanicMatches, "boom") // want `qtlint: qt.PanicMatches always fails on impossiblechecker.action, which is not a func\(\)`
+	c.Assert(func() { panic("boom") }, qt.PanicMatches, "boom")
+}
+
+func TestEquals(t *testing.T) {
+	c := qt.New(t)
+
+	var got int64 = 1
+	var r io.Reader
+	c.Assert(got, qt.Equals, 1)               // want `qtlint: qt.Equals always fails on int64 and int, which are never equal`
+	c.Check(got, qt.Not(qt.Equals), int32(1)) // want `qtlint: qt.Not\(qt.Equals\) always passes on int64 and int32, which are never equal`
+	c.Assert(r, qt.Equals, point{})           // want `qtlint: qt.Equals always fails on io.Reader and impossiblechecker.point, which are never equal`
+
+	var v any = 1
+	var e error = &myErr{}
+	var s fmt.Stringer = name("x")
+	c.Assert(got, qt.Equals, int64(1))
+	c.Assert(v, qt.Equals, 1)
+	c.Assert(e, qt.Equals, myErr{}) // want `qtlint: qt.Equals always fails on error and impossiblechecker.myErr, which are never equal`
+	c.Assert(s, qt.Equals, name("x"))
+	c.Assert(r, qt.Equals, v)
+	c.Assert(got, qt.Equals, nil) // want `qtlint: use qt.IsNil instead of qt.Equals, nil`
+}
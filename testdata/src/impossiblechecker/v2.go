@@ -0,0 +1,24 @@
+package impossiblechecker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+// go-quicktest/qt's signatures leave only the checkers taking any T open.
+func TestV2(t *testing.T) {
+	n, p := 3, point{}
+	qt.Assert(t, qt.IsNil(n))           // want `qtlint: qt.IsNil always fails on int, which cannot be nil`
+	qt.Check(t, qt.Not(qt.IsNotNil(p))) // want `qtlint: qt.Not\(qt.IsNotNil\) always fails on impossiblechecker.point, which cannot be nil` `qtlint: use qt.IsNil\(x\) instead of qt.Not\(qt.IsNotNil\(x\)\)`
+	qt.Assert(t, qt.HasLen(n, 1))       // want `qtlint: qt.HasLen always fails on int, which has no length`
+
+	err := errors.New("x")
+	var target *myErr
+	var code int
+	qt.Assert(t, qt.ErrorAs(err, &code)) // want `qtlint: qt.ErrorAs panics on target \*int, which points to neither an interface nor an error`
+	qt.Assert(t, qt.ErrorAs(err, &target))
+	qt.Assert(t, qt.IsNotNil(err))
+	qt.Assert(t, qt.HasLen([]int{}, 0))
+}
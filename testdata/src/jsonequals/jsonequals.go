@@ -0,0 +1,86 @@
+package jsonequals
+
+import (
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type user struct {
+	Name string `json:"name"`
+}
+
+func body() []byte { return []byte(`{"name":"ann"}`) }
+
+func TestUnmarshal(t *testing.T) {
+	c := qt.New(t)
+
+	data := body()
+	var got map[string]any
+	c.Assert(json.Unmarshal(data, &got), qt.IsNil)
+	c.Assert(got, qt.DeepEquals, map[string]any{"name": "ann"}) // want `qtlint: use qt.JSONEquals instead of json.Unmarshal into got and qt.DeepEquals`
+
+	raw := json.RawMessage(data)
+	var v any
+	qt.Assert(t, json.Unmarshal(raw, &v), qt.IsNil)
+	qt.Assert(t, v, qt.DeepEquals, any(map[string]any{"name": "ann"}), qt.Commentf("decoded")) // want `qtlint: use qt.JSONEquals instead of json.Unmarshal into v and qt.DeepEquals`
+
+	// A struct drops the fields it does not name, so the fix is best-effort.
+	var u user
+	c.Assert(json.Unmarshal(data, &u), qt.IsNil)
+	c.Assert(u, qt.DeepEquals, user{Name: "ann"}) // want `qtlint: use qt.JSONEquals instead of json.Unmarshal into u and qt.DeepEquals`
+}
+
+func TestUnmarshalNoFix(t *testing.T) {
+	c := qt.New(t)
+
+	data := body()
+	var got map[string]any
+	c.Assert(json.Unmarshal(data, &got), qt.IsNil)
+	c.Assert(got, qt.DeepEquals, map[string]any{"name": "ann"}) // want `qtlint: use qt.JSONEquals instead of json.Unmarshal into got and qt.DeepEquals; no fix: got is used again`
+	c.Assert(got["name"], qt.Equals, "ann")
+
+	var again map[string]any
+	c.Assert(json.Unmarshal(data, &again), qt.IsNil)
+	data = nil
+	c.Assert(again, qt.DeepEquals, map[string]any{"name": "ann"}) // want `qtlint: use qt.JSONEquals instead of json.Unmarshal into again and qt.DeepEquals; no fix: the statements between the two change what json.Unmarshal decodes`
+
+	decoded := map[string]any{}
+	c.Assert(json.Unmarshal(body(), &decoded), qt.IsNil)
+	c.Assert(decoded, qt.DeepEquals, map[string]any{"name": "ann"}) // want `qtlint: use qt.JSONEquals instead of json.Unmarshal into decoded and qt.DeepEquals; no fix: decoded is not declared with a bare var in this block`
+
+	// Compared with something else than qt.DeepEquals: not reported.
+	var n map[string]any
+	c.Assert(json.Unmarshal(body(), &n), qt.IsNil)
+	c.Assert(n, qt.HasLen, 1)
+}
+
+func TestMarshal(t *testing.T) {
+	c := qt.New(t)
+
+	gotJSON, err := json.Marshal(user{Name: "ann"})
+	c.Assert(err, qt.IsNil)
+	wantJSON, err := json.Marshal(map[string]any{"name": "ann"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(gotJSON), qt.Equals, string(wantJSON)) // want `qtlint: use qt.JSONEquals instead of comparing the json.Marshal output wantJSON with qt.Equals`
+}
+
+func TestMarshalDeepEquals(t *testing.T) {
+	c := qt.New(t)
+
+	want := user{Name: "ann"}
+	expected, err := json.Marshal(want)
+	c.Assert(err, qt.IsNil)
+	c.Assert(body(), qt.DeepEquals, expected) // want `qtlint: use qt.JSONEquals instead of comparing the json.Marshal output expected with qt.DeepEquals`
+}
+
+func TestMarshalNoFix(t *testing.T) {
+	c := qt.New(t)
+
+	expected, err := json.Marshal(user{Name: "ann"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(body(), qt.DeepEquals, expected) // want `qtlint: use qt.JSONEquals instead of comparing the json.Marshal output expected with qt.DeepEquals; no fix: err is used again`
+	_, err = json.Marshal(nil)
+	c.Check(err, qt.IsNil)
+}
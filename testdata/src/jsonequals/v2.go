@@ -0,0 +1,18 @@
+package jsonequals
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestV2(t *testing.T) {
+	var got []any
+	qt.Assert(t, qt.IsNil(json.Unmarshal([]byte(`["a"]`), &got)))
+	qt.Assert(t, qt.DeepEquals(got, []any{"a"})) // want `qtlint: use qt.JSONEquals instead of json.Unmarshal into got and qt.DeepEquals`
+
+	wantJSON, err := json.Marshal([]string{"a"})
+	qt.Assert(t, qt.IsNil(err))
+	qt.Check(t, qt.Equals(`["a"]`, string(wantJSON))) // want `qtlint: use qt.JSONEquals instead of comparing the json.Marshal output wantJSON with qt.Equals`
+}
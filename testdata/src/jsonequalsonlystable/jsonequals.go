@@ -0,0 +1,26 @@
+package jsonequalsonlystable
+
+import (
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type user struct {
+	Name string `json:"name"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	c := qt.New(t)
+
+	data := []byte(`{"name":"ann","admin":true}`)
+	var got map[string]any
+	c.Assert(json.Unmarshal(data, &got), qt.IsNil)
+	c.Assert(got, qt.DeepEquals, map[string]any{"name": "ann", "admin": true}) // want `qtlint: use qt.JSONEquals instead of json.Unmarshal into got and qt.DeepEquals`
+
+	// Decoding into user drops "admin", which qt.JSONEquals would compare.
+	var u user
+	c.Assert(json.Unmarshal(data, &u), qt.IsNil)
+	c.Assert(u, qt.DeepEquals, user{Name: "ann"}) // want `qtlint: use qt.JSONEquals instead of json.Unmarshal into u and qt.DeepEquals`
+}
@@ -0,0 +1,76 @@
+package migrategocheck
+
+import (
+	"testing"
+
+	check "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+// Fixtures run around every test of the suite, and a plain test function has
+// nothing to run them.
+type FixtureSuite struct {
+	dir string
+}
+
+var _ = check.Suite(&FixtureSuite{})
+
+func (s *FixtureSuite) SetUpTest(c *check.C) { s.dir = c.MkDir() }
+
+func (s *FixtureSuite) TearDownSuite(c *check.C) {}
+
+func (s *FixtureSuite) TestFixture(c *check.C) { // want `qtlint: use func TestFixture\(t \*testing.T\) with quicktest instead of gocheck's FixtureSuite.TestFixture; no fix: FixtureSuite has SetUpTest and TearDownSuite, which gocheck runs around its tests`
+	c.Assert(s.dir, check.Not(check.Equals), "")
+}
+
+type S struct {
+	name string
+}
+
+var _ = check.Suite(&S{})
+
+var isOne check.Checker
+
+func helper(c *check.C) {}
+
+func (s *S) TestReceiver(c *check.C) { // want `no fix: the test uses its suite through s`
+	c.Assert(s.name, check.Equals, "")
+}
+
+func (*S) TestHelper(c *check.C) { // want `no fix: c is used as a value, where a \*qt.C may not fit`
+	helper(c)
+}
+
+func (*S) TestName(c *check.C) { // want `no fix: c.TestName has no counterpart on \*qt.C`
+	c.Assert(c.TestName(), check.Equals, "S.TestName")
+}
+
+func (*S) TestFits(c *check.C) { // want `no fix: check.FitsTypeOf has no quicktest counterpart`
+	c.Assert(1, check.FitsTypeOf, 2)
+}
+
+func (*S) TestCustom(c *check.C) { // want `no fix: the checker is not one of gocheck's`
+	c.Assert(1, isOne)
+}
+
+func (*S) TestComment(c *check.C) { // want `no fix: check.Commentf is used in a form the migration does not rewrite`
+	comment := check.Commentf("one")
+	c.Assert(1, check.Equals, 1, comment)
+}
+
+// S's TestDup becomes TestSDup; U's would be TestUDup, which is taken.
+func (*S) TestDup(c *check.C) {} // want `qtlint: use func TestSDup\(t \*testing.T\) with quicktest instead of gocheck's S.TestDup$`
+
+type U struct{}
+
+var _ = check.Suite(&U{})
+
+func (*U) TestDup(c *check.C) {} // want `qtlint: use func TestDup\(t \*testing.T\) with quicktest instead of gocheck's U.TestDup; no fix: neither TestDup nor TestUDup is free as a top-level name`
+
+func TestUDup(t *testing.T) {}
+
+// Only methods named Test… are tests.
+func (*U) Helper(c *check.C) {}
+
+func (*U) BenchmarkDup(c *check.C) {}
@@ -0,0 +1,20 @@
+package migrategocheck
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+	check "gopkg.in/check.v1"
+)
+
+type V struct{}
+
+var _ = check.Suite(&V{})
+
+func (*V) TestV2(c *check.C) { // want `qtlint: use func TestV2\(t \*testing.T\) with quicktest instead of gocheck's V.TestV2; no fix: the name qt, which the rewrite would import github.com/frankban/quicktest under, is taken`
+	c.Assert(1, check.Equals, 1)
+}
+
+func TestPlain(t *testing.T) {
+	qt.Assert(t, qt.Equals(1, 1))
+}
@@ -0,0 +1,19 @@
+package migrategocheckfix
+
+import (
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+type T struct{}
+
+// The suite stays registered from this file, so gocheck's import does too.
+var _ = Suite(&T{})
+
+func (*T) TestShared(c *C) { // want `qtlint: use func TestTShared\(t \*testing.T\) with quicktest instead of gocheck's T.TestShared`
+	c.Assert(nil, IsNil)
+	c.Check(map[string]int{}, Not(DeepEquals), map[string]int{"a": 1})
+}
+
+func TestPlain(t *testing.T) {}
@@ -0,0 +1,32 @@
+package migrategocheckfix
+
+import (
+	"os"
+
+	check "gopkg.in/check.v1"
+)
+
+// Every use of gocheck in this file moves, so its import goes too, and
+// quicktest and testing take its place.
+func (s *S) TestEquals(c *check.C) { // want `qtlint: use func TestEquals\(t \*testing.T\) with quicktest instead of gocheck's S.TestEquals`
+	c.Assert(1+1, check.Equals, 2)
+	c.Check("abc", check.Matches, "a.c", check.Commentf("id %d", 1))
+	c.Assert([]int{1}, check.Not(check.HasLen), 0)
+}
+
+// MkDir is quicktest's Mkdir.
+func (s *S) TestErrors(c *check.C) { // want `qtlint: use func TestErrors\(t \*testing.T\) with quicktest instead of gocheck's S.TestErrors`
+	_, err := os.Open("missing")
+	c.Assert(err, check.NotNil)
+	c.Assert(err, check.ErrorMatches, "open missing: .*")
+	dir := c.MkDir()
+	c.Logf("dir %s", dir)
+}
+
+// A test that never uses its *check.C gets no *qt.C.
+func (*S) TestNothing(c *check.C) {} // want `qtlint: use func TestNothing\(t \*testing.T\) with quicktest instead of gocheck's S.TestNothing`
+
+// T has a TestShared too, so each takes its suite's name.
+func (s *S) TestShared(c *check.C) { // want `qtlint: use func TestSShared\(t \*testing.T\) with quicktest instead of gocheck's S.TestShared`
+	c.Assert(len("ab"), check.Equals, 2)
+}
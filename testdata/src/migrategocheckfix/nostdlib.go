@@ -0,0 +1,10 @@
+package migrategocheckfix
+
+import (
+	check "gopkg.in/check.v1"
+)
+
+// With no standard-library import to join, testing starts a group of its own.
+func (s *S) TestNoStdlib(c *check.C) { // want `qtlint: use func TestNoStdlib\(t \*testing.T\) with quicktest instead of gocheck's S.TestNoStdlib`
+	c.Assert("a", check.Equals, "a")
+}
@@ -0,0 +1,34 @@
+package migrategocheckonlystable
+
+import (
+	"errors"
+	"testing"
+
+	check "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type opaque struct {
+	secret int
+}
+
+// go-cmp fails on opaque's unexported field where reflect.DeepEqual does not,
+// and qt.IsNil fails on an error holding a nil pointer where gocheck does not,
+// so these tests stay where they are.
+func (*S) TestDeep(c *check.C) { // want `qtlint: use func TestDeep\(t \*testing.T\) with quicktest instead of gocheck's S.TestDeep`
+	c.Assert(opaque{1}, check.DeepEquals, opaque{1})
+}
+
+func (*S) TestNil(c *check.C) { // want `qtlint: use func TestNil\(t \*testing.T\) with quicktest instead of gocheck's S.TestNil`
+	c.Assert(errors.New("x"), check.NotNil)
+}
+
+// Exported fields compare the same way either way.
+func (*S) TestStable(c *check.C) { // want `qtlint: use func TestStable\(t \*testing.T\) with quicktest instead of gocheck's S.TestStable`
+	c.Assert(struct{ A []int }{}, check.DeepEquals, struct{ A []int }{})
+}
@@ -0,0 +1,32 @@
+package migrateqtv2
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// qt.All has no go-quicktest/qt counterpart, so the file stays where it is and
+// its other assertions say why.
+func TestAll(t *testing.T) {
+	c := qt.New(t)
+	c.Assert([]int{1, 1}, qt.All(qt.Equals), 1) // want "qtlint: use go-quicktest/qt instead of c.Assert\\(got, qt.All\\(...\\), want\\); no fix: qt.All has no direct go-quicktest/qt counterpart"
+	c.Assert(1, qt.Equals, 1)                   // want "qtlint: use qt.Assert\\(t, qt.Equals\\(got, want\\)\\) instead of c.Assert\\(got, qt.Equals, want\\); no fix: the file moves as a unit, and the assertion at line 13 cannot move"
+}
+
+// The generic checkers take both operands as one type parameter.
+func TestMismatchedOperands(t *testing.T) {
+	var got int64 = 1
+	want := 1
+	qt.Assert(t, got, qt.Equals, want) // want "qtlint: use go-quicktest/qt instead of qt.Assert\\(t, got, qt.Equals, want\\); no fix: qt.Equals takes got and want as one type parameter, and they are int64 and int" "qtlint: qt.Equals always fails on int64 and int, which are never equal"
+	qt.Assert(t, 3, qt.Equals, got)    // want "qtlint: use go-quicktest/qt instead of qt.Assert\\(t, got, qt.Equals, want\\); no fix: frankban/quicktest compares the untyped constant as int, which is never equal to int64, and go-quicktest/qt would convert it to int64" "qtlint: qt.Equals always fails on int and int64, which are never equal"
+	qt.Assert(t, 1, qt.IsTrue)         // want "qtlint: use go-quicktest/qt instead of qt.Assert\\(t, got, qt.IsTrue\\); no fix: qt.IsTrue takes a bool in go-quicktest/qt, and got is int"
+}
+
+// go-quicktest/qt's Assert returns nothing.
+func TestResultUsed(t *testing.T) {
+	c := qt.New(t)
+	if !c.Assert(1, qt.Equals, 1) { // want "qtlint: use go-quicktest/qt instead of c.Assert\\(got, qt.Equals, want\\); no fix: the result of Assert is used, and go-quicktest/qt's Assert returns nothing"
+		return
+	}
+}
@@ -0,0 +1,21 @@
+package migrateqtv2
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func check(c *qt.C) {
+	c.Assert(1, qt.Equals, 1) // want "qtlint: use go-quicktest/qt instead of c.Assert\\(got, qt.Equals, want\\); no fix: c is not made by qt.New in this function, so there is no test handle to pass instead"
+}
+
+// A *qt.C handed to a helper or given to c.Run has nothing to become, so the
+// file stays where it is.
+func TestReceivers(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(1, qt.Equals, 1) // want "qtlint: use qt.Assert\\(t, qt.Equals\\(got, want\\)\\) instead of c.Assert\\(got, qt.Equals, want\\); no fix: the file moves as a unit, and the assertion at line 10 cannot move"
+	c.Run("sub", func(c *qt.C) {
+		check(c)
+	})
+}
@@ -0,0 +1,19 @@
+package migrateqtv2
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func logName(tb testing.TB) {
+	tb.Log(tb.Name())
+}
+
+// Every assertion here has a go-quicktest/qt form, but the *qt.C is also
+// handed on as a value, and there is no *qt.C on the other side.
+func TestValue(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(1, qt.Equals, 1) // want "qtlint: use qt.Assert\\(t, qt.Equals\\(got, want\\)\\) instead of c.Assert\\(got, qt.Equals, want\\); no fix: the file moves as a unit, and c is used as a value with no go-quicktest/qt counterpart \\(line 18\\)"
+	logName(c)
+}
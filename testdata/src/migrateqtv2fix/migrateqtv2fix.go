@@ -0,0 +1,69 @@
+package migrateqtv2fix
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type customErr struct{}
+
+func (*customErr) Error() string { return "custom" }
+
+var errBoom = errors.New("boom")
+
+type point struct{ X, Y int }
+
+// Every assertion in the file has a go-quicktest/qt form, so every one of them
+// carries the fix that moves the file.
+func TestMigrate(t *testing.T) {
+	c := qt.New(t)
+	n := 3
+	s := "hello world"
+	xs := []int{1, 2, 3}
+	m := map[string]int{"a": 1}
+	err := errBoom
+	var target *customErr
+	var opt any
+
+	c.Assert(n, qt.Equals, 3)                          // want "qtlint: use qt.Assert\\(t, qt.Equals\\(got, want\\)\\) instead of c.Assert\\(got, qt.Equals, want\\)"
+	c.Check(point{1, 2}, qt.DeepEquals, point{1, 2})   // want "qtlint: use qt.Check\\(t, qt.DeepEquals\\(got, want\\)\\) instead of c.Check\\(got, qt.DeepEquals, want\\)"
+	c.Assert(xs, qt.CmpEquals(opt), []int{1, 2, 3})    // want "qtlint: use qt.Assert\\(t, qt.CmpEquals\\(got, want, ...\\)\\) instead of c.Assert\\(got, qt.CmpEquals\\(...\\), want\\)"
+	c.Assert(n, qt.Not(qt.Equals), 4)                  // want "qtlint: use qt.Assert\\(t, qt.Not\\(qt.Equals\\(got, want\\)\\)\\) instead of c.Assert\\(got, qt.Not\\(qt.Equals\\), want\\)"
+	c.Assert(target, qt.IsNil, qt.Commentf("n=%d", n)) // want "qtlint: use qt.Assert\\(t, qt.IsNil\\(got\\)\\) instead of c.Assert\\(got, qt.IsNil\\)"
+	c.Assert(n > 0, qt.IsTrue)                         // want "qtlint: use qt.Assert\\(t, qt.IsTrue\\(got\\)\\) instead of c.Assert\\(got, qt.IsTrue\\)"
+	c.Assert(xs, qt.HasLen, 3)                         // want "qtlint: use qt.Assert\\(t, qt.HasLen\\(got, want\\)\\) instead of c.Assert\\(got, qt.HasLen, want\\)"
+
+	c.Assert(s, qt.Contains, "world") // want "qtlint: use qt.Assert\\(t, qt.StringContains\\(got, want\\)\\) instead of c.Assert\\(got, qt.Contains, want\\)"
+	c.Assert(xs, qt.Contains, 2)      // want "qtlint: use qt.Assert\\(t, qt.SliceContains\\(got, want\\)\\) instead of c.Assert\\(got, qt.Contains, want\\)"
+	c.Assert(m, qt.Contains, 1)       // want "qtlint: use qt.Assert\\(t, qt.MapContains\\(got, want\\)\\) instead of c.Assert\\(got, qt.Contains, want\\)"
+
+	c.Assert(err, qt.ErrorMatches, "bo+m")                                   // want "qtlint: use qt.Assert\\(t, qt.ErrorMatches\\(got, want\\)\\) instead of c.Assert\\(got, qt.ErrorMatches, want\\)"
+	c.Assert(err, qt.ErrorIs, errBoom)                                       // want "qtlint: use qt.Assert\\(t, qt.ErrorIs\\(got, want\\)\\) instead of c.Assert\\(got, qt.ErrorIs, want\\)"
+	c.Assert(err, qt.Not(qt.ErrorAs), &target)                               // want "qtlint: use qt.Assert\\(t, qt.Not\\(qt.ErrorAs\\(got, want\\)\\)\\) instead of c.Assert\\(got, qt.Not\\(qt.ErrorAs\\), want\\)"
+	c.Assert(func() { panic("x") }, qt.PanicMatches, "x")                    // want "qtlint: use qt.Assert\\(t, qt.PanicMatches\\(got, want\\)\\) instead of c.Assert\\(got, qt.PanicMatches, want\\)"
+	c.Assert(`{"a": 1}`, qt.JSONEquals, m)                                   // want "qtlint: use qt.Assert\\(t, qt.JSONEquals\\(got, want\\)\\) instead of c.Assert\\(got, qt.JSONEquals, want\\)"
+	c.Assert(point{}, qt.CodecEquals(json.Marshal, json.Unmarshal), point{}) // want "qtlint: use qt.Assert\\(t, qt.CodecEquals\\(got, want, ...\\)\\) instead of c.Assert\\(got, qt.CodecEquals\\(...\\), want\\)"
+
+	// A *qt.C method testing.TB also has moves to the handle.
+	dir := c.TempDir()
+	if !c.Check(dir, qt.Not(qt.Equals), "") { // want "qtlint: use qt.Check\\(t, qt.Not\\(qt.Equals\\(got, want\\)\\)\\) instead of c.Check\\(got, qt.Not\\(qt.Equals\\), want\\)"
+		return
+	}
+}
+
+// The package-level form keeps its handle.
+func assertPoint(t testing.TB, got point) {
+	qt.Assert(t, got, qt.DeepEquals, point{1, 2}) // want "qtlint: use qt.Assert\\(t, qt.DeepEquals\\(got, want\\)\\) instead of qt.Assert\\(t, got, qt.DeepEquals, want\\)"
+}
+
+// Each subtest's *qt.C gives way to that subtest's handle.
+func TestSubtest(t *testing.T) {
+	t.Run("sub", func(t *testing.T) {
+		c := qt.New(t)
+		c.Assert(1, qt.Equals, 1) // want "qtlint: use qt.Assert\\(t, qt.Equals\\(got, want\\)\\) instead of c.Assert\\(got, qt.Equals, want\\)"
+	})
+	assertPoint(t, point{1, 2})
+}
@@ -0,0 +1,18 @@
+package migrateqtv2onlystable
+
+import (
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type point struct{ X, Y int }
+
+// qt.CodecEquals is best-effort, and the file moves as a unit, so neither
+// assertion carries a fix under --only-stable-fixes.
+func TestCodec(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(point{}, qt.CodecEquals(json.Marshal, json.Unmarshal), point{}) // want "qtlint: use qt.Assert\\(t, qt.CodecEquals\\(got, want, ...\\)\\) instead of c.Assert\\(got, qt.CodecEquals\\(...\\), want\\)"
+	c.Assert(1, qt.Equals, 1)                                                // want "qtlint: use qt.Assert\\(t, qt.Equals\\(got, want\\)\\) instead of c.Assert\\(got, qt.Equals, want\\)"
+}
@@ -0,0 +1,53 @@
+package migratetestify
+
+import (
+	"io"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/stretchr/testify/assert"
+)
+
+type harness struct {
+	t *testing.T
+}
+
+type record struct {
+	Name string
+}
+
+var sharedT *testing.T
+
+// Checkers whose quicktest counterpart does not mean the same thing.
+func TestNoCounterpart(t *testing.T) {
+	c := qt.New(t)
+	assert.Greater(t, 2, 1)                            // want `qtlint: use quicktest instead of assert.Greater\(t, \.\.\.\); no fix: assert.Greater has no quicktest counterpart`
+	assert.ErrorContains(t, io.EOF, "EOF")             // want `qtlint: use quicktest instead of assert.ErrorContains\(t, \.\.\.\); no fix: assert.ErrorContains has no quicktest counterpart`
+	assert.Contains(t, map[string]int{"a": 1}, "a")    // want `qtlint: use c.Check\(container, qt.Contains, elem\) instead of assert.Contains\(t, container, elem\); no fix: testify looks for a map key, and qt.Contains for a value`
+	assert.Empty(t, record{})                          // want `qtlint: use c.Check\(x, qt.HasLen, 0\) instead of assert.Empty\(t, x\); no fix: testify checks a migratetestify.record for its zero value, not its length`
+	assert.EqualError(t, io.EOF, "unexpected EOF (1)") // want `qtlint: use c.Check\(err, qt.ErrorMatches, msg\) instead of assert.EqualError\(t, err, msg\); no fix: the message is not a literal free of the metacharacters qt.ErrorMatches would interpret`
+	c.Assert(1, qt.Equals, 1)
+}
+
+// Handles the rule cannot make a *qt.C from.
+func TestHandles(t *testing.T) {
+	h := harness{t: t}
+	assert.True(h.t, true)     // want `qtlint: use c.Check\(b, qt.IsTrue\) instead of assert.True\(t, b\); no fix: the test handle is not a named variable`
+	assert.True(sharedT, true) // want `qtlint: use c.Check\(b, qt.IsTrue\) instead of assert.True\(sharedT, b\); no fix: sharedT is not a parameter of an enclosing function, so there is nowhere to make a \*qt.C from it`
+}
+
+// Messages that do not divide into a qt.Commentf.
+func TestMessages(t *testing.T) {
+	var format any = "id %d"
+	args := []any{"id %d", 1}
+	assert.True(t, true, format, 1) // want `qtlint: use c.Check\(b, qt.IsTrue\) instead of assert.True\(t, b\); no fix: the message format is not a string`
+	assert.True(t, true, args...)   // want `qtlint: use c.Check\(b, qt.IsTrue\) instead of assert.True\(t, b\); no fix: spread message arguments cannot be divided into a qt.Commentf format and its arguments`
+}
+
+// testify's helpers and its Assertions type are not assertions the rule
+// rewrites.
+func TestNotAssertions(t *testing.T) {
+	a := assert.New(t)
+	a.Equal(1, 1)
+	_ = assert.ObjectsAreEqual(1, 1)
+}
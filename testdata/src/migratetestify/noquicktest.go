@@ -0,0 +1,12 @@
+package migratetestify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// A file that does not import quicktest is not this tool's business.
+func TestWithoutQuicktest(t *testing.T) {
+	assert.Equal(t, 1, 1)
+}
@@ -0,0 +1,68 @@
+package migratetestifyfix
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type point struct {
+	X, Y int
+}
+
+func load() (string, error) { return "", nil }
+
+// The *qt.C made from t is reused, and testify's (want, got) becomes (got, want).
+func TestReusesC(t *testing.T) {
+	c := qt.New(t)
+	got, err := load()
+	require.NoError(t, err)      // want `qtlint: use c.Assert\(err, qt.IsNil\) instead of require.NoError\(t, err\)`
+	assert.Equal(t, "x", got)    // want `qtlint: use c.Check\(got, qt.DeepEquals, want\) instead of assert.Equal\(t, want, got\)`
+	assert.NotEqual(t, "y", got) // want `qtlint: use c.Check\(got, qt.Not\(qt.DeepEquals\), want\) instead of assert.NotEqual\(t, want, got\)`
+	c.Assert(got, qt.Not(qt.Equals), "")
+}
+
+// No *qt.C exists, so one is made at the top of the test, once.
+func TestCreatesC(t *testing.T) {
+	names := []string{"a", "b"}
+	assert.Len(t, names, 2)                                   // want `qtlint: use c.Check\(x, qt.HasLen, n\) instead of assert.Len\(t, x, n\)`
+	assert.Contains(t, names, "a")                            // want `qtlint: use c.Check\(container, qt.Contains, elem\) instead of assert.Contains\(t, container, elem\)`
+	assert.NotContains(t, "abc", "z")                         // want `qtlint: use c.Check\(container, qt.Not\(qt.Contains\), elem\) instead of assert.NotContains\(t, container, elem\)`
+	assert.NotEmpty(t, names)                                 // want `qtlint: use c.Check\(x, qt.Not\(qt.HasLen\), 0\) instead of assert.NotEmpty\(t, x\)`
+	assert.Empty(t, map[string]int{})                         // want `qtlint: use c.Check\(x, qt.HasLen, 0\) instead of assert.Empty\(t, x\)`
+	assert.True(t, len(names) > 1, "two at least")            // want `qtlint: use c.Check\(b, qt.IsTrue\) instead of assert.True\(t, b\)`
+	assert.False(t, names == nil, "100%")                     // want `qtlint: use c.Check\(b, qt.IsFalse\) instead of assert.False\(t, b\)`
+	assert.Equalf(t, point{1, 2}, point{1, 2}, "point %d", 1) // want `qtlint: use c.Check\(got, qt.DeepEquals, want\) instead of assert.Equalf\(t, want, got, format, ...\)`
+}
+
+// Errors.
+func TestErrors(t *testing.T) {
+	_, err := os.Open("missing")
+	require.Error(t, err)                           // want `qtlint: use c.Assert\(err, qt.IsNotNil\) instead of require.Error\(t, err\)`
+	require.ErrorIs(t, err, os.ErrNotExist)         // want `qtlint: use c.Assert\(err, qt.ErrorIs, target\) instead of require.ErrorIs\(t, err, target\)`
+	assert.NotErrorIs(t, err, os.ErrExist, "id", 3) // want `qtlint: use c.Check\(err, qt.Not\(qt.ErrorIs\), target\) instead of assert.NotErrorIs\(t, err, target\)`
+	var pathErr *os.PathError
+	require.ErrorAs(t, err, &pathErr)                                // want `qtlint: use c.Assert\(err, qt.ErrorAs, target\) instead of require.ErrorAs\(t, err, target\)`
+	assert.EqualError(t, errors.New("no such file"), "no such file") // want `qtlint: use c.Check\(err, qt.ErrorMatches, msg\) instead of assert.EqualError\(t, err, msg\)`
+}
+
+// A subtest's t gets its own *qt.C rather than the parent's c.
+func TestSubtest(t *testing.T) {
+	c := qt.New(t)
+	c.Run("quicktest", func(c *qt.C) {
+		assert.Nil(c, (*point)(nil)) // want `qtlint: use c.Check\(x, qt.IsNil\) instead of assert.Nil\(c, x\)`
+	})
+	t.Run("testing", func(t *testing.T) {
+		assert.NotNil(t, &point{}) // want `qtlint: use c.Check\(x, qt.IsNotNil\) instead of assert.NotNil\(t, x\)`
+	})
+}
+
+// The name c is taken, so the *qt.C is called c2.
+func TestNameTaken(t *testing.T) {
+	c := "taken"
+	require.NotEmpty(t, c) // want `qtlint: use c2.Assert\(x, qt.Not\(qt.HasLen\), 0\) instead of require.NotEmpty\(t, x\)`
+}
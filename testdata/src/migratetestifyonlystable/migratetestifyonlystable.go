@@ -0,0 +1,34 @@
+package migratetestifyonlystable
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type opaque struct {
+	secret int
+}
+
+var errFailed = errors.New("failed")
+
+// go-cmp fails on opaque's unexported field where reflect.DeepEqual does not,
+// and qt.IsNil fails on an error holding a nil pointer where testify does not,
+// so both rewrites are best-effort. The assert import stays for the calls that
+// are left, and the stable assert call moves on its own.
+func TestOnlyStable(t *testing.T) {
+	assert.Equal(t, opaque{1}, opaque{1}) // want `qtlint: use c.Check\(got, qt.DeepEquals, want\) instead of assert.Equal\(t, want, got\)`
+	assert.True(t, true)                  // want `qtlint: use c.Check\(b, qt.IsTrue\) instead of assert.True\(t, b\)`
+	assert.Nil(t, errFailed)              // want `qtlint: use c.Check\(x, qt.IsNil\) instead of assert.Nil\(t, x\)`
+}
+
+// Every require call here is stable, so require's import goes with them.
+func TestStable(t *testing.T) {
+	require.NoError(t, nil) // want `qtlint: use c.Assert\(err, qt.IsNil\) instead of require.NoError\(t, err\)`
+}
+
+// helper keeps the file's quicktest import, which is what anchors the rule.
+func helper(c *qt.C) {}
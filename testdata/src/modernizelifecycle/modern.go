@@ -0,0 +1,59 @@
+//go:build go1.21
+
+package modernizelifecycle
+
+import (
+	"os"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestMkdir(t *testing.T) {
+	c := qt.New(t)
+	dir := c.Mkdir() // want `qtlint: use c.TempDir instead of c.Mkdir, which testing.TB has superseded since go1.15`
+	_ = dir
+}
+
+func TestDefer(t *testing.T) {
+	c := qt.New(t)
+	c.Defer(func() {}) // want `qtlint: use c.Cleanup instead of c.Defer, which testing.TB has superseded since go1.14`
+
+	d := qt.New(t)
+	defer d.Done()
+	d.Defer(func() {}) // want `qtlint: use d.Cleanup instead of d.Defer, which testing.TB has superseded since go1.14; no fix: d.Done would be left with nothing to do`
+
+	c.Run("sub", func(c *qt.C) {
+		c.Defer(func() {}) // want `qtlint: use c.Cleanup instead of c.Defer, which testing.TB has superseded since go1.14`
+	})
+}
+
+type suite struct{ c *qt.C }
+
+func (s *suite) setUp() {
+	s.c.Defer(func() {}) // want `qtlint: use s.c.Cleanup instead of s.c.Defer, which testing.TB has superseded since go1.14; no fix: cannot tell whether Done is called on s.c`
+}
+
+func TestSetenv(t *testing.T) {
+	c := qt.New(t)
+	c.Setenv("HOME", "/tmp") // want `qtlint: use t.Setenv instead of c.Setenv, which testing.TB has superseded since go1.17`
+
+	c.Run("sub", func(c *qt.C) {
+		c.Setenv("HOME", "/") // want `qtlint: use c.TB.Setenv instead of c.Setenv, which testing.TB has superseded since go1.17`
+	})
+}
+
+// Helpers testing.TB has no counterpart for, and the replacements themselves.
+func TestNotSuperseded(t *testing.T) {
+	c := qt.New(t)
+	c.Unsetenv("HOME")
+	c.Patch(&os.Args, nil)
+	c.Cleanup(func() {})
+	_ = c.TempDir()
+	t.Setenv("HOME", "/")
+}
+
+func TestParallelNotSuperseded(t *testing.T) {
+	c := qt.New(t)
+	c.Parallel()
+}
@@ -0,0 +1,13 @@
+package modernizelifecycle
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// A file whose Go version is not known is not reported.
+func TestUnknown(t *testing.T) {
+	c := qt.New(t)
+	_ = c.Mkdir()
+}
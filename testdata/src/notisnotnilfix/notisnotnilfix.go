@@ -0,0 +1,15 @@
+package notisnotnilfix
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestNotIsNotNil(t *testing.T) {
+	c := qt.New(t)
+	var err error
+
+	c.Assert(err, qt.Not(qt.IsNotNil))    // want `qtlint: use qt.IsNil instead of qt.Not\(qt.IsNotNil\)`
+	qt.Check(t, err, qt.Not(qt.IsNotNil)) // want `qtlint: use qt.IsNil instead of qt.Not\(qt.IsNotNil\)`
+}
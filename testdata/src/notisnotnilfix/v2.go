@@ -0,0 +1,13 @@
+package notisnotnilfix
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestNotIsNotNilV2(t *testing.T) {
+	var err error
+
+	qt.Assert(t, qt.Not(qt.IsNotNil(err))) // want `qtlint: use qt.IsNil\(x\) instead of qt.Not\(qt.IsNotNil\(x\)\)`
+}
@@ -0,0 +1,145 @@
+package panicmatches
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func mustPositive(n int) int {
+	if n <= 0 {
+		panic(fmt.Sprintf("not positive: %d", n))
+	}
+	return n
+}
+
+func TestNoComparison(t *testing.T) {
+	c := qt.New(t)
+	defer func() { // want `qtlint: use qt.PanicMatches instead of checking recover\(\) in a deferred function`
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	c.Log("calling")
+	mustPositive(0) // the call that panics
+}
+
+func TestEquals(t *testing.T) {
+	c := qt.New(t)
+	defer func() { // want `qtlint: use qt.PanicMatches instead of checking recover\(\) in a deferred function`
+		r := recover()
+		if r == nil {
+			t.Fatal("no panic")
+		}
+		if fmt.Sprint(r) != "not positive: 0" {
+			t.Fatalf("unexpected panic: %v", r)
+		}
+	}()
+	_ = c
+	mustPositive(0)
+}
+
+func TestEqualsRaw(t *testing.T) {
+	c := qt.New(t)
+	want := "not positive: -1"
+	defer func() { // want `qtlint: use qt.PanicMatches instead of checking recover\(\) in a deferred function`
+		if r := recover(); r == nil {
+			t.Error("no panic")
+		} else if r != want {
+			t.Errorf("got %v", r)
+		}
+	}()
+	_ = c
+	mustPositive(-1)
+}
+
+func TestContains(t *testing.T) {
+	c := qt.New(t)
+	defer func() { // want `qtlint: use qt.PanicMatches instead of checking recover\(\) in a deferred function`
+		r := recover()
+		if r == nil {
+			t.Fatal("no panic")
+		}
+		if !strings.Contains(fmt.Sprint(r), "positive") {
+			t.Fatal(r)
+		}
+	}()
+	c.Assert(mustPositive(0), qt.Equals, 0)
+}
+
+func TestAssertion(t *testing.T) {
+	c := qt.New(t)
+	defer func() { // want `qtlint: use qt.PanicMatches instead of checking recover\(\) in a deferred function`
+		r := recover()
+		if r == nil {
+			t.Error("no panic")
+		}
+		c.Assert(fmt.Sprint(r), qt.Matches, "not positive: .*")
+	}()
+	mustPositive(0)
+}
+
+func TestNoFix(t *testing.T) {
+	c := qt.New(t)
+	name := "mustPositive"
+	defer func() { // want `qtlint: use qt.PanicMatches instead of checking recover\(\) in a deferred function; no fix: the failure message says more than that the panic was missing or wrong`
+		if recover() == nil {
+			t.Fatalf("%s did not panic", name)
+		}
+	}()
+	_ = c
+	mustPositive(0)
+}
+
+func TestReturns(t *testing.T) {
+	c := qt.New(t)
+	defer func() { // want `qtlint: use qt.PanicMatches instead of checking recover\(\) in a deferred function; no fix: the code after the defer leaves the function, which it cannot do from a func literal`
+		if recover() == nil {
+			t.Fatal("no panic")
+		}
+	}()
+	_ = c
+	if testing.Short() {
+		return
+	}
+	mustPositive(0)
+}
+
+func TestNotMatched(t *testing.T) {
+	c := qt.New(t)
+	errBoom := errors.New("boom")
+
+	// The panic value is compared with an error, not with text. Its nil
+	// check is a failure block like any other.
+	defer func() {
+		r := recover()
+		if r == nil { // want `qtlint: use c.Assert\(r, qt.IsNotNil, qt.Commentf\(...\)\) instead of t.Fatal\(...\)`
+			t.Fatal("no panic")
+		}
+		if r != errBoom { // want `qtlint: use c.Assert\(r, qt.Equals, errBoom, qt.Commentf\(...\)\) instead of t.Fatal\(...\)`
+			t.Fatal(r)
+		}
+	}()
+
+	// The deferred function does more than check the panic.
+	defer func() {
+		if recover() == nil {
+			c.Log("no panic")
+			t.Fatal("no panic")
+		}
+	}()
+	panic(errBoom)
+}
+
+// No *qt.C is in scope: not reported.
+func TestNoChecker(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("no panic")
+		}
+	}()
+	mustPositive(0)
+}
@@ -0,0 +1,19 @@
+package panicmatches
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// Left as gofmt would not write it: the body's closing brace shares a line
+// with the code under test, and the fix closes the func literal before it.
+func TestBraceOnLastLine(t *testing.T) {
+	c := qt.New(t)
+	defer func() { // want `qtlint: use qt.PanicMatches instead of checking recover\(\) in a deferred function`
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	_ = c
+	mustPositive(0) }
@@ -0,0 +1,19 @@
+package panicmatches
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestV2(t *testing.T) {
+	defer func() { // want `qtlint: use qt.PanicMatches instead of checking recover\(\) in a deferred function`
+		r := recover()
+		if r == nil {
+			t.Fatal("no panic")
+		}
+		qt.Check(t, qt.Equals(fmt.Sprint(r), "not positive: 0"))
+	}()
+	mustPositive(0)
+}
@@ -0,0 +1,38 @@
+package panicmatchesonlystable
+
+import (
+	"fmt"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func boom() { panic("boom") }
+
+func TestSprint(t *testing.T) {
+	c := qt.New(t)
+	defer func() { // want `qtlint: use qt.PanicMatches instead of checking recover\(\) in a deferred function`
+		if r := recover(); r == nil {
+			t.Fatal("no panic")
+		} else if fmt.Sprint(r) != "boom" {
+			t.Fatal(r)
+		}
+	}()
+	_ = c
+	boom()
+}
+
+// r != "boom" fails for an error whose text is "boom", which qt.PanicMatches
+// passes, so the fix is withheld.
+func TestRaw(t *testing.T) {
+	c := qt.New(t)
+	defer func() { // want `qtlint: use qt.PanicMatches instead of checking recover\(\) in a deferred function`
+		if r := recover(); r == nil {
+			t.Fatal("no panic")
+		} else if r != "boom" {
+			t.Fatal(r)
+		}
+	}()
+	_ = c
+	boom()
+}
@@ -0,0 +1,123 @@
+package parallelstate
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+var now = time.Now
+
+type config struct {
+	retries int
+}
+
+var defaults config
+
+var current = &config{}
+
+// testing's Setenv and Chdir on a test that calls Parallel.
+func TestTestingParallel(t *testing.T) {
+	t.Parallel()
+	t.Setenv("HOME", "/tmp") // want `qtlint: t.Setenv in a test run in parallel by t.Parallel\(\) panics; no fix: the change belongs in a test that does not run in parallel`
+	t.Chdir("/tmp")          // want `qtlint: t.Chdir in a test run in parallel by t.Parallel\(\) panics`
+}
+
+// The *qt.C made from t is a handle on the same test, whichever of the two
+// Parallel is called on.
+func TestQtCParallel(t *testing.T) {
+	c := qt.New(t)
+	t.Parallel()
+	c.Setenv("HOME", "/tmp")      // want `qtlint: c.Setenv in a test run in parallel by t.Parallel\(\) races with the tests alongside it on the process environment`
+	c.Unsetenv("HOME")            // want `qtlint: c.Unsetenv in a test run in parallel by t.Parallel\(\) races with the tests alongside it on the process environment`
+	c.Patch(&now, time.Now)       // want `qtlint: c.Patch in a test run in parallel by t.Parallel\(\) races with the tests alongside it on package-level variable now`
+	c.Patch(&defaults.retries, 3) // want `qtlint: c.Patch in a test run in parallel by t.Parallel\(\) races with the tests alongside it on package-level variable defaults`
+	c.TB.Setenv("HOME", "/tmp")   // want `qtlint: c.TB.Setenv in a test run in parallel by t.Parallel\(\) panics`
+	c.Chdir("/tmp")               // want `qtlint: c.Chdir in a test run in parallel by t.Parallel\(\) panics`
+}
+
+func TestCParallel(t *testing.T) {
+	c := qt.New(t)
+	c.Parallel()
+	t.Setenv("HOME", "/tmp") // want `qtlint: t.Setenv in a test run in parallel by c.Parallel\(\) panics`
+}
+
+// A subtest runs in parallel when a test it runs under does.
+func TestParallelParent(t *testing.T) {
+	c := qt.New(t)
+	c.Parallel()
+	c.Run("sub", func(c *qt.C) {
+		c.Setenv("HOME", "/tmp") // want `qtlint: c.Setenv in a test run in parallel by c.Parallel\(\) races with the tests alongside it on the process environment`
+		c.Run("nested", func(c *qt.C) {
+			c.Patch(&now, time.Now) // want `qtlint: c.Patch in a test run in parallel by c.Parallel\(\) races`
+		})
+	})
+	t.Run("sub", func(t *testing.T) {
+		t.Setenv("HOME", "/tmp") // want `qtlint: t.Setenv in a test run in parallel by c.Parallel\(\) panics`
+	})
+}
+
+// A parallel subtest, and its parent that is not parallel.
+func TestParallelSubtest(t *testing.T) {
+	c := qt.New(t)
+	c.Setenv("HOME", "/tmp")
+	c.Run("sub", func(c *qt.C) {
+		c.Parallel()
+		c.Setenv("HOME", "/tmp") // want `qtlint: c.Setenv in a test run in parallel by c.Parallel\(\) races`
+	})
+	t.Run("sub", func(t *testing.T) {
+		t.Setenv("HOME", "/tmp")
+		t.Run("nested", func(t *testing.T) {
+			t.Parallel()
+			qt.New(t).Setenv("HOME", "/tmp")
+		})
+	})
+	c.Patch(&now, time.Now)
+}
+
+// What a parallel test may change: a local variable, memory reached through
+// a pointer it is handed, and nothing at all.
+func TestParallelAllowed(t *testing.T) {
+	c := qt.New(t)
+	c.Parallel()
+	var local int
+	c.Patch(&local, 1)
+	c.Patch(&current.retries, 2)
+	dest := &defaults.retries
+	c.Patch(dest, 3)
+	c.Assert(local, qt.Equals, 1)
+}
+
+// A subtest run before its parent calls Parallel has finished by then.
+func TestParallelAfterSubtest(t *testing.T) {
+	t.Run("setup", func(t *testing.T) {
+		t.Setenv("A", "b")
+	})
+	c := qt.New(t)
+	c.Run("setup", func(c *qt.C) {
+		c.Patch(&now, time.Now)
+	})
+	t.Parallel()
+	t.Run("after", func(t *testing.T) {
+		t.Setenv("A", "b") // want `qtlint: t.Setenv in a test run in parallel by t.Parallel\(\) panics`
+	})
+}
+
+// A test that is not parallel may change what it likes.
+func TestNotParallel(t *testing.T) {
+	c := qt.New(t)
+	c.Setenv("HOME", "/tmp")
+	c.Patch(&now, time.Now)
+	t.Chdir("/tmp")
+}
+
+// A helper handed the test's handle.
+func setup(c *qt.C) {
+	c.Parallel()
+	c.Setenv("HOME", "/tmp") // want `qtlint: c.Setenv in a test run in parallel by c.Parallel\(\) races`
+}
+
+func tbHelper(tb testing.TB) {
+	tb.Setenv("HOME", "/tmp")
+}
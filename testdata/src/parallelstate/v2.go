@@ -0,0 +1,15 @@
+package parallelstate
+
+import (
+	"testing"
+	"time"
+
+	qtv2 "github.com/go-quicktest/qt"
+)
+
+func TestV2Patch(t *testing.T) {
+	t.Parallel()
+	qtv2.Patch(t, &now, time.Now) // want `qtlint: qtv2.Patch in a test run in parallel by t.Parallel\(\) races with the tests alongside it on package-level variable now`
+	var local int
+	qtv2.Patch(t, &local, 1)
+}
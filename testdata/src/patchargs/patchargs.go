@@ -0,0 +1,92 @@
+package patchargs
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+var now = time.Now
+
+var timeout = time.Second
+
+var names []string
+
+type config struct {
+	retries int
+}
+
+var defaults config
+
+type clock interface {
+	Now() time.Time
+}
+
+type fixedClock struct{}
+
+func (fixedClock) Now() time.Time { return time.Time{} }
+
+var currentClock clock
+
+func realNow() time.Time { return time.Now() }
+
+func fakeNow() time.Time { return time.Time{} }
+
+func stubs() any { return fakeNow }
+
+// A variable passed where its address belongs.
+func TestMissingAddress(t *testing.T) {
+	c := qt.New(t)
+	c.Patch(now, fakeNow)              // want `qtlint: c.Patch destination now of type func\(\) time.Time is not a pointer, and Patch panics at run time`
+	c.Patch(defaults.retries, 3)       // want `qtlint: c.Patch destination defaults.retries of type int is not a pointer, and Patch panics at run time`
+	c.Patch(names, []string{"a", "b"}) // want `qtlint: c.Patch destination names of type \[\]string is not a pointer, and Patch panics at run time`
+	c.Patch(timeout, nil)              // want `qtlint: c.Patch destination timeout of type time.Duration is not a pointer, and Patch panics at run time`
+}
+
+// A destination that is not a pointer, and whose address cannot be taken or
+// would not help.
+func TestNotAPointer(t *testing.T) {
+	c := qt.New(t)
+	c.Patch(realNow, fakeNow) // want `qtlint: c.Patch destination realNow of type func\(\) time.Time is not a pointer, and Patch panics at run time; no fix: realNow is not a variable whose address can be taken`
+	c.Patch(timeout, "1s")    // want `qtlint: c.Patch destination timeout of type time.Duration is not a pointer, and Patch panics at run time; no fix: the value is not assignable to time.Duration either`
+}
+
+// A value of a type that does not fit the destination.
+func TestValueNotAssignable(t *testing.T) {
+	c := qt.New(t)
+	c.Patch(&timeout, 5)                 // want `qtlint: c.Patch value 5 of type int is not assignable to time.Duration, the type &timeout points to, and Patch panics at run time`
+	c.Patch(&defaults.retries, int64(3)) // want `qtlint: c.Patch value int64\(3\) of type int64 is not assignable to int, the type &defaults.retries points to, and Patch panics at run time`
+	c.Patch(&now, realNow())             // want `qtlint: c.Patch value realNow\(\) of type time.Time is not assignable to func\(\) time.Time, the type &now points to, and Patch panics at run time`
+	c.Patch(&currentClock, &defaults)    // want `qtlint: c.Patch value &defaults of type \*config is not assignable to clock, the type &currentClock points to, and Patch panics at run time`
+}
+
+// Arguments Patch accepts, or whose dynamic type is not known.
+func TestValid(t *testing.T) {
+	c := qt.New(t)
+	c.Patch(&now, fakeNow)
+	c.Patch(&timeout, 5*time.Second)
+	c.Patch(&timeout, time.Duration(5))
+	c.Patch(&defaults.retries, 3)
+	c.Patch(&defaults, config{retries: 1})
+	c.Patch(&names, nil)
+	c.Patch(&names, []string{"a"})
+	c.Patch(&currentClock, fixedClock{})
+	c.Patch(&currentClock, nil)
+	c.Patch(&now, stubs())
+
+	var dest any = &now
+	c.Patch(dest, fakeNow)
+
+	c.Run("sub", func(c *qt.C) {
+		c.Patch(&timeout, time.Minute)
+	})
+}
+
+// Patch on the *qt.C a subtest is handed.
+func TestSubtest(t *testing.T) {
+	c := qt.New(t)
+	c.Run("sub", func(c *qt.C) {
+		c.Patch(now, fakeNow) // want `qtlint: c.Patch destination now of type func\(\) time.Time is not a pointer, and Patch panics at run time`
+	})
+}
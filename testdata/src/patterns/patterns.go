@@ -0,0 +1,29 @@
+package patterns
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+const badPattern = "a[b"
+
+func TestInvalid(t *testing.T) {
+	c := qt.New(t)
+
+	err := errors.New("bad (regexp")
+	c.Assert(err, qt.ErrorMatches, "bad (regexp")                   // want `qtlint: qt.ErrorMatches pattern "bad \(regexp" does not compile: missing closing \)`
+	c.Check("abc", qt.Matches, badPattern)                          // want `qtlint: qt.Matches pattern "a\[b" does not compile: missing closing \]`
+	c.Assert(func() { panic("x") }, qt.PanicMatches, "x{2,1}")      // want `qtlint: qt.PanicMatches pattern "x\{2,1\}" does not compile: invalid repeat count`
+	qt.Assert(t, "abc", qt.Not(qt.Matches), "*abc")                 // want `qtlint: qt.Matches pattern "\*abc" does not compile: missing argument to repetition operator`
+	c.Assert([]string{"a"}, qt.All(qt.Matches), "(a")               // want `qtlint: qt.Matches pattern "\(a" does not compile: missing closing \)`
+	c.Assert(err, qt.ErrorMatches, "bad (regexp", qt.Commentf("x")) // want `qtlint: qt.ErrorMatches pattern "bad \(regexp" does not compile: missing closing \)`
+
+	// quicktest anchors the pattern as ^(pattern)$, which balances this one.
+	c.Assert("a", qt.Matches, "a)|(b")
+	c.Assert(err, qt.ErrorMatches, `bad \(regexp`)
+	c.Assert(err, qt.ErrorMatches, regexp.MustCompile("bad"))
+	c.Assert("abc", qt.Matches, "a.c")
+}
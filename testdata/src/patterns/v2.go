@@ -0,0 +1,15 @@
+package patterns
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestV2(t *testing.T) {
+	err := errors.New("x")
+	qt.Assert(t, qt.ErrorMatches(err, "x++"))           // want `qtlint: qt.ErrorMatches pattern "x\+\+" does not compile: invalid nested repetition operator`
+	qt.Check(t, qt.Not(qt.Matches("abc", "[[:foo:]]"))) // want `qtlint: qt.Matches pattern "\[\[:foo:\]\]" does not compile: invalid character class range`
+	qt.Assert(t, qt.ErrorMatches(err, "x+"))
+}
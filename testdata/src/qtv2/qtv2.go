@@ -0,0 +1,105 @@
+package qtv2
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+type customErr struct{}
+
+func (*customErr) Error() string { return "custom" }
+
+var errBoom = errors.New("boom")
+
+// Test case: qt.Not around a checker with a direct negation.
+func TestNot(t *testing.T) {
+	var x *int
+	ok := true
+	qt.Assert(t, qt.Not(qt.IsNil(x)))    // want "qtlint: use qt.IsNotNil\\(x\\) instead of qt.Not\\(qt.IsNil\\(x\\)\\)"
+	qt.Check(t, qt.Not(qt.IsTrue(ok)))   // want "qtlint: use qt.IsFalse\\(x\\) instead of qt.Not\\(qt.IsTrue\\(x\\)\\)"
+	qt.Assert(t, qt.Not(qt.IsFalse(ok))) // want "qtlint: use qt.IsTrue\\(x\\) instead of qt.Not\\(qt.IsFalse\\(x\\)\\)"
+}
+
+// Test case: len(x) compared with qt.Equals.
+func TestLenEquals(t *testing.T) {
+	xs := []int{1, 2, 3}
+	qt.Assert(t, qt.Equals(len(xs), 3))         // want "qtlint: use qt.HasLen\\(x, n\\) instead of qt.Equals\\(len\\(x\\), n\\)"
+	qt.Assert(t, qt.Not(qt.Equals(len(xs), 0))) // want "qtlint: use qt.Not\\(qt.HasLen\\(x, n\\)\\) instead of qt.Not\\(qt.Equals\\(len\\(x\\), n\\)\\)"
+}
+
+// Test case: comparisons with nil wrapped in qt.IsTrue/qt.IsFalse.
+func TestNilComparison(t *testing.T) {
+	var x *int
+	qt.Assert(t, qt.IsTrue(x == nil))  // want "qtlint: use qt.IsNil\\(x\\) instead of qt.IsTrue\\(x == nil\\)"
+	qt.Assert(t, qt.IsFalse(x == nil)) // want "qtlint: use qt.IsNotNil\\(x\\) instead of qt.IsFalse\\(x == nil\\)"
+	qt.Assert(t, qt.IsTrue(nil != x))  // want "qtlint: use qt.IsNotNil\\(x\\) instead of qt.IsTrue\\(x != nil\\)"
+	qt.Assert(t, qt.IsFalse(x != nil)) // want "qtlint: use qt.IsNil\\(x\\) instead of qt.IsFalse\\(x != nil\\)"
+}
+
+// Test case: equality comparisons wrapped in qt.IsTrue/qt.IsFalse.
+func TestEqualityComparison(t *testing.T) {
+	n := 3
+	qt.Assert(t, qt.IsTrue(n == 3))  // want "qtlint: use qt.Equals\\(x, y\\) instead of qt.IsTrue\\(x == y\\)"
+	qt.Assert(t, qt.IsFalse(n == 4)) // want "qtlint: use qt.Not\\(qt.Equals\\(x, y\\)\\) instead of qt.IsFalse\\(x == y\\)"
+	qt.Assert(t, qt.IsTrue(n != 4))  // want "qtlint: use qt.Not\\(qt.Equals\\(x, y\\)\\) instead of qt.IsTrue\\(x != y\\)"
+	qt.Assert(t, qt.IsFalse(n != 3)) // want "qtlint: use qt.Equals\\(x, y\\) instead of qt.IsFalse\\(x != y\\)"
+}
+
+// An interface compared with a concrete type is legal Go, but qt.Equals
+// cannot infer one type parameter from both, so there is no fix.
+func TestEqualityMixedTypes(t *testing.T) {
+	var err error
+	target := &customErr{}
+	qt.Assert(t, qt.IsTrue(err == target)) // want "qtlint: use qt.Equals\\(x, y\\) instead of qt.IsTrue\\(x == y\\); no fix: the operands have different types, which qt.Equals cannot infer one type parameter from"
+}
+
+// Test case: strings.Contains and slices.Contains wrapped in qt.IsTrue/qt.IsFalse.
+func TestContains(t *testing.T) {
+	s := "hello world"
+	xs := []int{1, 2, 3}
+	qt.Assert(t, qt.IsTrue(strings.Contains(s, "world"))) // want "qtlint: use qt.StringContains\\(x, y\\) instead of qt.IsTrue\\(strings.Contains\\(x, y\\)\\)"
+	qt.Assert(t, qt.IsFalse(strings.Contains(s, "foo")))  // want "qtlint: use qt.Not\\(qt.StringContains\\(x, y\\)\\) instead of qt.IsFalse\\(strings.Contains\\(x, y\\)\\)"
+	qt.Assert(t, qt.IsTrue(slices.Contains(xs, 2)))       // want "qtlint: use qt.SliceContains\\(x, y\\) instead of qt.IsTrue\\(slices.Contains\\(x, y\\)\\)"
+	qt.Assert(t, qt.IsFalse(slices.Contains(xs, 9)))      // want "qtlint: use qt.Not\\(qt.SliceContains\\(x, y\\)\\) instead of qt.IsFalse\\(slices.Contains\\(x, y\\)\\)"
+}
+
+// Test case: errors.Is and errors.As wrapped in qt.IsTrue/qt.IsFalse.
+func TestErrorIsAs(t *testing.T) {
+	err := errBoom
+	var target *customErr
+	qt.Assert(t, qt.IsTrue(errors.Is(err, errBoom)))  // want "qtlint: use qt.ErrorIs\\(err, target\\) instead of qt.IsTrue\\(errors.Is\\(err, target\\)\\)"
+	qt.Assert(t, qt.IsFalse(errors.Is(err, errBoom))) // want "qtlint: use qt.Not\\(qt.ErrorIs\\(err, target\\)\\) instead of qt.IsFalse\\(errors.Is\\(err, target\\)\\)"
+	qt.Assert(t, qt.IsTrue(errors.As(err, &target)))  // want "qtlint: use qt.ErrorAs\\(err, target\\) instead of qt.IsTrue\\(errors.As\\(err, target\\)\\)"
+}
+
+// Test case: qt.Equals with nil.
+func TestEqualsNil(t *testing.T) {
+	var x *int
+	qt.Assert(t, qt.Equals(x, nil)) // want "qtlint: use qt.IsNil\\(x\\) instead of qt.Equals\\(x, nil\\)"
+}
+
+// Test case: if err != nil { t.Fatal } with no *qt.C to assert through.
+func TestErrNilFatal(t *testing.T) {
+	err := errBoom
+	if err != nil { // want "qtlint: use qt.Assert\\(t, qt.IsNil\\(err\\), qt.Commentf\\(...\\)\\) instead of t.Fatal\\(...\\)"
+		t.Fatal(err)
+	}
+	if err != nil { // want "qtlint: use qt.Check\\(t, qt.IsNil\\(err\\), qt.Commentf\\(...\\)\\) instead of t.Errorf\\(...\\)"
+		t.Errorf("unexpected: %v", err)
+	}
+}
+
+// Negative test cases: the direct checkers are what the rules suggest.
+func TestAllowed(t *testing.T) {
+	var x *int
+	xs := []int{1}
+	qt.Assert(t, qt.IsNotNil(x))
+	qt.Assert(t, qt.HasLen(xs, 1))
+	qt.Assert(t, qt.Equals(xs[0], 1))
+	qt.Assert(t, qt.StringContains("abc", "b"))
+	qt.Assert(t, qt.Not(qt.DeepEquals(xs, nil)))
+}
@@ -0,0 +1,54 @@
+package qtv2fix
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+type customErr struct{}
+
+func (*customErr) Error() string { return "custom" }
+
+var errBoom = errors.New("boom")
+
+func TestV2Fixes(t *testing.T) {
+	var x *int
+	ok := true
+	n := 3
+	s := "hello world"
+	xs := []int{1, 2, 3}
+	err := errBoom
+	var target *customErr
+
+	qt.Assert(t, qt.Not(qt.IsNil(x)))    // want "qtlint: use qt.IsNotNil\\(x\\) instead of qt.Not\\(qt.IsNil\\(x\\)\\)"
+	qt.Check(t, qt.Not(qt.IsTrue(ok)))   // want "qtlint: use qt.IsFalse\\(x\\) instead of qt.Not\\(qt.IsTrue\\(x\\)\\)"
+	qt.Assert(t, qt.Not(qt.IsFalse(ok))) // want "qtlint: use qt.IsTrue\\(x\\) instead of qt.Not\\(qt.IsFalse\\(x\\)\\)"
+
+	qt.Assert(t, qt.Equals(len(xs), 3))         // want "qtlint: use qt.HasLen\\(x, n\\) instead of qt.Equals\\(len\\(x\\), n\\)"
+	qt.Assert(t, qt.Not(qt.Equals(len(xs), 0))) // want "qtlint: use qt.Not\\(qt.HasLen\\(x, n\\)\\) instead of qt.Not\\(qt.Equals\\(len\\(x\\), n\\)\\)"
+
+	qt.Assert(t, qt.IsTrue(x == nil))  // want "qtlint: use qt.IsNil\\(x\\) instead of qt.IsTrue\\(x == nil\\)"
+	qt.Assert(t, qt.IsFalse(x == nil)) // want "qtlint: use qt.IsNotNil\\(x\\) instead of qt.IsFalse\\(x == nil\\)"
+
+	qt.Assert(t, qt.IsTrue(n == 3))  // want "qtlint: use qt.Equals\\(x, y\\) instead of qt.IsTrue\\(x == y\\)"
+	qt.Assert(t, qt.IsFalse(n == 4)) // want "qtlint: use qt.Not\\(qt.Equals\\(x, y\\)\\) instead of qt.IsFalse\\(x == y\\)"
+
+	qt.Assert(t, qt.IsTrue(strings.Contains(s, "world"))) // want "qtlint: use qt.StringContains\\(x, y\\) instead of qt.IsTrue\\(strings.Contains\\(x, y\\)\\)"
+	qt.Assert(t, qt.IsFalse(slices.Contains(xs, 9)))      // want "qtlint: use qt.Not\\(qt.SliceContains\\(x, y\\)\\) instead of qt.IsFalse\\(slices.Contains\\(x, y\\)\\)"
+
+	qt.Assert(t, qt.IsTrue(errors.Is(err, errBoom))) // want "qtlint: use qt.ErrorIs\\(err, target\\) instead of qt.IsTrue\\(errors.Is\\(err, target\\)\\)"
+	qt.Assert(t, qt.IsTrue(errors.As(err, &target))) // want "qtlint: use qt.ErrorAs\\(err, target\\) instead of qt.IsTrue\\(errors.As\\(err, target\\)\\)"
+
+	qt.Assert(t, qt.Equals(x, nil), qt.Commentf("should be nil")) // want "qtlint: use qt.IsNil\\(x\\) instead of qt.Equals\\(x, nil\\)"
+
+	if err != nil { // want "qtlint: use qt.Assert\\(t, qt.IsNil\\(err\\), qt.Commentf\\(...\\)\\) instead of t.Fatal\\(...\\)"
+		t.Fatal(err)
+	}
+	if err != nil { // want "qtlint: use qt.Check\\(t, qt.IsNil\\(err\\), qt.Commentf\\(...\\)\\) instead of t.Errorf\\(...\\)"
+		t.Errorf("unexpected: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package qtv2subtest
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+// The go-quicktest/qt spelling of a borrowed checker: the subtest asserts
+// through the handle of the test around it, so the failure names that test.
+func TestBorrowsHandle(t *testing.T) {
+	t.Run("sub", func(t2 *testing.T) { // want "qtlint: this subtest asserts through the \\*testing.T of the test around it, so a failure names that test instead"
+		qt.Assert(t, qt.Equals(1, 1))
+		qt.Check(t, qt.IsTrue(true))
+	})
+}
+
+// Conforming: the subtest asserts through its own handle, shadowed or not.
+func TestOwnHandle(t *testing.T) {
+	t.Run("sub", func(t *testing.T) {
+		qt.Assert(t, qt.Equals(1, 1))
+	})
+	t.Run("renamed", func(t2 *testing.T) {
+		qt.Assert(t2, qt.Equals(t.Name(), t.Name()))
+	})
+}
+
+// A blank handle leaves nothing to assert through.
+func TestBlankHandle(t *testing.T) {
+	t.Run("sub", func(_ *testing.T) { // want "qtlint: this subtest asserts through the \\*testing.T of the test around it, so a failure names that test instead; no fix: the closure's \\*testing.T is blank, so there is no handle to assert through"
+		qt.Assert(t, qt.Equals(1, 1))
+	})
+}
@@ -0,0 +1,21 @@
+package quoteliteralpatterns
+
+import (
+	"errors"
+	re "regexp"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+var _ = re.MustCompile
+
+func TestImported(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(errors.New("a.b"), qt.ErrorMatches, "a.b") // want `qtlint: use regexp.QuoteMeta\("a.b"\) instead of "a.b", in which . matches any character`
+
+	// The name the file imports regexp under is shadowed, and there is no fix.
+	re := "a.b"
+	c.Assert(re, qt.Matches, "a.b") // want `qtlint: use regexp.QuoteMeta\("a.b"\) instead of "a.b", in which . matches any character`
+}
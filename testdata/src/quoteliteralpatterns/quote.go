@@ -0,0 +1,24 @@
+package quoteliteralpatterns
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestLiteral(t *testing.T) {
+	c := qt.New(t)
+
+	err := errors.New("open config.yaml: no such file")
+	c.Assert(err, qt.ErrorMatches, "open config.yaml: no such file")  // want `qtlint: use regexp.QuoteMeta\("open config.yaml: no such file"\) instead of "open config.yaml: no such file", in which . matches any character`
+	c.Assert("Close() failed", qt.Matches, "Close() failed")          // want `qtlint: use regexp.QuoteMeta\("Close\(\) failed"\) instead of "Close\(\) failed", in which \( and \) form a group`
+	c.Check("f(1.5)", qt.Not(qt.Matches), "f(1.5)", qt.Commentf("x")) // want `qtlint: use regexp.QuoteMeta\("f\(1.5\)"\) instead of "f\(1.5\)", in which . matches any character and \( and \) form a group`
+
+	// Written as regular expressions.
+	c.Assert(err, qt.ErrorMatches, "open .*: no such file")
+	c.Assert(err, qt.ErrorMatches, `open config\.yaml: .*`)
+	c.Assert("a", qt.Matches, "a|b")
+	c.Assert("a", qt.Matches, "[a]")
+	c.Assert("a", qt.Matches, "a")
+}
@@ -0,0 +1,8 @@
+package quoteliteralpatterns
+
+import "testing"
+import "github.com/go-quicktest/qt"
+
+func TestV2(t *testing.T) {
+	qt.Assert(t, qt.PanicMatches(func() { panic("x.y") }, "x.y")) // want `qtlint: use regexp.QuoteMeta\("x.y"\) instead of "x.y", in which . matches any character`
+}
@@ -95,9 +95,10 @@ func TestStringsContainsWithOtherCheckers(t *testing.T) {
 	c := qt.New(t)
 	str := "hello world"
 
-	// These should not trigger the rule
-	qt.Assert(t, strings.Contains(str, "world"), qt.Equals, true)
-	c.Assert(strings.Contains(str, "world"), qt.DeepEquals, true)
+	// These should not trigger the rule, though their checkers have more
+	// direct spellings
+	qt.Assert(t, strings.Contains(str, "world"), qt.Equals, true) // want `qtlint: use qt.IsTrue instead of qt.Equals, true`
+	c.Assert(strings.Contains(str, "world"), qt.DeepEquals, true) // want `qtlint: use qt.Equals instead of qt.DeepEquals on bool, which == compares`
 }
 
 // Test case: other functions named Contains
@@ -0,0 +1,70 @@
+package subtestqtnew
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// A subtest building its checker from the test around it.
+func TestOuterHandle(t *testing.T) {
+	t.Run("x", func(t2 *testing.T) {
+		c := qt.New(t) // want `qtlint: qt.New\(t\) in a subtest builds a checker from the test around it, so a failure names that test and Assert stops it; use the subtest's own t2`
+		c.Assert(1, qt.Equals, 1)
+	})
+	t.Run("y", func(st *testing.T) {
+		qt.New(t).Assert(1, qt.Equals, 1) // want `qtlint: qt.New\(t\) in a subtest builds a checker from the test around it`
+	})
+}
+
+// Nested subtests: the innermost closure's handle is the one meant.
+func TestNested(t *testing.T) {
+	t.Run("outer", func(outer *testing.T) {
+		outer.Run("inner", func(inner *testing.T) {
+			c := qt.New(outer) // want `qtlint: qt.New\(outer\) in a subtest builds a checker from the test around it, so a failure names that test and Assert stops it; use the subtest's own inner`
+			c.Assert(1, qt.Equals, 1)
+		})
+		c := qt.New(outer)
+		c.Assert(1, qt.Equals, 1)
+	})
+}
+
+// A closure with no handle to name, or one hidden where qt.New is.
+func TestNoFix(t *testing.T) {
+	t.Run("blank", func(_ *testing.T) {
+		c := qt.New(t) // want `qtlint: qt.New\(t\) in a subtest builds a checker from the test around it, so a failure names that test and Assert stops it; no fix: the closure's \*testing.T is blank, so there is no handle to build a checker from`
+		c.Assert(1, qt.Equals, 1)
+	})
+	t.Run("hidden", func(st *testing.T) {
+		for _, st := range []string{"a"} {
+			c := qt.New(t) // want `qtlint: qt.New\(t\) in a subtest builds a checker from the test around it, so a failure names that test and Assert stops it; no fix: the closure's handle is hidden where qt.New would name it`
+			c.Assert(st, qt.Equals, "a")
+		}
+	})
+}
+
+// The conforming shapes: the subtest's own handle, one declared within it,
+// and qt.New outside any subtest.
+func TestOwnHandle(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(1, qt.Equals, 1)
+	t.Run("x", func(t *testing.T) {
+		c := qt.New(t)
+		c.Assert(1, qt.Equals, 1)
+	})
+	t.Run("y", func(st *testing.T) {
+		tt := st
+		c := qt.New(tt)
+		c.Assert(1, qt.Equals, 1)
+	})
+	c.Run("z", func(c *qt.C) {
+		c.Assert(1, qt.Equals, 1)
+	})
+}
+
+func fuzzOuterHandle(f *testing.F, t *testing.T) {
+	f.Fuzz(func(ft *testing.T, s string) {
+		c := qt.New(t) // want `qtlint: qt.New\(t\) in a subtest builds a checker from the test around it, so a failure names that test and Assert stops it; use the subtest's own ft`
+		c.Assert(s, qt.Equals, s)
+	})
+}
@@ -0,0 +1,53 @@
+package swappedgotwant
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type point struct{ X, Y int }
+
+const answer = 42
+
+func compute() int { return 42 }
+
+func makePoint() point { return point{1, 2} }
+
+func TestSwapped(t *testing.T) {
+	c := qt.New(t)
+	n := compute()
+	result := "expected"
+	want := point{1, 2}
+	tt := struct{ expectedName string }{"a"}
+	values := []int{n}
+
+	c.Assert(42, qt.Equals, n)                               // want `qtlint: swap got and want: 42 reads as the expected value, but qt.Equals reports it as got`
+	qt.Check(t, "expected", qt.Equals, result)               // want `qtlint: swap got and want: "expected" reads as the expected value, but qt.Equals reports it as got`
+	c.Assert(answer, qt.Equals, compute(), qt.Commentf("x")) // want `qtlint: swap got and want: answer reads as the expected value`
+	c.Assert(want, qt.DeepEquals, makePoint())               // want `qtlint: swap got and want: want reads as the expected value, but qt.DeepEquals reports it as got`
+	c.Assert([]int{1}, qt.CmpEquals(nil), values)            // want `qtlint: swap got and want: \[\]int\{1\} reads as the expected value, but qt.CmpEquals reports it as got`
+	c.Assert(&point{}, qt.DeepEquals, &want)                 // want `qtlint: swap got and want: &point{} reads as the expected value`
+	c.Assert(tt.expectedName, qt.Equals, result)             // want `qtlint: swap got and want: tt.expectedName reads as the expected value`
+}
+
+// Assertions the rule leaves alone.
+func TestNotSwapped(t *testing.T) {
+	c := qt.New(t)
+	n := compute()
+	wanted := 3
+	want := 42
+
+	// In order already.
+	c.Assert(n, qt.Equals, 42)
+	// Both read as expected values, or neither does.
+	c.Assert(want, qt.Equals, 42)
+	c.Assert([]int{1}, qt.DeepEquals, []int{n})
+	c.Assert(n, qt.Equals, wanted)
+	// The operands play different roles.
+	c.Assert("abc", qt.Contains, string(rune(n)))
+	c.Assert("abc", qt.HasLen, n)
+	c.Assert("abc", qt.Matches, string(rune(n)))
+	// Negated, the failure message does not label got and want.
+	c.Assert(42, qt.Not(qt.Equals), n)
+}
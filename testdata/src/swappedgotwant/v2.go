@@ -0,0 +1,18 @@
+package swappedgotwant
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+)
+
+func TestSwappedV2(t *testing.T) {
+	n := compute()
+	values := []int{n}
+
+	qt.Assert(t, qt.Equals(42, n))                // want `qtlint: swap got and want: 42 reads as the expected value, but qt.Equals reports it as got`
+	qt.Assert(t, qt.DeepEquals([]int{1}, values)) // want `qtlint: swap got and want: \[\]int\{1\} reads as the expected value, but qt.DeepEquals reports it as got`
+	qt.Assert(t, qt.Equals(n, 42))
+	qt.Assert(t, qt.Not(qt.Equals(42, n)))
+	qt.Assert(t, qt.StringContains("abc", string(rune(n))))
+}
@@ -0,0 +1,15 @@
+package unusedchecker
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// The file's only use of quicktest: the fix removes the import too.
+func TestLonely(t *testing.T) {
+	c := qt.New( // want `qtlint: c is made by qt.New but never asserts, runs a subtest or reaches a helper that does`
+		t)
+	_ = c
+	t.Log("lonely")
+}
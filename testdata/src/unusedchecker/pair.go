@@ -0,0 +1,22 @@
+package unusedchecker
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// Two removals that would leave the import unused only together.
+func TestPairFirst(t *testing.T) {
+	c := qt.New( // want `qtlint: c is made by qt.New but never asserts, runs a subtest or reaches a helper that does; no fix: removing every unused qt.New in the file would leave its quicktest import unused`
+		t)
+	_ = c
+	t.Log("first")
+}
+
+func TestPairSecond(t *testing.T) {
+	c := qt.New( // want `qtlint: c is made by qt.New but never asserts, runs a subtest or reaches a helper that does; no fix: removing every unused qt.New in the file would leave its quicktest import unused`
+		t)
+	_ = c
+	t.Log("second")
+}
@@ -0,0 +1,129 @@
+package unusedchecker
+
+import (
+	"os"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func parse(s string) (int, error) { return len(s), nil }
+
+// A test left with a checker and no assertion. The want comments sit inside
+// qt.New( so that the line the fix removes holds nothing else.
+func TestLeftOver(t *testing.T) {
+	c := qt.New( // want `qtlint: c is made by qt.New but never asserts, runs a subtest or reaches a helper that does`
+		t)
+	_ = c
+	n, _ := parse("x")
+	t.Log(n, os.Getenv("HOME"))
+}
+
+func TestVarDecl(t *testing.T) {
+	var c = qt.New( // want `qtlint: c is made by qt.New but never asserts`
+		t)
+	_ = c
+	t.Log("done")
+}
+
+// A failure block the failure-block rule would assert through the checker.
+func TestFailureBlock(t *testing.T) {
+	c := qt.New(t) // want `qtlint: c is made by qt.New but never asserts, runs a subtest or reaches a helper that does; no fix: t.Fatal could assert through it instead`
+	_ = c
+	if _, err := parse("x"); err != nil { // want `qtlint: use c.Assert\(err, qt.IsNil, qt.Commentf\(...\)\) instead of t.Fatal\(...\)`
+		t.Fatal(err)
+	}
+}
+
+// Assertions that come after a return never run.
+func TestAfterReturn(t *testing.T) {
+	c := qt.New(t) // want `qtlint: c is made by qt.New but never asserts, runs a subtest or reaches a helper that does; no fix: it is still named after a return, in code that never runs`
+	n, _ := parse("x")
+	t.Log(n)
+	return
+	c.Check(n, qt.Equals, 1)
+}
+
+// A checker kept for something other than an assertion.
+func TestOtherUse(t *testing.T) {
+	c := qt.New(t) // want `qtlint: c is made by qt.New but never asserts, runs a subtest or reaches a helper that does; no fix: c.Setenv still uses it`
+	c.Setenv("HOME", "/tmp")
+	t.Log(os.Getenv("HOME"))
+}
+
+func setHome(c *qt.C) {
+	c.Setenv("HOME", "/tmp")
+}
+
+func TestHelperDoesNotAssert(t *testing.T) {
+	c := qt.New(t) // want `qtlint: c is made by qt.New but never asserts, runs a subtest or reaches a helper that does; no fix: setHome still uses it`
+	setHome(c)
+}
+
+// A subtest closure's checker is checked as the test's is.
+func TestSubtest(t *testing.T) {
+	t.Run("sub", func(t *testing.T) {
+		c := qt.New( // want `qtlint: c is made by qt.New but never asserts`
+			t)
+		_ = c
+		t.Log("sub")
+	})
+}
+
+// Checkers that assert, run a subtest, or reach a helper that asserts.
+func checkParse(c *qt.C, s string) {
+	_, err := parse(s)
+	c.Check(err, qt.IsNil)
+}
+
+func TestUsed(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(1, qt.Equals, 1)
+}
+
+func TestUsedCheck(t *testing.T) {
+	c := qt.New(t)
+	if _, err := parse("x"); err != nil {
+		c.Check(err, qt.IsNil)
+		return
+	}
+}
+
+func TestUsedRun(t *testing.T) {
+	c := qt.New(t)
+	c.Run("sub", func(c *qt.C) {
+		c.Assert(1, qt.Equals, 1)
+	})
+}
+
+func TestUsedByHelper(t *testing.T) {
+	c := qt.New(t)
+	checkParse(c, "x")
+}
+
+func TestHandedOn(t *testing.T) {
+	c := qt.New(t)
+	var tb testing.TB = c
+	tb.Log("x")
+}
+
+// Only test functions are checked.
+func helper(t *testing.T) {
+	c := qt.New(t)
+	_ = os.Getenv("HOME")
+	c.Setenv("HOME", "/tmp")
+}
+
+func Testable(t *testing.T) {
+	c := qt.New(t)
+	c.Setenv("HOME", "/tmp")
+}
+
+func BenchmarkLeftOver(b *testing.B) {
+	c := qt.New( // want `qtlint: c is made by qt.New but never asserts`
+		b)
+	_ = c
+	for i := 0; i < b.N; i++ {
+		_, _ = parse("x")
+	}
+}
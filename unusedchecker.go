@@ -0,0 +1,313 @@
+package qtlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// unusedChecker is one *qt.C a test makes and never asserts with.
+type unusedChecker struct {
+	obj    types.Object
+	origin qtCOrigin
+	// reason says why there is no fix, and edits is the fix otherwise.
+	reason string
+	edits  []analysis.TextEdit
+}
+
+// checkUnusedChecker checks for a *qt.C that a Test, Benchmark or Fuzz
+// function, or a subtest within one, makes with qt.New and then does nothing
+// with that a *qt.C is for: no Assert, Check or Run is called on it, and no
+// helper it is handed does so, as far as calleeReach can read the helper. A
+// checker handed anywhere the rule cannot follow is taken to be used.
+//
+// A checker named nowhere does not compile, so the one left behind is kept
+// alive by _ = c, which is not counted as a use. Code after a return in the
+// same block never runs, so an assertion there does not count either. It
+// still names the checker, though, and so does a call to another of its
+// methods, such as c.Setenv; the declaration then cannot go without breaking
+// the build, and the report comes without a fix.
+//
+// The fix removes the declaration, with declRemovalEdits, every _ = c, and
+// the file's quicktest import when nothing else in the file names it.
+func (*analyzer) checkUnusedChecker(pass *analysis.Pass) {
+	var callees *calleeReach
+	for _, file := range pass.Files {
+		var unused []*unusedChecker
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Body == nil || !isTestFuncName(fn.Name.Name) {
+				continue
+			}
+			origins := collectQtCOrigins(pass, fn)
+			if len(origins) == 0 {
+				continue
+			}
+			if callees == nil {
+				callees = newCalleeReach(pass)
+			}
+			dead := unreachableStmts(fn.Body)
+			objs := make([]types.Object, 0, len(origins))
+			for obj := range origins {
+				objs = append(objs, obj)
+			}
+			sort.Slice(objs, func(i, j int) bool { return objs[i].Pos() < objs[j].Pos() })
+			for _, obj := range objs {
+				if u := findUnusedChecker(pass, callees, fn, dead, obj, origins[obj]); u != nil {
+					unused = append(unused, u)
+				}
+			}
+		}
+		reportUnusedCheckers(pass, file, unused)
+	}
+}
+
+// isTestFuncName reports whether name is one the go tool runs as a test, a
+// benchmark or a fuzz test: the prefix, then nothing or anything but a lower
+// case letter.
+func isTestFuncName(name string) bool {
+	for _, prefix := range []string{"Test", "Benchmark", "Fuzz"} {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		rest := name[len(prefix):]
+		if rest == "" {
+			return true
+		}
+		r, _ := utf8.DecodeRuneInString(rest)
+		return !unicode.IsLower(r)
+	}
+	return false
+}
+
+// unreachableStmts returns the statements that follow a return in the same
+// block within body.
+func unreachableStmts(body *ast.BlockStmt) []ast.Stmt {
+	var dead []ast.Stmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		default:
+			return true
+		}
+		for i, stmt := range list {
+			if _, ok := stmt.(*ast.ReturnStmt); ok {
+				dead = append(dead, list[i+1:]...)
+				break
+			}
+		}
+		return true
+	})
+	return dead
+}
+
+// within reports whether pos lies inside any of stmts.
+func within(stmts []ast.Stmt, pos token.Pos) bool {
+	for _, stmt := range stmts {
+		if stmt.Pos() <= pos && pos < stmt.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// findUnusedChecker reports obj, declared within root by qt.New, when nothing
+// reachable asserts with it, and decides the fix.
+func findUnusedChecker(pass *analysis.Pass, callees *calleeReach, root *ast.FuncDecl, dead []ast.Stmt, obj types.Object, origin qtCOrigin) *unusedChecker {
+	used, deadUse := false, false
+	// other names the first reachable use that is not an assertion, and
+	// blanks are the _ = c statements that keep an unused c compiling.
+	other := ""
+	var blanks []*ast.AssignStmt
+	inspectWithParent(root, func(n, parent ast.Node) {
+		ident, ok := n.(*ast.Ident)
+		if !ok || used || pass.TypesInfo.Uses[ident] != obj {
+			return
+		}
+		if within(dead, ident.Pos()) {
+			deadUse = true
+			return
+		}
+		if assign, ok := parent.(*ast.AssignStmt); ok && isBlankAssign(assign, ident) {
+			blanks = append(blanks, assign)
+			return
+		}
+		if sel, ok := parent.(*ast.SelectorExpr); ok && sel.X == ident {
+			switch sel.Sel.Name {
+			case "Assert", "Check", "Run":
+				used = true
+			default:
+				if other == "" {
+					other = ident.Name + "." + sel.Sel.Name
+				}
+			}
+			return
+		}
+		if reach, ok := followedCallReach(callees, parent, ident); ok && !reach.checks && !reach.handedOn {
+			if helper, ok := formatExpr(pass, parent.(*ast.CallExpr).Fun); ok && other == "" {
+				other = helper
+			}
+			return
+		}
+		// Handed to a helper that asserts with it, or somewhere the rule
+		// cannot follow.
+		used = true
+	})
+	if used {
+		return nil
+	}
+
+	u := &unusedChecker{obj: obj, origin: origin}
+	fails := failsThrough(pass, root, dead, origin)
+	switch {
+	case other != "":
+		u.reason = fmt.Sprintf("; no fix: %s still uses it", other)
+	case deadUse:
+		u.reason = "; no fix: it is still named after a return, in code that never runs"
+	case fails != "":
+		u.reason = fmt.Sprintf("; no fix: %s could assert through it instead", fails)
+	default:
+		if _, ok := stripParens(origin.arg).(*ast.Ident); !ok {
+			u.reason = "; no fix: removing qt.New would drop the expression it is given"
+			break
+		}
+		u.edits, u.reason = declRemovalEdits(pass, root, obj)
+		for _, assign := range blanks {
+			if u.reason != "" {
+				break
+			}
+			start, end, ok := wholeLineSpan(pass, assign)
+			if !ok {
+				u.reason = fmt.Sprintf("; no fix: _ = %s shares its line, so removing it would take that with it", obj.Name())
+				break
+			}
+			u.edits = append(u.edits, analysis.TextEdit{Pos: start, End: end})
+		}
+	}
+	return u
+}
+
+// failsThrough returns the first reachable t.Fatal, t.Error or their
+// formatting forms within root on the test the checker was made from, or "".
+// The failure-block rules suggest asserting through the checker in its place,
+// and removing the checker would leave that fix nothing to assert through.
+func failsThrough(pass *analysis.Pass, root ast.Node, dead []ast.Stmt, origin qtCOrigin) string {
+	handle := testHandleOf(pass, origin.arg)
+	if handle == nil {
+		return ""
+	}
+	found := ""
+	ast.Inspect(root, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || found != "" {
+			return found == ""
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || within(dead, call.Pos()) || testHandleOf(pass, sel.X) != handle {
+			return true
+		}
+		switch sel.Sel.Name {
+		case "Fatal", "Fatalf", "Error", "Errorf":
+			found, _ = formatExpr(pass, sel)
+		}
+		return true
+	})
+	return found
+}
+
+// isBlankAssign reports whether assign is _ = ident, which uses ident only to
+// keep it from being reported as unused.
+func isBlankAssign(assign *ast.AssignStmt, ident *ast.Ident) bool {
+	if assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 || stripParens(assign.Rhs[0]) != ast.Expr(ident) {
+		return false
+	}
+	blank, ok := assign.Lhs[0].(*ast.Ident)
+	return ok && blank.Name == "_"
+}
+
+// reportUnusedCheckers reports the unused checkers of one file. Removing
+// them can leave the file's quicktest import with nothing to qualify, and the
+// fix that removes the last one has to remove the import with it.
+func reportUnusedCheckers(pass *analysis.Pass, file *ast.File, unused []*unusedChecker) {
+	var fixable []*unusedChecker
+	for _, u := range unused {
+		if u.reason == "" {
+			fixable = append(fixable, u)
+		}
+	}
+	if len(fixable) > 0 && !namesQuicktestOutside(pass, file, fixable) {
+		switch spec := importSpecFor(file, quicktestPkgPath); {
+		case len(fixable) > 1:
+			for _, u := range fixable {
+				u.reason = "; no fix: removing every unused qt.New in the file would leave its quicktest import unused"
+			}
+		case spec == nil:
+			fixable[0].reason = "; no fix: the quicktest import would be left unused"
+		default:
+			var node ast.Node = spec
+			if gen := importDeclOf(file, spec); gen != nil && len(gen.Specs) == 1 {
+				node = gen
+			}
+			start, end, ok := wholeLineSpan(pass, node)
+			if !ok {
+				fixable[0].reason = "; no fix: the quicktest import would be left unused, and it shares its line"
+				break
+			}
+			fixable[0].edits = append(fixable[0].edits, analysis.TextEdit{Pos: start, End: end})
+		}
+	}
+
+	for _, u := range unused {
+		diag := analysis.Diagnostic{
+			Pos: u.origin.decl.Pos(),
+			End: u.origin.decl.End(),
+			Message: fmt.Sprintf("qtlint: %s is made by qt.New but never asserts, runs a subtest or reaches a helper that does",
+				u.obj.Name()) + u.reason,
+		}
+		if u.reason == "" {
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "Remove the unused qt.New",
+				TextEdits: u.edits,
+			}}
+		}
+		pass.Report(diag)
+	}
+}
+
+// namesQuicktestOutside reports whether anything in file outside the
+// declarations of the checkers being removed qualifies a name with the
+// quicktest import.
+func namesQuicktestOutside(pass *analysis.Pass, file *ast.File, removed []*unusedChecker) bool {
+	found := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok || found {
+			return !found
+		}
+		pkg, ok := pass.TypesInfo.Uses[ident].(*types.PkgName)
+		if !ok || pkg.Imported().Path() != quicktestPkgPath {
+			return true
+		}
+		for _, u := range removed {
+			if u.origin.decl.Pos() <= ident.Pos() && ident.Pos() < u.origin.decl.End() {
+				return true
+			}
+		}
+		found = true
+		return false
+	})
+	return found
+}